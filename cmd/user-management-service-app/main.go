@@ -3,15 +3,21 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 
 	"github.com/coneno/logger"
+	"github.com/influenzanet/go-utils/pkg/global_types"
 	"github.com/influenzanet/user-management-service/internal/config"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/dbs/sqldb"
 	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	gc "github.com/influenzanet/user-management-service/pkg/grpc/clients"
 	"github.com/influenzanet/user-management-service/pkg/grpc/service"
+	"github.com/influenzanet/user-management-service/pkg/migrations"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/timer_event"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
 )
 
 const userManagementTimerEventFrequency = 90 * 60 // seconds
@@ -31,8 +37,23 @@ func main() {
 	defer close()
 	clients.LoggingService = loggingClient
 
-	userDBService := userdb.NewUserDBService(conf.UserDBConfig)
-	globalDBService := globaldb.NewGlobalDBService(conf.GlobalDBConfig)
+	if conf.JWTKeyring != nil {
+		tokens.UseKeyring(conf.JWTKeyring)
+		currentKey := conf.JWTKeyring.CurrentKey()
+		logger.Info.Printf("signing access tokens with %s, key ID %s", currentKey.Method(), currentKey.ID)
+	}
+	if conf.JWKSHTTPPort != "" {
+		go serveJWKS(conf.JWKSHTTPPort)
+	}
+
+	userDBService, globalDBService := initDBServices(conf)
+
+	if conf.RunMigrationsOnStartup {
+		runner := migrations.NewRunner(userDBService, globalDBService, migrations.UserMigrations)
+		if _, err := runner.Run(context.Background(), false); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Start timer thread
 	userTimerService := timer_event.NewUserManagmentTimerService(
@@ -42,6 +63,8 @@ func main() {
 		clients,
 		conf.CleanUpUnverifiedUsersAfter,
 		conf.ReminderToUnverifiedAccountsAfter,
+		conf.RefreshTokens,
+		clock.Real,
 	)
 
 	// Start server thread
@@ -49,15 +72,74 @@ func main() {
 
 	userTimerService.Run(ctx)
 
-	if err := service.RunServer(
-		ctx,
-		conf.Port,
-		clients,
-		userDBService,
-		globalDBService,
-		conf.Intervals,
-		conf.NewUserCountLimit,
-	); err != nil {
+	if err := service.RunServer(ctx, conf.Port, service.Config{
+		Clients:               clients,
+		UserDBService:         userDBService,
+		GlobalDBService:       globalDBService,
+		Intervals:             conf.Intervals,
+		AccessTokens:          conf.AccessTokens,
+		NewUserCountLimit:     conf.NewUserCountLimit,
+		TOTP:                  conf.TOTP,
+		RelyingParty:          conf.WebAuthn,
+		PasswordlessLogin:     conf.PasswordlessLogin,
+		RefreshTokens:         conf.RefreshTokens,
+		ExternalIDPs:          conf.ExternalIDPs,
+		Clock:                 clock.Real,
+		IntrospectionHTTPPort: conf.IntrospectionHTTPPort,
+	}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// initDBServices connects to the DB backend selected in the config
+func initDBServices(conf config.Config) (userdb.UserStore, globaldb.GlobalStore) {
+	if conf.DBBackend == config.DB_BACKEND_MONGODB {
+		userDBService := userdb.NewUserDBService(conf.UserDBConfig, clock.Real, conf.FieldEncryption)
+		globalDBService := globaldb.NewGlobalDBService(conf.GlobalDBConfig, conf.FieldEncryption)
+		ensureDBIndexes(userDBService, globalDBService)
+		return userDBService, globalDBService
+	}
+
+	db, err := sqldb.Connect(conf.SQLDBConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	userDBService := sqldb.NewUserDBService(db, conf.SQLDBConfig, clock.Real)
+	globalDBService := sqldb.NewGlobalDBService(db, conf.SQLDBConfig)
+	for _, instanceID := range conf.SQLDBInstances {
+		if err := globalDBService.AddInstance(context.Background(), global_types.Instance{InstanceID: instanceID}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return userDBService, globalDBService
+}
+
+// ensureDBIndexes creates missing indexes for the global DB and the user DB of each instance. Failures are
+// logged, since the service can still run without the indexes.
+func ensureDBIndexes(userDBService *userdb.UserDBService, globalDBService *globaldb.GlobalDBService) {
+	ctx := context.Background()
+	if _, err := globalDBService.EnsureIndexes(ctx, false); err != nil {
+		logger.Error.Printf("global DB indexes: %v", err)
+	}
+
+	instances, err := globalDBService.GetAllInstances(ctx)
+	if err != nil {
+		logger.Error.Printf("could not load instances to ensure indexes: %v", err)
+		return
+	}
+	for _, instance := range instances {
+		if _, err := userDBService.EnsureIndexes(ctx, instance.InstanceID, false); err != nil {
+			logger.Error.Printf("user DB indexes: %v", err)
+		}
+	}
+}
+
+// serveJWKS publishes the public keys of the access tokens over HTTP for services that cannot use gRPC
+func serveJWKS(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/.well-known/jwks.json", tokens.JWKSHandler())
+	logger.Info.Printf("serving JWKS on port %s", port)
+	if err := http.ListenAndServe(":"+port, mux); err != nil {
 		log.Fatal(err)
 	}
 }
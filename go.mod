@@ -4,12 +4,15 @@ go 1.15
 
 require (
 	github.com/coneno/logger v1.2.1
+	github.com/fxamacker/cbor/v2 v2.4.0
 	github.com/golang-jwt/jwt/v4 v4.2.0
 	github.com/golang/mock v1.6.0
 	github.com/golang/protobuf v1.5.2
 	github.com/influenzanet/go-utils v0.2.10
 	github.com/influenzanet/logging-service v0.1.0
 	github.com/influenzanet/messaging-service v0.9.3
+	github.com/lib/pq v1.10.4
+	github.com/mattn/go-sqlite3 v1.14.12
 	go.mongodb.org/mongo-driver v1.8.0
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
 	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
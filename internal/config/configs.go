@@ -1,14 +1,22 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/dbs/sqldb"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
 	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/oidc"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
 )
 
 // Config is the structure that holds all global configuration data
@@ -19,12 +27,26 @@ type Config struct {
 		MessagingService string
 		LoggingService   string
 	}
+	DBBackend                         string
 	UserDBConfig                      models.DBConfig
 	GlobalDBConfig                    models.DBConfig
+	SQLDBConfig                       models.SQLDBConfig
+	SQLDBInstances                    []string
 	Intervals                         models.Intervals
+	AccessTokens                      models.AccessTokenConfig // iss and aud claims of the access tokens
 	NewUserCountLimit                 int64
 	CleanUpUnverifiedUsersAfter       int64
 	ReminderToUnverifiedAccountsAfter int64
+	RunMigrationsOnStartup            bool
+	FieldEncryption                   *fieldcrypt.Keyring
+	TOTP                              models.TOTPConfig
+	WebAuthn                          webauthn.RelyingParty
+	PasswordlessLogin                 models.PasswordlessLoginConfig
+	RefreshTokens                     models.RefreshTokenConfig
+	JWTKeyring                        *tokens.Keyring // nil for HS256 with JWT_TOKEN_KEY
+	JWKSHTTPPort                      string
+	IntrospectionHTTPPort             string
+	ExternalIDPs                      oidc.Config
 }
 
 func InitConfig() Config {
@@ -34,9 +56,19 @@ func InitConfig() Config {
 	conf.ServiceURLs.LoggingService = os.Getenv("ADDR_LOGGING_SERVICE")
 
 	conf.LogLevel = getLogLevel()
-	conf.UserDBConfig = getUserDBConfig()
-	conf.GlobalDBConfig = getGlobalDBConfig()
+	conf.DBBackend = getDBBackend()
+	if conf.DBBackend == DB_BACKEND_MONGODB {
+		conf.UserDBConfig = getUserDBConfig()
+		conf.GlobalDBConfig = getGlobalDBConfig()
+	} else {
+		conf.SQLDBConfig = getSQLDBConfig(conf.DBBackend)
+		conf.SQLDBInstances = getSQLDBInstances()
+	}
 	conf.Intervals = getIntervalsConfig()
+	conf.AccessTokens = models.AccessTokenConfig{
+		Issuer:   os.Getenv(ENV_JWT_ISSUER),
+		Audience: os.Getenv(ENV_JWT_AUDIENCE),
+	}
 
 	rl, err := strconv.Atoi(os.Getenv("NEW_USER_RATE_LIMIT"))
 	if err != nil {
@@ -55,9 +87,268 @@ func InitConfig() Config {
 		log.Fatal(ENV_SEND_REMINDER_TO_UNVERIFIED_USERS_AFTER + ": " + err.Error())
 	}
 	conf.ReminderToUnverifiedAccountsAfter = int64(reminderToUnverifiedAccountsAfter)
+
+	conf.RunMigrationsOnStartup = os.Getenv(ENV_RUN_MIGRATIONS_ON_STARTUP) == "true"
+	conf.FieldEncryption = getFieldEncryptionConfig()
+	if conf.FieldEncryption != nil && conf.DBBackend != DB_BACKEND_MONGODB {
+		log.Fatal("field encryption is only supported with the " + DB_BACKEND_MONGODB + " DB backend")
+	}
+	conf.TOTP = getTOTPConfig()
+	conf.WebAuthn = getWebAuthnConfig()
+	conf.PasswordlessLogin = getPasswordlessLoginConfig()
+	conf.RefreshTokens = getRefreshTokenConfig()
+	conf.JWTKeyring = getJWTKeyring()
+	conf.JWKSHTTPPort = os.Getenv(ENV_JWKS_HTTP_PORT)
+	conf.IntrospectionHTTPPort = os.Getenv(ENV_INTROSPECTION_HTTP_PORT)
+	conf.ExternalIDPs = getExternalIDPConfig()
+	return conf
+}
+
+// getJWTKeyring loads the keys for signing access tokens from a directory or file. Without keyring, a single private
+// key can be configured for the asymmetric signing methods.
+func getJWTKeyring() *tokens.Keyring {
+	currentKeyID := os.Getenv(ENV_JWT_SIGNING_KEY_ID)
+	if dir := os.Getenv(ENV_JWT_KEYRING_DIR); dir != "" {
+		keyring, err := tokens.LoadKeyringFromDir(dir, currentKeyID)
+		if err != nil {
+			log.Fatal(ENV_JWT_KEYRING_DIR + ": " + err.Error())
+		}
+		return keyring
+	}
+	if path := os.Getenv(ENV_JWT_KEYRING_FILE); path != "" {
+		keyring, err := tokens.LoadKeyringFromFile(path, currentKeyID)
+		if err != nil {
+			log.Fatal(ENV_JWT_KEYRING_FILE + ": " + err.Error())
+		}
+		return keyring
+	}
+
+	method := os.Getenv(ENV_JWT_SIGNING_METHOD)
+	if method == "" || method == tokens.SIGNING_METHOD_HS256 {
+		return nil
+	}
+	pemData := []byte(os.Getenv(ENV_JWT_PRIVATE_KEY))
+	if path := os.Getenv(ENV_JWT_PRIVATE_KEY_FILE); path != "" {
+		var err error
+		pemData, err = ioutil.ReadFile(path)
+		if err != nil {
+			log.Fatal(ENV_JWT_PRIVATE_KEY_FILE + ": " + err.Error())
+		}
+	}
+	if len(pemData) == 0 {
+		log.Fatal(ENV_JWT_SIGNING_METHOD + " " + method + " needs " + ENV_JWT_PRIVATE_KEY + " or " + ENV_JWT_PRIVATE_KEY_FILE)
+	}
+	key, err := tokens.ParseSigningKey(method, pemData)
+	if err != nil {
+		log.Fatal("JWT signing key: " + err.Error())
+	}
+	keyring, err := tokens.NewKeyring([]*tokens.SigningKey{key}, "")
+	if err != nil {
+		log.Fatal("JWT signing key: " + err.Error())
+	}
+	return keyring
+}
+
+func getRefreshTokenConfig() models.RefreshTokenConfig {
+	conf := models.RefreshTokenConfig{
+		ReuseNotification: os.Getenv(ENV_REFRESH_TOKEN_REUSE_NOTIFICATION) == "true",
+		MaxSessions:       models.MaxRefreshTokens,
+	}
+	if v := os.Getenv(ENV_MAX_SESSIONS_PER_USER); v != "" {
+		maxSessions, err := strconv.Atoi(v)
+		if err != nil || maxSessions < 1 {
+			log.Fatal(ENV_MAX_SESSIONS_PER_USER + ": positive number expected")
+		}
+		conf.MaxSessions = maxSessions
+	}
+	if v := os.Getenv(ENV_REFRESH_TOKEN_HASH_KEY); v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			log.Fatal(ENV_REFRESH_TOKEN_HASH_KEY + ": " + err.Error())
+		}
+		conf.HashKey = key
+	} else {
+		log.Println("WARNING: " + ENV_REFRESH_TOKEN_HASH_KEY + " is not set, refresh tokens are stored as plain SHA-256")
+	}
+	conf.Lifetime = models.SessionLifetime{
+		IdleTimeout: getSessionLifetimeSeconds(ENV_SESSION_IDLE_TIMEOUT, os.Getenv(ENV_SESSION_IDLE_TIMEOUT)),
+		MaxAge:      getSessionLifetimeSeconds(ENV_SESSION_MAX_AGE, os.Getenv(ENV_SESSION_MAX_AGE)),
+	}
+	conf.RoleLifetimes = getSessionLifetimesByRole()
+	return conf
+}
+
+// getSessionLifetimesByRole reads a comma separated list of <role>:<idle timeout>:<max age>, both in seconds
+func getSessionLifetimesByRole() map[string]models.SessionLifetime {
+	lifetimes := map[string]models.SessionLifetime{}
+	for _, entry := range strings.Split(os.Getenv(ENV_SESSION_LIFETIMES_BY_ROLE), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			log.Fatal(ENV_SESSION_LIFETIMES_BY_ROLE + ": entries must have the format <role>:<idle timeout>:<max age>")
+		}
+		lifetimes[parts[0]] = models.SessionLifetime{
+			IdleTimeout: getSessionLifetimeSeconds(ENV_SESSION_LIFETIMES_BY_ROLE, parts[1]),
+			MaxAge:      getSessionLifetimeSeconds(ENV_SESSION_LIFETIMES_BY_ROLE, parts[2]),
+		}
+	}
+	return lifetimes
+}
+
+// getSessionLifetimeSeconds parses a lifetime of the env variable name, empty values and 0 mean no limit
+func getSessionLifetimeSeconds(name string, value string) int64 {
+	if value == "" {
+		return 0
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		log.Fatal(name + ": number of seconds expected")
+	}
+	return seconds
+}
+
+// getPasswordlessLoginConfig reads the instances as comma separated list of <instance-id> (link and code allowed) or
+// <instance-id>:<method>
+func getPasswordlessLoginConfig() models.PasswordlessLoginConfig {
+	conf := models.PasswordlessLoginConfig{}
+	for _, entry := range strings.Split(os.Getenv(ENV_PASSWORDLESS_LOGIN_INSTANCES), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) == 1 {
+			conf[parts[0]] = []string{models.PASSWORDLESS_METHOD_LINK, models.PASSWORDLESS_METHOD_CODE}
+			continue
+		}
+		switch parts[1] {
+		case models.PASSWORDLESS_METHOD_LINK, models.PASSWORDLESS_METHOD_CODE:
+			conf[parts[0]] = append(conf[parts[0]], parts[1])
+		default:
+			log.Fatal(ENV_PASSWORDLESS_LOGIN_INSTANCES + ": unsupported method " + parts[1])
+		}
+	}
+	return conf
+}
+
+func getWebAuthnConfig() webauthn.RelyingParty {
+	rp := webauthn.RelyingParty{
+		ID:   os.Getenv(ENV_WEBAUTHN_RP_ID),
+		Name: os.Getenv(ENV_WEBAUTHN_RP_NAME),
+	}
+	if rp.ID == "" {
+		return rp
+	}
+	if rp.Name == "" {
+		rp.Name = defaultWebAuthnRPName
+	}
+	for _, origin := range strings.Split(os.Getenv(ENV_WEBAUTHN_RP_ORIGINS), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			rp.Origins = append(rp.Origins, origin)
+		}
+	}
+	if len(rp.Origins) < 1 {
+		log.Fatal(ENV_WEBAUTHN_RP_ORIGINS + " is not set.")
+	}
+	return rp
+}
+
+// getExternalIDPConfig loads the identity providers whose ID tokens LoginWithExternalIDP accepts
+func getExternalIDPConfig() oidc.Config {
+	conf := oidc.Config{
+		TrustCaller: os.Getenv(ENV_EXTERNAL_IDP_TRUST_CALLER) == "true",
+	}
+	path := os.Getenv(ENV_EXTERNAL_IDP_CONFIG_FILE)
+	if path == "" {
+		return conf
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(ENV_EXTERNAL_IDP_CONFIG_FILE + ": " + err.Error())
+	}
+	conf.Providers, err = oidc.ParseProviders(data)
+	if err != nil {
+		log.Fatal(ENV_EXTERNAL_IDP_CONFIG_FILE + ": " + err.Error())
+	}
 	return conf
 }
 
+func getTOTPConfig() models.TOTPConfig {
+	keys, err := fieldcrypt.ParseSecretKeyring(os.Getenv(ENV_TOTP_SECRET_KEYS), os.Getenv(ENV_TOTP_SECRET_CURRENT_KEY))
+	if err != nil {
+		log.Fatal("TOTP secret keys: " + err.Error())
+	}
+	issuer := os.Getenv(ENV_TOTP_ISSUER)
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+	return models.TOTPConfig{
+		Issuer:     issuer,
+		SecretKeys: keys,
+	}
+}
+
+func getDBBackend() string {
+	backend := os.Getenv(ENV_DB_BACKEND)
+	switch backend {
+	case "":
+		return DB_BACKEND_MONGODB
+	case DB_BACKEND_SQLITE:
+		if !sqldb.SQLiteAvailable {
+			log.Fatal(ENV_DB_BACKEND + ": " + backend + " is not available, the service was built without cgo")
+		}
+		return backend
+	case DB_BACKEND_MONGODB, DB_BACKEND_POSTGRES:
+		return backend
+	default:
+		log.Fatal(ENV_DB_BACKEND + ": unsupported value " + backend)
+		return ""
+	}
+}
+
+func getSQLDBConfig(driver string) models.SQLDBConfig {
+	dsn := os.Getenv(ENV_SQL_DB_DSN)
+	if dsn == "" {
+		log.Fatal(ENV_SQL_DB_DSN + " is not set.")
+	}
+	timeout, err := strconv.Atoi(os.Getenv("DB_TIMEOUT"))
+	if err != nil {
+		log.Fatal("DB_TIMEOUT: " + err.Error())
+	}
+	return models.SQLDBConfig{
+		Driver:  driver,
+		DSN:     dsn,
+		Timeout: timeout,
+	}
+}
+
+// getSQLDBInstances returns the instance IDs to register in the SQL DB
+func getSQLDBInstances() []string {
+	instances := []string{}
+	for _, instanceID := range strings.Split(os.Getenv(ENV_SQL_DB_INSTANCES), ",") {
+		if instanceID = strings.TrimSpace(instanceID); instanceID != "" {
+			instances = append(instances, instanceID)
+		}
+	}
+	return instances
+}
+
+// getFieldEncryptionConfig reads the keys for encrypting personal data, returns nil if no keys are configured
+func getFieldEncryptionConfig() *fieldcrypt.Keyring {
+	keyring, err := fieldcrypt.ParseKeyring(
+		os.Getenv(ENV_FIELD_ENCRYPTION_KEYS),
+		os.Getenv(ENV_FIELD_ENCRYPTION_CURRENT_KEY),
+		os.Getenv(ENV_FIELD_ENCRYPTION_INDEX_KEY),
+	)
+	if err != nil {
+		log.Fatal("field encryption: " + err.Error())
+	}
+	return keyring
+}
+
 func getLogLevel() logger.LogLevel {
 	switch os.Getenv("LOG_LEVEL") {
 	case "debug":
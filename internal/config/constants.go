@@ -6,9 +6,59 @@ const (
 
 	ENV_USE_NO_CURSOR_TIMEOUT                   = "USE_NO_CURSOR_TIMEOUT"
 	ENV_SEND_REMINDER_TO_UNVERIFIED_USERS_AFTER = "SEND_REMINDER_TO_UNVERIFIED_USERS_AFTER"
+	ENV_RUN_MIGRATIONS_ON_STARTUP               = "RUN_MIGRATIONS_ON_STARTUP"
+
+	ENV_FIELD_ENCRYPTION_KEYS        = "FIELD_ENCRYPTION_KEYS"
+	ENV_FIELD_ENCRYPTION_CURRENT_KEY = "FIELD_ENCRYPTION_CURRENT_KEY"
+	ENV_FIELD_ENCRYPTION_INDEX_KEY   = "FIELD_ENCRYPTION_INDEX_KEY"
+
+	ENV_TOTP_ISSUER             = "TOTP_ISSUER"
+	ENV_TOTP_SECRET_KEYS        = "TOTP_SECRET_KEYS"
+	ENV_TOTP_SECRET_CURRENT_KEY = "TOTP_SECRET_CURRENT_KEY"
+
+	ENV_WEBAUTHN_RP_ID      = "WEBAUTHN_RP_ID"
+	ENV_WEBAUTHN_RP_NAME    = "WEBAUTHN_RP_NAME"
+	ENV_WEBAUTHN_RP_ORIGINS = "WEBAUTHN_RP_ORIGINS"
+
+	ENV_PASSWORDLESS_LOGIN_INSTANCES = "PASSWORDLESS_LOGIN_INSTANCES"
+
+	ENV_REFRESH_TOKEN_REUSE_NOTIFICATION = "REFRESH_TOKEN_REUSE_NOTIFICATION"
+	ENV_MAX_SESSIONS_PER_USER            = "MAX_SESSIONS_PER_USER"
+	ENV_REFRESH_TOKEN_HASH_KEY           = "REFRESH_TOKEN_HASH_KEY"
+	ENV_SESSION_IDLE_TIMEOUT             = "SESSION_IDLE_TIMEOUT"
+	ENV_SESSION_MAX_AGE                  = "SESSION_MAX_AGE"
+	ENV_SESSION_LIFETIMES_BY_ROLE        = "SESSION_LIFETIMES_BY_ROLE"
+
+	ENV_JWT_SIGNING_METHOD   = "JWT_SIGNING_METHOD"
+	ENV_JWT_PRIVATE_KEY      = "JWT_PRIVATE_KEY"
+	ENV_JWT_PRIVATE_KEY_FILE = "JWT_PRIVATE_KEY_FILE"
+	ENV_JWT_KEYRING_DIR      = "JWT_KEYRING_DIR"
+	ENV_JWT_KEYRING_FILE     = "JWT_KEYRING_FILE"
+	ENV_JWT_SIGNING_KEY_ID   = "JWT_SIGNING_KEY_ID"
+	ENV_JWKS_HTTP_PORT       = "JWKS_HTTP_PORT"
+	ENV_JWT_ISSUER           = "JWT_ISSUER"
+	ENV_JWT_AUDIENCE         = "JWT_AUDIENCE"
+
+	ENV_INTROSPECTION_HTTP_PORT = "INTROSPECTION_HTTP_PORT"
+
+	ENV_EXTERNAL_IDP_CONFIG_FILE  = "EXTERNAL_IDP_CONFIG_FILE"
+	ENV_EXTERNAL_IDP_TRUST_CALLER = "EXTERNAL_IDP_TRUST_CALLER"
+
+	ENV_DB_BACKEND       = "DB_BACKEND"
+	ENV_SQL_DB_DSN       = "SQL_DB_DSN"
+	ENV_SQL_DB_INSTANCES = "SQL_DB_INSTANCES"
+)
+
+// Values of DB_BACKEND, the SQL backends are named after their driver
+const (
+	DB_BACKEND_MONGODB  = "mongodb"
+	DB_BACKEND_POSTGRES = "postgres"
+	DB_BACKEND_SQLITE   = "sqlite3"
 )
 
 const (
 	defaultVerificationCodeLifetime = 15 * 60 // for 2FA 6 digit code
 	defaultTokenExpirationMin       = 55
+	defaultTOTPIssuer               = "Influenzanet"
+	defaultWebAuthnRPName           = "Influenzanet"
 )
@@ -235,11 +235,14 @@ type LoginWithEmailMsg struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email            string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password         string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	InstanceId       string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	AsParticipant    bool   `protobuf:"varint,4,opt,name=as_participant,json=asParticipant,proto3" json:"as_participant,omitempty"`
-	VerificationCode string `protobuf:"bytes,5,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
+	Email              string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password           string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	InstanceId         string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	AsParticipant      bool   `protobuf:"varint,4,opt,name=as_participant,json=asParticipant,proto3" json:"as_participant,omitempty"`
+	VerificationCode   string `protobuf:"bytes,5,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
+	PasskeyChallengeId string `protobuf:"bytes,6,opt,name=passkey_challenge_id,json=passkeyChallengeId,proto3" json:"passkey_challenge_id,omitempty"` // instead of the verification code, from StartPasskeyLogin
+	PasskeyCredential  string `protobuf:"bytes,7,opt,name=passkey_credential,json=passkeyCredential,proto3" json:"passkey_credential,omitempty"`      // PublicKeyCredential from navigator.credentials.get() as JSON
+	RecoveryCode       string `protobuf:"bytes,8,opt,name=recovery_code,json=recoveryCode,proto3" json:"recovery_code,omitempty"`                     // instead of the verification code
 }
 
 func (x *LoginWithEmailMsg) Reset() {
@@ -309,6 +312,27 @@ func (x *LoginWithEmailMsg) GetVerificationCode() string {
 	return ""
 }
 
+func (x *LoginWithEmailMsg) GetPasskeyChallengeId() string {
+	if x != nil {
+		return x.PasskeyChallengeId
+	}
+	return ""
+}
+
+func (x *LoginWithEmailMsg) GetPasskeyCredential() string {
+	if x != nil {
+		return x.PasskeyCredential
+	}
+	return ""
+}
+
+func (x *LoginWithEmailMsg) GetRecoveryCode() string {
+	if x != nil {
+		return x.RecoveryCode
+	}
+	return ""
+}
+
 type LoginWithExternalIDPMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -320,6 +344,12 @@ type LoginWithExternalIDPMsg struct {
 	Customer   string `protobuf:"bytes,4,opt,name=customer,proto3" json:"customer,omitempty"`
 	Idp        string `protobuf:"bytes,5,opt,name=idp,proto3" json:"idp,omitempty"`
 	GroupInfo  string `protobuf:"bytes,6,opt,name=group_info,json=groupInfo,proto3" json:"group_info,omitempty"`
+	// OIDC ID token of the identity provider, email and groups are taken from it
+	IdToken string `protobuf:"bytes,7,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	// nonce the client sent in the authentication request. It is only compared with the nonce of the token: the gateway
+	// must create it, bind it to the browser session and pass on the nonce of that session, not one taken from the
+	// client, otherwise a captured ID token can be replayed.
+	Nonce string `protobuf:"bytes,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
 }
 
 func (x *LoginWithExternalIDPMsg) Reset() {
@@ -396,17 +426,31 @@ func (x *LoginWithExternalIDPMsg) GetGroupInfo() string {
 	return ""
 }
 
-type AutoValidateReq struct {
+func (x *LoginWithExternalIDPMsg) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+func (x *LoginWithExternalIDPMsg) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type StartPasskeyLoginMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TempToken   string `protobuf:"bytes,1,opt,name=temp_token,json=tempToken,proto3" json:"temp_token,omitempty"`
-	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Email      string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // optional, to allow only the passkeys of this account
 }
 
-func (x *AutoValidateReq) Reset() {
-	*x = AutoValidateReq{}
+func (x *StartPasskeyLoginMsg) Reset() {
+	*x = StartPasskeyLoginMsg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,13 +458,13 @@ func (x *AutoValidateReq) Reset() {
 	}
 }
 
-func (x *AutoValidateReq) String() string {
+func (x *StartPasskeyLoginMsg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AutoValidateReq) ProtoMessage() {}
+func (*StartPasskeyLoginMsg) ProtoMessage() {}
 
-func (x *AutoValidateReq) ProtoReflect() protoreflect.Message {
+func (x *StartPasskeyLoginMsg) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -432,38 +476,38 @@ func (x *AutoValidateReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AutoValidateReq.ProtoReflect.Descriptor instead.
-func (*AutoValidateReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartPasskeyLoginMsg.ProtoReflect.Descriptor instead.
+func (*StartPasskeyLoginMsg) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AutoValidateReq) GetTempToken() string {
+func (x *StartPasskeyLoginMsg) GetInstanceId() string {
 	if x != nil {
-		return x.TempToken
+		return x.InstanceId
 	}
 	return ""
 }
 
-func (x *AutoValidateReq) GetAccessToken() string {
+func (x *StartPasskeyLoginMsg) GetEmail() string {
 	if x != nil {
-		return x.AccessToken
+		return x.Email
 	}
 	return ""
 }
 
-type AutoValidateResponse struct {
+type LoginWithPasskeyMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AccountId        string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	VerificationCode string `protobuf:"bytes,2,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
-	IsSameUser       bool   `protobuf:"varint,3,opt,name=is_same_user,json=isSameUser,proto3" json:"is_same_user,omitempty"`
-	InstanceId       string `protobuf:"bytes,4,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	InstanceId    string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ChallengeId   string `protobuf:"bytes,2,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"` // from StartPasskeyLogin
+	Credential    string `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`                      // PublicKeyCredential from navigator.credentials.get() as JSON
+	AsParticipant bool   `protobuf:"varint,4,opt,name=as_participant,json=asParticipant,proto3" json:"as_participant,omitempty"`
 }
 
-func (x *AutoValidateResponse) Reset() {
-	*x = AutoValidateResponse{}
+func (x *LoginWithPasskeyMsg) Reset() {
+	*x = LoginWithPasskeyMsg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,13 +515,13 @@ func (x *AutoValidateResponse) Reset() {
 	}
 }
 
-func (x *AutoValidateResponse) String() string {
+func (x *LoginWithPasskeyMsg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AutoValidateResponse) ProtoMessage() {}
+func (*LoginWithPasskeyMsg) ProtoMessage() {}
 
-func (x *AutoValidateResponse) ProtoReflect() protoreflect.Message {
+func (x *LoginWithPasskeyMsg) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -489,51 +533,51 @@ func (x *AutoValidateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AutoValidateResponse.ProtoReflect.Descriptor instead.
-func (*AutoValidateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginWithPasskeyMsg.ProtoReflect.Descriptor instead.
+func (*LoginWithPasskeyMsg) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *AutoValidateResponse) GetAccountId() string {
+func (x *LoginWithPasskeyMsg) GetInstanceId() string {
 	if x != nil {
-		return x.AccountId
+		return x.InstanceId
 	}
 	return ""
 }
 
-func (x *AutoValidateResponse) GetVerificationCode() string {
+func (x *LoginWithPasskeyMsg) GetChallengeId() string {
 	if x != nil {
-		return x.VerificationCode
+		return x.ChallengeId
 	}
 	return ""
 }
 
-func (x *AutoValidateResponse) GetIsSameUser() bool {
+func (x *LoginWithPasskeyMsg) GetCredential() string {
 	if x != nil {
-		return x.IsSameUser
+		return x.Credential
 	}
-	return false
+	return ""
 }
 
-func (x *AutoValidateResponse) GetInstanceId() string {
+func (x *LoginWithPasskeyMsg) GetAsParticipant() bool {
 	if x != nil {
-		return x.InstanceId
+		return x.AsParticipant
 	}
-	return ""
+	return false
 }
 
-type SendVerificationCodeReq struct {
+type PasswordlessLoginMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email      string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password   string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	InstanceId string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Email      string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Method     string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"` // "link" (default) or "code"
 }
 
-func (x *SendVerificationCodeReq) Reset() {
-	*x = SendVerificationCodeReq{}
+func (x *PasswordlessLoginMsg) Reset() {
+	*x = PasswordlessLoginMsg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -541,13 +585,13 @@ func (x *SendVerificationCodeReq) Reset() {
 	}
 }
 
-func (x *SendVerificationCodeReq) String() string {
+func (x *PasswordlessLoginMsg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendVerificationCodeReq) ProtoMessage() {}
+func (*PasswordlessLoginMsg) ProtoMessage() {}
 
-func (x *SendVerificationCodeReq) ProtoReflect() protoreflect.Message {
+func (x *PasswordlessLoginMsg) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -559,44 +603,46 @@ func (x *SendVerificationCodeReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendVerificationCodeReq.ProtoReflect.Descriptor instead.
-func (*SendVerificationCodeReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use PasswordlessLoginMsg.ProtoReflect.Descriptor instead.
+func (*PasswordlessLoginMsg) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SendVerificationCodeReq) GetEmail() string {
+func (x *PasswordlessLoginMsg) GetInstanceId() string {
 	if x != nil {
-		return x.Email
+		return x.InstanceId
 	}
 	return ""
 }
 
-func (x *SendVerificationCodeReq) GetPassword() string {
+func (x *PasswordlessLoginMsg) GetEmail() string {
 	if x != nil {
-		return x.Password
+		return x.Email
 	}
 	return ""
 }
 
-func (x *SendVerificationCodeReq) GetInstanceId() string {
+func (x *PasswordlessLoginMsg) GetMethod() string {
 	if x != nil {
-		return x.InstanceId
+		return x.Method
 	}
 	return ""
 }
 
-type LoginResponse struct {
+type PasswordlessLoginTokenMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token              *TokenResponse `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	User               *User          `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	SecondFactorNeeded bool           `protobuf:"varint,3,opt,name=second_factor_needed,json=secondFactorNeeded,proto3" json:"second_factor_needed,omitempty"`
+	InstanceId    string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // from the login link
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"` // with the code
+	Code          string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	AsParticipant bool   `protobuf:"varint,5,opt,name=as_participant,json=asParticipant,proto3" json:"as_participant,omitempty"`
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
+func (x *PasswordlessLoginTokenMsg) Reset() {
+	*x = PasswordlessLoginTokenMsg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -604,13 +650,13 @@ func (x *LoginResponse) Reset() {
 	}
 }
 
-func (x *LoginResponse) String() string {
+func (x *PasswordlessLoginTokenMsg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*PasswordlessLoginTokenMsg) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+func (x *PasswordlessLoginTokenMsg) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -622,44 +668,57 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PasswordlessLoginTokenMsg.ProtoReflect.Descriptor instead.
+func (*PasswordlessLoginTokenMsg) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *LoginResponse) GetToken() *TokenResponse {
+func (x *PasswordlessLoginTokenMsg) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *PasswordlessLoginTokenMsg) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *LoginResponse) GetUser() *User {
+func (x *PasswordlessLoginTokenMsg) GetEmail() string {
 	if x != nil {
-		return x.User
+		return x.Email
 	}
-	return nil
+	return ""
 }
 
-func (x *LoginResponse) GetSecondFactorNeeded() bool {
+func (x *PasswordlessLoginTokenMsg) GetCode() string {
 	if x != nil {
-		return x.SecondFactorNeeded
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PasswordlessLoginTokenMsg) GetAsParticipant() bool {
+	if x != nil {
+		return x.AsParticipant
 	}
 	return false
 }
 
-type UserReference struct {
+type AutoValidateReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token      *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	UserId     string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	InstanceId string                `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	TempToken   string `protobuf:"bytes,1,opt,name=temp_token,json=tempToken,proto3" json:"temp_token,omitempty"`
+	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 }
 
-func (x *UserReference) Reset() {
-	*x = UserReference{}
+func (x *AutoValidateReq) Reset() {
+	*x = AutoValidateReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,13 +726,13 @@ func (x *UserReference) Reset() {
 	}
 }
 
-func (x *UserReference) String() string {
+func (x *AutoValidateReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserReference) ProtoMessage() {}
+func (*AutoValidateReq) ProtoMessage() {}
 
-func (x *UserReference) ProtoReflect() protoreflect.Message {
+func (x *AutoValidateReq) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -685,42 +744,38 @@ func (x *UserReference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserReference.ProtoReflect.Descriptor instead.
-func (*UserReference) Descriptor() ([]byte, []int) {
+// Deprecated: Use AutoValidateReq.ProtoReflect.Descriptor instead.
+func (*AutoValidateReq) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UserReference) GetToken() *api_types.TokenInfos {
-	if x != nil {
-		return x.Token
-	}
-	return nil
-}
-
-func (x *UserReference) GetUserId() string {
+func (x *AutoValidateReq) GetTempToken() string {
 	if x != nil {
-		return x.UserId
+		return x.TempToken
 	}
 	return ""
 }
 
-func (x *UserReference) GetInstanceId() string {
+func (x *AutoValidateReq) GetAccessToken() string {
 	if x != nil {
-		return x.InstanceId
+		return x.AccessToken
 	}
 	return ""
 }
 
-type RevokeRefreshTokensReq struct {
+type AutoValidateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	AccountId        string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	VerificationCode string `protobuf:"bytes,2,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
+	IsSameUser       bool   `protobuf:"varint,3,opt,name=is_same_user,json=isSameUser,proto3" json:"is_same_user,omitempty"`
+	InstanceId       string `protobuf:"bytes,4,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 }
 
-func (x *RevokeRefreshTokensReq) Reset() {
-	*x = RevokeRefreshTokensReq{}
+func (x *AutoValidateResponse) Reset() {
+	*x = AutoValidateResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -728,13 +783,13 @@ func (x *RevokeRefreshTokensReq) Reset() {
 	}
 }
 
-func (x *RevokeRefreshTokensReq) String() string {
+func (x *AutoValidateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeRefreshTokensReq) ProtoMessage() {}
+func (*AutoValidateResponse) ProtoMessage() {}
 
-func (x *RevokeRefreshTokensReq) ProtoReflect() protoreflect.Message {
+func (x *AutoValidateResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -746,30 +801,51 @@ func (x *RevokeRefreshTokensReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeRefreshTokensReq.ProtoReflect.Descriptor instead.
-func (*RevokeRefreshTokensReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use AutoValidateResponse.ProtoReflect.Descriptor instead.
+func (*AutoValidateResponse) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *RevokeRefreshTokensReq) GetToken() *api_types.TokenInfos {
+func (x *AutoValidateResponse) GetAccountId() string {
 	if x != nil {
-		return x.Token
+		return x.AccountId
 	}
-	return nil
+	return ""
 }
 
-type RefreshTokenRequest struct {
+func (x *AutoValidateResponse) GetVerificationCode() string {
+	if x != nil {
+		return x.VerificationCode
+	}
+	return ""
+}
+
+func (x *AutoValidateResponse) GetIsSameUser() bool {
+	if x != nil {
+		return x.IsSameUser
+	}
+	return false
+}
+
+func (x *AutoValidateResponse) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type SendVerificationCodeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId       string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	InstanceId   string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	RefreshToken string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	Email      string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password   string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	InstanceId string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
+func (x *SendVerificationCodeReq) Reset() {
+	*x = SendVerificationCodeReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -777,13 +853,13 @@ func (x *RefreshTokenRequest) Reset() {
 	}
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *SendVerificationCodeReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*SendVerificationCodeReq) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *SendVerificationCodeReq) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -795,42 +871,44 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendVerificationCodeReq.ProtoReflect.Descriptor instead.
+func (*SendVerificationCodeReq) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RefreshTokenRequest) GetUserId() string {
+func (x *SendVerificationCodeReq) GetEmail() string {
 	if x != nil {
-		return x.UserId
+		return x.Email
 	}
 	return ""
 }
 
-func (x *RefreshTokenRequest) GetInstanceId() string {
+func (x *SendVerificationCodeReq) GetPassword() string {
 	if x != nil {
-		return x.InstanceId
+		return x.Password
 	}
 	return ""
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *SendVerificationCodeReq) GetInstanceId() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.InstanceId
 	}
 	return ""
 }
 
-type AppTokenRequest struct {
+type LoginResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Token              *TokenResponse `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User               *User          `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	SecondFactorNeeded bool           `protobuf:"varint,3,opt,name=second_factor_needed,json=secondFactorNeeded,proto3" json:"second_factor_needed,omitempty"`
 }
 
-func (x *AppTokenRequest) Reset() {
-	*x = AppTokenRequest{}
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -838,13 +916,13 @@ func (x *AppTokenRequest) Reset() {
 	}
 }
 
-func (x *AppTokenRequest) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppTokenRequest) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *AppTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -856,28 +934,44 @@ func (x *AppTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppTokenRequest.ProtoReflect.Descriptor instead.
-func (*AppTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *AppTokenRequest) GetToken() string {
+func (x *LoginResponse) GetToken() *TokenResponse {
 	if x != nil {
 		return x.Token
 	}
-	return ""
+	return nil
 }
 
-type AppTokenValidation struct {
+func (x *LoginResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetSecondFactorNeeded() bool {
+	if x != nil {
+		return x.SecondFactorNeeded
+	}
+	return false
+}
+
+type UserReference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Instances []string `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	Token      *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId     string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InstanceId string                `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 }
 
-func (x *AppTokenValidation) Reset() {
-	*x = AppTokenValidation{}
+func (x *UserReference) Reset() {
+	*x = UserReference{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -885,13 +979,13 @@ func (x *AppTokenValidation) Reset() {
 	}
 }
 
-func (x *AppTokenValidation) String() string {
+func (x *UserReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppTokenValidation) ProtoMessage() {}
+func (*UserReference) ProtoMessage() {}
 
-func (x *AppTokenValidation) ProtoReflect() protoreflect.Message {
+func (x *UserReference) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -903,29 +997,42 @@ func (x *AppTokenValidation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppTokenValidation.ProtoReflect.Descriptor instead.
-func (*AppTokenValidation) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserReference.ProtoReflect.Descriptor instead.
+func (*UserReference) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AppTokenValidation) GetInstances() []string {
+func (x *UserReference) GetToken() *api_types.TokenInfos {
 	if x != nil {
-		return x.Instances
+		return x.Token
 	}
 	return nil
 }
 
-type ProfileRequest struct {
+func (x *UserReference) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserReference) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type RevokeRefreshTokensReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token   *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Profile *Profile              `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Token *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 }
 
-func (x *ProfileRequest) Reset() {
-	*x = ProfileRequest{}
+func (x *RevokeRefreshTokensReq) Reset() {
+	*x = RevokeRefreshTokensReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,13 +1040,13 @@ func (x *ProfileRequest) Reset() {
 	}
 }
 
-func (x *ProfileRequest) String() string {
+func (x *RevokeRefreshTokensReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProfileRequest) ProtoMessage() {}
+func (*RevokeRefreshTokensReq) ProtoMessage() {}
 
-func (x *ProfileRequest) ProtoReflect() protoreflect.Message {
+func (x *RevokeRefreshTokensReq) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,42 +1058,30 @@ func (x *ProfileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProfileRequest.ProtoReflect.Descriptor instead.
-func (*ProfileRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevokeRefreshTokensReq.ProtoReflect.Descriptor instead.
+func (*RevokeRefreshTokensReq) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ProfileRequest) GetToken() *api_types.TokenInfos {
+func (x *RevokeRefreshTokensReq) GetToken() *api_types.TokenInfos {
 	if x != nil {
 		return x.Token
 	}
 	return nil
 }
 
-func (x *ProfileRequest) GetProfile() *Profile {
-	if x != nil {
-		return x.Profile
-	}
-	return nil
-}
-
-type UserAuthInfo struct {
+type RefreshTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId            string     `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Roles             []string   `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
-	InstanceId        string     `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	AccountId         string     `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // e.g. email address
-	Profiles          []*Profile `protobuf:"bytes,5,rep,name=profiles,proto3" json:"profiles,omitempty"`
-	SelectedProfile   *Profile   `protobuf:"bytes,6,opt,name=selected_profile,json=selectedProfile,proto3" json:"selected_profile,omitempty"`
-	AccountConfirmed  bool       `protobuf:"varint,7,opt,name=account_confirmed,json=accountConfirmed,proto3" json:"account_confirmed,omitempty"`
-	PreferredLanguage string     `protobuf:"bytes,8,opt,name=preferred_language,json=preferredLanguage,proto3" json:"preferred_language,omitempty"`
+	UserId       string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InstanceId   string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	RefreshToken string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 }
 
-func (x *UserAuthInfo) Reset() {
-	*x = UserAuthInfo{}
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_user_management_user_management_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -994,13 +1089,13 @@ func (x *UserAuthInfo) Reset() {
 	}
 }
 
-func (x *UserAuthInfo) String() string {
+func (x *RefreshTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserAuthInfo) ProtoMessage() {}
+func (*RefreshTokenRequest) ProtoMessage() {}
 
-func (x *UserAuthInfo) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_management_user_management_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1012,157 +1107,1225 @@ func (x *UserAuthInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserAuthInfo.ProtoReflect.Descriptor instead.
-func (*UserAuthInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
 	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *UserAuthInfo) GetUserId() string {
+func (x *RefreshTokenRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *UserAuthInfo) GetRoles() []string {
+func (x *RefreshTokenRequest) GetInstanceId() string {
 	if x != nil {
-		return x.Roles
+		return x.InstanceId
 	}
-	return nil
+	return ""
 }
 
-func (x *UserAuthInfo) GetInstanceId() string {
+func (x *RefreshTokenRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.InstanceId
+		return x.RefreshToken
 	}
 	return ""
 }
 
-func (x *UserAuthInfo) GetAccountId() string {
+type AppTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *AppTokenRequest) Reset() {
+	*x = AppTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppTokenRequest) ProtoMessage() {}
+
+func (x *AppTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppTokenRequest.ProtoReflect.Descriptor instead.
+func (*AppTokenRequest) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AppTokenRequest) GetToken() string {
 	if x != nil {
-		return x.AccountId
+		return x.Token
 	}
 	return ""
 }
 
-func (x *UserAuthInfo) GetProfiles() []*Profile {
-	if x != nil {
-		return x.Profiles
+type AppTokenValidation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances []string `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	Audiences []string `protobuf:"bytes,2,rep,name=audiences,proto3" json:"audiences,omitempty"` // access tokens for the app must have one of these audiences, any if empty
+}
+
+func (x *AppTokenValidation) Reset() {
+	*x = AppTokenValidation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *UserAuthInfo) GetSelectedProfile() *Profile {
-	if x != nil {
-		return x.SelectedProfile
+func (x *AppTokenValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppTokenValidation) ProtoMessage() {}
+
+func (x *AppTokenValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UserAuthInfo) GetAccountConfirmed() bool {
+// Deprecated: Use AppTokenValidation.ProtoReflect.Descriptor instead.
+func (*AppTokenValidation) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AppTokenValidation) GetInstances() []string {
 	if x != nil {
-		return x.AccountConfirmed
+		return x.Instances
 	}
-	return false
+	return nil
 }
 
-func (x *UserAuthInfo) GetPreferredLanguage() string {
+func (x *AppTokenValidation) GetAudiences() []string {
 	if x != nil {
-		return x.PreferredLanguage
+		return x.Audiences
 	}
-	return ""
+	return nil
 }
 
-type ResendContactVerificationReq struct {
+type ProfileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Token   *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Type    string                `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Address string                `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Profile *Profile              `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
 }
 
-func (x *ResendContactVerificationReq) Reset() {
-	*x = ResendContactVerificationReq{}
+func (x *ProfileRequest) Reset() {
+	*x = ProfileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[15]
+		mi := &file_user_management_user_management_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResendContactVerificationReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileRequest) ProtoMessage() {}
+
+func (x *ProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileRequest.ProtoReflect.Descriptor instead.
+func (*ProfileRequest) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ProfileRequest) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ProfileRequest) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type UserAuthInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId            string     `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles             []string   `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	InstanceId        string     `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	AccountId         string     `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // e.g. email address
+	Profiles          []*Profile `protobuf:"bytes,5,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	SelectedProfile   *Profile   `protobuf:"bytes,6,opt,name=selected_profile,json=selectedProfile,proto3" json:"selected_profile,omitempty"`
+	AccountConfirmed  bool       `protobuf:"varint,7,opt,name=account_confirmed,json=accountConfirmed,proto3" json:"account_confirmed,omitempty"`
+	PreferredLanguage string     `protobuf:"bytes,8,opt,name=preferred_language,json=preferredLanguage,proto3" json:"preferred_language,omitempty"`
+}
+
+func (x *UserAuthInfo) Reset() {
+	*x = UserAuthInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserAuthInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAuthInfo) ProtoMessage() {}
+
+func (x *UserAuthInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAuthInfo.ProtoReflect.Descriptor instead.
+func (*UserAuthInfo) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UserAuthInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserAuthInfo) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *UserAuthInfo) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *UserAuthInfo) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UserAuthInfo) GetProfiles() []*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *UserAuthInfo) GetSelectedProfile() *Profile {
+	if x != nil {
+		return x.SelectedProfile
+	}
+	return nil
+}
+
+func (x *UserAuthInfo) GetAccountConfirmed() bool {
+	if x != nil {
+		return x.AccountConfirmed
+	}
+	return false
+}
+
+func (x *UserAuthInfo) GetPreferredLanguage() string {
+	if x != nil {
+		return x.PreferredLanguage
+	}
+	return ""
+}
+
+type ResendContactVerificationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token   *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Type    string                `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Address string                `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ResendContactVerificationReq) Reset() {
+	*x = ResendContactVerificationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResendContactVerificationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendContactVerificationReq) ProtoMessage() {}
+
+func (x *ResendContactVerificationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendContactVerificationReq.ProtoReflect.Descriptor instead.
+func (*ResendContactVerificationReq) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResendContactVerificationReq) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ResendContactVerificationReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResendContactVerificationReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type PasswordChangeMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token       *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	OldPassword string                `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword string                `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+}
+
+func (x *PasswordChangeMsg) Reset() {
+	*x = PasswordChangeMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasswordChangeMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordChangeMsg) ProtoMessage() {}
+
+func (x *PasswordChangeMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordChangeMsg.ProtoReflect.Descriptor instead.
+func (*PasswordChangeMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PasswordChangeMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *PasswordChangeMsg) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *PasswordChangeMsg) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type TOTPMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token    *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Code     string                `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`         // code from the authenticator app, to confirm the enrollment
+	Password string                `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"` // current password, to disable TOTP
+}
+
+func (x *TOTPMsg) Reset() {
+	*x = TOTPMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TOTPMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPMsg) ProtoMessage() {}
+
+func (x *TOTPMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPMsg.ProtoReflect.Descriptor instead.
+func (*TOTPMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TOTPMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *TOTPMsg) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TOTPMsg) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type TOTPEnrollmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret     string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32 encoded, for manual entry
+	OtpauthUri string `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // to be shown as QR code
+}
+
+func (x *TOTPEnrollmentResponse) Reset() {
+	*x = TOTPEnrollmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TOTPEnrollmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPEnrollmentResponse) ProtoMessage() {}
+
+func (x *TOTPEnrollmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPEnrollmentResponse.ProtoReflect.Descriptor instead.
+func (*TOTPEnrollmentResponse) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TOTPEnrollmentResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TOTPEnrollmentResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type PasskeyMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token        *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ChallengeId  string                `protobuf:"bytes,2,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"` // from StartPasskeyRegistration
+	Credential   string                `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`                      // PublicKeyCredential from navigator.credentials.create() as JSON
+	Nickname     string                `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	CredentialId string                `protobuf:"bytes,5,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"` // base64url encoded, to remove a passkey
+}
+
+func (x *PasskeyMsg) Reset() {
+	*x = PasskeyMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasskeyMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyMsg) ProtoMessage() {}
+
+func (x *PasskeyMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyMsg.ProtoReflect.Descriptor instead.
+func (*PasskeyMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PasskeyMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *PasskeyMsg) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *PasskeyMsg) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *PasskeyMsg) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *PasskeyMsg) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+type PasskeyChallenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChallengeId string `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Options     string `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // JSON, the publicKey options for navigator.credentials.create() or get()
+}
+
+func (x *PasskeyChallenge) Reset() {
+	*x = PasskeyChallenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasskeyChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyChallenge) ProtoMessage() {}
+
+func (x *PasskeyChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyChallenge.ProtoReflect.Descriptor instead.
+func (*PasskeyChallenge) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PasskeyChallenge) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *PasskeyChallenge) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+type Passkey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CredentialId string   `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"` // base64url encoded
+	Nickname     string   `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Transports   []string `protobuf:"bytes,3,rep,name=transports,proto3" json:"transports,omitempty"`
+	CreatedAt    int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt   int64    `protobuf:"varint,5,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+}
+
+func (x *Passkey) Reset() {
+	*x = Passkey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Passkey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passkey) ProtoMessage() {}
+
+func (x *Passkey) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passkey.ProtoReflect.Descriptor instead.
+func (*Passkey) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Passkey) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+func (x *Passkey) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Passkey) GetTransports() []string {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+func (x *Passkey) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Passkey) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+type PasskeyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Passkeys []*Passkey `protobuf:"bytes,1,rep,name=passkeys,proto3" json:"passkeys,omitempty"`
+}
+
+func (x *PasskeyList) Reset() {
+	*x = PasskeyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasskeyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyList) ProtoMessage() {}
+
+func (x *PasskeyList) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyList.ProtoReflect.Descriptor instead.
+func (*PasskeyList) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PasskeyList) GetPasskeys() []*Passkey {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+type RecoveryCodesMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token    *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password string                `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // current password
+}
+
+func (x *RecoveryCodesMsg) Reset() {
+	*x = RecoveryCodesMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecoveryCodesMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesMsg) ProtoMessage() {}
+
+func (x *RecoveryCodesMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodesMsg.ProtoReflect.Descriptor instead.
+func (*RecoveryCodesMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RecoveryCodesMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *RecoveryCodesMsg) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RecoveryCodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codes []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"` // only returned once, the service stores hashes
+}
+
+func (x *RecoveryCodes) Reset() {
+	*x = RecoveryCodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodes) ProtoMessage() {}
+
+func (x *RecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodes.ProtoReflect.Descriptor instead.
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RecoveryCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type SessionMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	SessionId string                `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // to revoke a session
+}
+
+func (x *SessionMsg) Reset() {
+	*x = SessionMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionMsg) ProtoMessage() {}
+
+func (x *SessionMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionMsg.ProtoReflect.Descriptor instead.
+func (*SessionMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SessionMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *SessionMsg) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Session is a login on a device, it ends when its refresh token is revoked
+type Session struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt  int64  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt int64  `protobuf:"varint,3,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	UserAgent  string `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress  string `protobuf:"bytes,5,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Label      string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Session) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SessionList) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// JSONWebKey is a public key for verifying access tokens (RFC 7517), only the fields of its key type are set
+type JSONWebKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kty string `protobuf:"bytes,1,opt,name=kty,proto3" json:"kty,omitempty"`
+	Use string `protobuf:"bytes,2,opt,name=use,proto3" json:"use,omitempty"`
+	Alg string `protobuf:"bytes,3,opt,name=alg,proto3" json:"alg,omitempty"`
+	Kid string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
+	N   string `protobuf:"bytes,5,opt,name=n,proto3" json:"n,omitempty"`     // RSA modulus
+	E   string `protobuf:"bytes,6,opt,name=e,proto3" json:"e,omitempty"`     // RSA exponent
+	Crv string `protobuf:"bytes,7,opt,name=crv,proto3" json:"crv,omitempty"` // curve of EC and OKP keys
+	X   string `protobuf:"bytes,8,opt,name=x,proto3" json:"x,omitempty"`
+	Y   string `protobuf:"bytes,9,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *JSONWebKey) Reset() {
+	*x = JSONWebKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JSONWebKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSONWebKey) ProtoMessage() {}
+
+func (x *JSONWebKey) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSONWebKey.ProtoReflect.Descriptor instead.
+func (*JSONWebKey) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *JSONWebKey) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *JSONWebKey) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JSONWebKey) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
 }
 
-func (*ResendContactVerificationReq) ProtoMessage() {}
+func (x *JSONWebKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
 
-func (x *ResendContactVerificationReq) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[15]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *JSONWebKey) GetN() string {
+	if x != nil {
+		return x.N
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ResendContactVerificationReq.ProtoReflect.Descriptor instead.
-func (*ResendContactVerificationReq) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{15}
+func (x *JSONWebKey) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
 }
 
-func (x *ResendContactVerificationReq) GetToken() *api_types.TokenInfos {
+func (x *JSONWebKey) GetCrv() string {
 	if x != nil {
-		return x.Token
+		return x.Crv
 	}
-	return nil
+	return ""
 }
 
-func (x *ResendContactVerificationReq) GetType() string {
+func (x *JSONWebKey) GetX() string {
 	if x != nil {
-		return x.Type
+		return x.X
 	}
 	return ""
 }
 
-func (x *ResendContactVerificationReq) GetAddress() string {
+func (x *JSONWebKey) GetY() string {
 	if x != nil {
-		return x.Address
+		return x.Y
 	}
 	return ""
 }
 
-type PasswordChangeMsg struct {
+type JSONWebKeySet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token       *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	OldPassword string                `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
-	NewPassword string                `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	Keys []*JSONWebKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
 }
 
-func (x *PasswordChangeMsg) Reset() {
-	*x = PasswordChangeMsg{}
+func (x *JSONWebKeySet) Reset() {
+	*x = JSONWebKeySet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[16]
+		mi := &file_user_management_user_management_service_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PasswordChangeMsg) String() string {
+func (x *JSONWebKeySet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PasswordChangeMsg) ProtoMessage() {}
+func (*JSONWebKeySet) ProtoMessage() {}
 
-func (x *PasswordChangeMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[16]
+func (x *JSONWebKeySet) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1173,32 +2336,18 @@ func (x *PasswordChangeMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PasswordChangeMsg.ProtoReflect.Descriptor instead.
-func (*PasswordChangeMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use JSONWebKeySet.ProtoReflect.Descriptor instead.
+func (*JSONWebKeySet) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *PasswordChangeMsg) GetToken() *api_types.TokenInfos {
+func (x *JSONWebKeySet) GetKeys() []*JSONWebKey {
 	if x != nil {
-		return x.Token
+		return x.Keys
 	}
 	return nil
 }
 
-func (x *PasswordChangeMsg) GetOldPassword() string {
-	if x != nil {
-		return x.OldPassword
-	}
-	return ""
-}
-
-func (x *PasswordChangeMsg) GetNewPassword() string {
-	if x != nil {
-		return x.NewPassword
-	}
-	return ""
-}
-
 type InitiateResetPasswordMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1211,7 +2360,7 @@ type InitiateResetPasswordMsg struct {
 func (x *InitiateResetPasswordMsg) Reset() {
 	*x = InitiateResetPasswordMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[17]
+		mi := &file_user_management_user_management_service_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1224,7 +2373,7 @@ func (x *InitiateResetPasswordMsg) String() string {
 func (*InitiateResetPasswordMsg) ProtoMessage() {}
 
 func (x *InitiateResetPasswordMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[17]
+	mi := &file_user_management_user_management_service_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1237,7 +2386,7 @@ func (x *InitiateResetPasswordMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitiateResetPasswordMsg.ProtoReflect.Descriptor instead.
 func (*InitiateResetPasswordMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{17}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *InitiateResetPasswordMsg) GetInstanceId() string {
@@ -1265,7 +2414,7 @@ type GetInfosForResetPasswordMsg struct {
 func (x *GetInfosForResetPasswordMsg) Reset() {
 	*x = GetInfosForResetPasswordMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[18]
+		mi := &file_user_management_user_management_service_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1278,7 +2427,7 @@ func (x *GetInfosForResetPasswordMsg) String() string {
 func (*GetInfosForResetPasswordMsg) ProtoMessage() {}
 
 func (x *GetInfosForResetPasswordMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[18]
+	mi := &file_user_management_user_management_service_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1291,7 +2440,7 @@ func (x *GetInfosForResetPasswordMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInfosForResetPasswordMsg.ProtoReflect.Descriptor instead.
 func (*GetInfosForResetPasswordMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{18}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetInfosForResetPasswordMsg) GetToken() string {
@@ -1312,7 +2461,7 @@ type UserInfoForPWReset struct {
 func (x *UserInfoForPWReset) Reset() {
 	*x = UserInfoForPWReset{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[19]
+		mi := &file_user_management_user_management_service_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1325,7 +2474,7 @@ func (x *UserInfoForPWReset) String() string {
 func (*UserInfoForPWReset) ProtoMessage() {}
 
 func (x *UserInfoForPWReset) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[19]
+	mi := &file_user_management_user_management_service_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,7 +2487,7 @@ func (x *UserInfoForPWReset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserInfoForPWReset.ProtoReflect.Descriptor instead.
 func (*UserInfoForPWReset) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{19}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *UserInfoForPWReset) GetAccountId() string {
@@ -1360,7 +2509,7 @@ type ResetPasswordMsg struct {
 func (x *ResetPasswordMsg) Reset() {
 	*x = ResetPasswordMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[20]
+		mi := &file_user_management_user_management_service_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1373,7 +2522,7 @@ func (x *ResetPasswordMsg) String() string {
 func (*ResetPasswordMsg) ProtoMessage() {}
 
 func (x *ResetPasswordMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[20]
+	mi := &file_user_management_user_management_service_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1386,7 +2535,7 @@ func (x *ResetPasswordMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetPasswordMsg.ProtoReflect.Descriptor instead.
 func (*ResetPasswordMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{20}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ResetPasswordMsg) GetToken() string {
@@ -1417,7 +2566,7 @@ type EmailChangeMsg struct {
 func (x *EmailChangeMsg) Reset() {
 	*x = EmailChangeMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[21]
+		mi := &file_user_management_user_management_service_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1430,7 +2579,7 @@ func (x *EmailChangeMsg) String() string {
 func (*EmailChangeMsg) ProtoMessage() {}
 
 func (x *EmailChangeMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[21]
+	mi := &file_user_management_user_management_service_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1443,7 +2592,7 @@ func (x *EmailChangeMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmailChangeMsg.ProtoReflect.Descriptor instead.
 func (*EmailChangeMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{21}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *EmailChangeMsg) GetToken() *api_types.TokenInfos {
@@ -1486,7 +2635,7 @@ type LanguageChangeMsg struct {
 func (x *LanguageChangeMsg) Reset() {
 	*x = LanguageChangeMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[22]
+		mi := &file_user_management_user_management_service_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1499,7 +2648,7 @@ func (x *LanguageChangeMsg) String() string {
 func (*LanguageChangeMsg) ProtoMessage() {}
 
 func (x *LanguageChangeMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[22]
+	mi := &file_user_management_user_management_service_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1512,7 +2661,7 @@ func (x *LanguageChangeMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LanguageChangeMsg.ProtoReflect.Descriptor instead.
 func (*LanguageChangeMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{22}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *LanguageChangeMsg) GetToken() *api_types.TokenInfos {
@@ -1541,7 +2690,7 @@ type ContactPreferencesMsg struct {
 func (x *ContactPreferencesMsg) Reset() {
 	*x = ContactPreferencesMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[23]
+		mi := &file_user_management_user_management_service_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1554,7 +2703,7 @@ func (x *ContactPreferencesMsg) String() string {
 func (*ContactPreferencesMsg) ProtoMessage() {}
 
 func (x *ContactPreferencesMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[23]
+	mi := &file_user_management_user_management_service_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1567,7 +2716,7 @@ func (x *ContactPreferencesMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContactPreferencesMsg.ProtoReflect.Descriptor instead.
 func (*ContactPreferencesMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{23}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ContactPreferencesMsg) GetToken() *api_types.TokenInfos {
@@ -1596,7 +2745,7 @@ type ContactInfoMsg struct {
 func (x *ContactInfoMsg) Reset() {
 	*x = ContactInfoMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[24]
+		mi := &file_user_management_user_management_service_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1609,7 +2758,7 @@ func (x *ContactInfoMsg) String() string {
 func (*ContactInfoMsg) ProtoMessage() {}
 
 func (x *ContactInfoMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[24]
+	mi := &file_user_management_user_management_service_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1622,66 +2771,281 @@ func (x *ContactInfoMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContactInfoMsg.ProtoReflect.Descriptor instead.
 func (*ContactInfoMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{24}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ContactInfoMsg) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ContactInfoMsg) GetContactInfo() *ContactInfo {
+	if x != nil {
+		return x.ContactInfo
+	}
+	return nil
+}
+
+type JWTRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	AppToken string `protobuf:"bytes,2,opt,name=app_token,json=appToken,proto3" json:"app_token,omitempty"` // optional, the audience of the token is checked against the audiences of the app
+}
+
+func (x *JWTRequest) Reset() {
+	*x = JWTRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JWTRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWTRequest) ProtoMessage() {}
+
+func (x *JWTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWTRequest.ProtoReflect.Descriptor instead.
+func (*JWTRequest) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *JWTRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *JWTRequest) GetAppToken() string {
+	if x != nil {
+		return x.AppToken
+	}
+	return ""
+}
+
+type IntrospectTokenMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppToken      string `protobuf:"bytes,1,opt,name=app_token,json=appToken,proto3" json:"app_token,omitempty"` // authenticates the caller, only tokens of its instances are active
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	TokenTypeHint string `protobuf:"bytes,3,opt,name=token_type_hint,json=tokenTypeHint,proto3" json:"token_type_hint,omitempty"` // access_token or refresh_token, optional
+}
+
+func (x *IntrospectTokenMsg) Reset() {
+	*x = IntrospectTokenMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntrospectTokenMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntrospectTokenMsg) ProtoMessage() {}
+
+func (x *IntrospectTokenMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntrospectTokenMsg.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenMsg) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *IntrospectTokenMsg) GetAppToken() string {
+	if x != nil {
+		return x.AppToken
+	}
+	return ""
+}
+
+func (x *IntrospectTokenMsg) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IntrospectTokenMsg) GetTokenTypeHint() string {
+	if x != nil {
+		return x.TokenTypeHint
+	}
+	return ""
+}
+
+// TokenIntrospection follows RFC 7662, for inactive tokens only active is set
+type TokenIntrospection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Active          bool     `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	TokenType       string   `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // access_token or refresh_token
+	Sub             string   `protobuf:"bytes,3,opt,name=sub,proto3" json:"sub,omitempty"`                              // user ID
+	InstanceId      string   `protobuf:"bytes,4,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Roles           []string `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	ProfileId       string   `protobuf:"bytes,6,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	OtherProfileIds []string `protobuf:"bytes,7,rep,name=other_profile_ids,json=otherProfileIds,proto3" json:"other_profile_ids,omitempty"`
+	Exp             int64    `protobuf:"varint,8,opt,name=exp,proto3" json:"exp,omitempty"` // 0 if a refresh token does not expire
+	Iat             int64    `protobuf:"varint,9,opt,name=iat,proto3" json:"iat,omitempty"`
+	Iss             string   `protobuf:"bytes,10,opt,name=iss,proto3" json:"iss,omitempty"`
+	Aud             string   `protobuf:"bytes,11,opt,name=aud,proto3" json:"aud,omitempty"`
+	Jti             string   `protobuf:"bytes,12,opt,name=jti,proto3" json:"jti,omitempty"`
+	Username        string   `protobuf:"bytes,13,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *TokenIntrospection) Reset() {
+	*x = TokenIntrospection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_management_user_management_service_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenIntrospection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenIntrospection) ProtoMessage() {}
+
+func (x *TokenIntrospection) ProtoReflect() protoreflect.Message {
+	mi := &file_user_management_user_management_service_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenIntrospection.ProtoReflect.Descriptor instead.
+func (*TokenIntrospection) Descriptor() ([]byte, []int) {
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TokenIntrospection) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TokenIntrospection) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *TokenIntrospection) GetSub() string {
+	if x != nil {
+		return x.Sub
+	}
+	return ""
+}
+
+func (x *TokenIntrospection) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *TokenIntrospection) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
 }
 
-func (x *ContactInfoMsg) GetToken() *api_types.TokenInfos {
+func (x *TokenIntrospection) GetProfileId() string {
 	if x != nil {
-		return x.Token
+		return x.ProfileId
 	}
-	return nil
+	return ""
 }
 
-func (x *ContactInfoMsg) GetContactInfo() *ContactInfo {
+func (x *TokenIntrospection) GetOtherProfileIds() []string {
 	if x != nil {
-		return x.ContactInfo
+		return x.OtherProfileIds
 	}
 	return nil
 }
 
-type JWTRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+func (x *TokenIntrospection) GetExp() int64 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
 }
 
-func (x *JWTRequest) Reset() {
-	*x = JWTRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[25]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TokenIntrospection) GetIat() int64 {
+	if x != nil {
+		return x.Iat
 	}
+	return 0
 }
 
-func (x *JWTRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TokenIntrospection) GetIss() string {
+	if x != nil {
+		return x.Iss
+	}
+	return ""
 }
 
-func (*JWTRequest) ProtoMessage() {}
-
-func (x *JWTRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[25]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *TokenIntrospection) GetAud() string {
+	if x != nil {
+		return x.Aud
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use JWTRequest.ProtoReflect.Descriptor instead.
-func (*JWTRequest) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{25}
+func (x *TokenIntrospection) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
 }
 
-func (x *JWTRequest) GetToken() string {
+func (x *TokenIntrospection) GetUsername() string {
 	if x != nil {
-		return x.Token
+		return x.Username
 	}
 	return ""
 }
@@ -1698,7 +3062,7 @@ type RefreshJWTRequest struct {
 func (x *RefreshJWTRequest) Reset() {
 	*x = RefreshJWTRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[26]
+		mi := &file_user_management_user_management_service_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1711,7 +3075,7 @@ func (x *RefreshJWTRequest) String() string {
 func (*RefreshJWTRequest) ProtoMessage() {}
 
 func (x *RefreshJWTRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[26]
+	mi := &file_user_management_user_management_service_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1724,7 +3088,7 @@ func (x *RefreshJWTRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshJWTRequest.ProtoReflect.Descriptor instead.
 func (*RefreshJWTRequest) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{26}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *RefreshJWTRequest) GetRefreshToken() string {
@@ -1761,7 +3125,7 @@ type CreateUserReq struct {
 func (x *CreateUserReq) Reset() {
 	*x = CreateUserReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[27]
+		mi := &file_user_management_user_management_service_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1774,7 +3138,7 @@ func (x *CreateUserReq) String() string {
 func (*CreateUserReq) ProtoMessage() {}
 
 func (x *CreateUserReq) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[27]
+	mi := &file_user_management_user_management_service_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1787,7 +3151,7 @@ func (x *CreateUserReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserReq.ProtoReflect.Descriptor instead.
 func (*CreateUserReq) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{27}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *CreateUserReq) GetToken() *api_types.TokenInfos {
@@ -1866,7 +3230,7 @@ type RoleMsg struct {
 func (x *RoleMsg) Reset() {
 	*x = RoleMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[28]
+		mi := &file_user_management_user_management_service_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1879,7 +3243,7 @@ func (x *RoleMsg) String() string {
 func (*RoleMsg) ProtoMessage() {}
 
 func (x *RoleMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[28]
+	mi := &file_user_management_user_management_service_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,7 +3256,7 @@ func (x *RoleMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleMsg.ProtoReflect.Descriptor instead.
 func (*RoleMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{28}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *RoleMsg) GetToken() *api_types.TokenInfos {
@@ -1928,7 +3292,7 @@ type StreamUsersMsg struct {
 func (x *StreamUsersMsg) Reset() {
 	*x = StreamUsersMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[29]
+		mi := &file_user_management_user_management_service_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1941,7 +3305,7 @@ func (x *StreamUsersMsg) String() string {
 func (*StreamUsersMsg) ProtoMessage() {}
 
 func (x *StreamUsersMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[29]
+	mi := &file_user_management_user_management_service_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1954,7 +3318,7 @@ func (x *StreamUsersMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamUsersMsg.ProtoReflect.Descriptor instead.
 func (*StreamUsersMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{29}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *StreamUsersMsg) GetInstanceId() string {
@@ -1982,7 +3346,7 @@ type FindNonParticipantUsersMsg struct {
 func (x *FindNonParticipantUsersMsg) Reset() {
 	*x = FindNonParticipantUsersMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[30]
+		mi := &file_user_management_user_management_service_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1995,7 +3359,7 @@ func (x *FindNonParticipantUsersMsg) String() string {
 func (*FindNonParticipantUsersMsg) ProtoMessage() {}
 
 func (x *FindNonParticipantUsersMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[30]
+	mi := &file_user_management_user_management_service_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2008,7 +3372,7 @@ func (x *FindNonParticipantUsersMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindNonParticipantUsersMsg.ProtoReflect.Descriptor instead.
 func (*FindNonParticipantUsersMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{30}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *FindNonParticipantUsersMsg) GetToken() *api_types.TokenInfos {
@@ -2029,7 +3393,7 @@ type UserListMsg struct {
 func (x *UserListMsg) Reset() {
 	*x = UserListMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[31]
+		mi := &file_user_management_user_management_service_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2042,7 +3406,7 @@ func (x *UserListMsg) String() string {
 func (*UserListMsg) ProtoMessage() {}
 
 func (x *UserListMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[31]
+	mi := &file_user_management_user_management_service_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2055,7 +3419,7 @@ func (x *UserListMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserListMsg.ProtoReflect.Descriptor instead.
 func (*UserListMsg) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{31}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *UserListMsg) GetUsers() []*User {
@@ -2076,7 +3440,7 @@ type TempToken struct {
 func (x *TempToken) Reset() {
 	*x = TempToken{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[32]
+		mi := &file_user_management_user_management_service_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2089,7 +3453,7 @@ func (x *TempToken) String() string {
 func (*TempToken) ProtoMessage() {}
 
 func (x *TempToken) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[32]
+	mi := &file_user_management_user_management_service_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2102,7 +3466,7 @@ func (x *TempToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TempToken.ProtoReflect.Descriptor instead.
 func (*TempToken) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{32}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *TempToken) GetToken() string {
@@ -2128,7 +3492,7 @@ type TokenResponse struct {
 func (x *TokenResponse) Reset() {
 	*x = TokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[33]
+		mi := &file_user_management_user_management_service_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2141,7 +3505,7 @@ func (x *TokenResponse) String() string {
 func (*TokenResponse) ProtoMessage() {}
 
 func (x *TokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[33]
+	mi := &file_user_management_user_management_service_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2154,7 +3518,7 @@ func (x *TokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenResponse.ProtoReflect.Descriptor instead.
 func (*TokenResponse) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{33}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *TokenResponse) GetAccessToken() string {
@@ -2212,7 +3576,7 @@ type StreamUsersMsg_Filters struct {
 func (x *StreamUsersMsg_Filters) Reset() {
 	*x = StreamUsersMsg_Filters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_user_management_user_management_service_proto_msgTypes[34]
+		mi := &file_user_management_user_management_service_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2225,7 +3589,7 @@ func (x *StreamUsersMsg_Filters) String() string {
 func (*StreamUsersMsg_Filters) ProtoMessage() {}
 
 func (x *StreamUsersMsg_Filters) ProtoReflect() protoreflect.Message {
-	mi := &file_user_management_user_management_service_proto_msgTypes[34]
+	mi := &file_user_management_user_management_service_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2238,7 +3602,7 @@ func (x *StreamUsersMsg_Filters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamUsersMsg_Filters.ProtoReflect.Descriptor instead.
 func (*StreamUsersMsg_Filters) Descriptor() ([]byte, []int) {
-	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{29, 0}
+	return file_user_management_user_management_service_proto_rawDescGZIP(), []int{48, 0}
 }
 
 func (x *StreamUsersMsg_Filters) GetUseReminderWeekdayFilter() bool {
@@ -2302,7 +3666,7 @@ var file_user_management_user_management_service_proto_rawDesc = []byte{
 	0x72, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f, 0x32, 0x66, 0x61, 0x18, 0x06, 0x20, 0x01,
 	0x28, 0x08, 0x52, 0x06, 0x75, 0x73, 0x65, 0x32, 0x66, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e,
 	0x66, 0x6f, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x69, 0x6e, 0x66, 0x6f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xba, 0x01, 0x0a, 0x11, 0x4c, 0x6f,
+	0x69, 0x6e, 0x66, 0x6f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xc0, 0x02, 0x0a, 0x11, 0x4c, 0x6f,
 	0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x4d, 0x73, 0x67, 0x12,
 	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
 	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
@@ -2314,506 +3678,804 @@ var file_user_management_user_management_service_proto_rawDesc = []byte{
 	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x76, 0x65, 0x72,
 	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x17, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
-	0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x44, 0x50, 0x4d,
-	0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x70,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x53, 0x0a, 0x0f, 0x41, 0x75,
-	0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x1d, 0x0a,
-	0x0a, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
-	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
-	0xa5, 0x01, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f,
-	0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x53, 0x61,
-	0x6d, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x6c, 0x0a, 0x17, 0x53, 0x65, 0x6e, 0x64, 0x56,
-	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52,
-	0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0xac, 0x01, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22,
-	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69,
-	0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73,
-	0x65, 0x72, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x66, 0x61, 0x63,
-	0x74, 0x6f, 0x72, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x12, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x4e, 0x65,
-	0x65, 0x64, 0x65, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
-	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a,
-	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b,
-	0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65,
+	0x79, 0x5f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x61, 0x73, 0x73,
+	0x6b, 0x65, 0x79, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xe2, 0x01, 0x0a,
+	0x17, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x49, 0x44, 0x50, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x64, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64,
+	0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x19, 0x0a, 0x08, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x69, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x22, 0x4d, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65,
+	0x79, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x22, 0xa0, 0x01, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x50, 0x61,
+	0x73, 0x73, 0x6b, 0x65, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x25, 0x0a, 0x0e,
+	0x61, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x73, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x14, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c,
+	0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0xa3, 0x01, 0x0a, 0x19, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x73, 0x5f,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x61, 0x73, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
+	0x22, 0x53, 0x0a, 0x0f, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a,
+	0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73,
+	0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x69, 0x73, 0x53, 0x61, 0x6d, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x6c, 0x0a,
+	0x17, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0xac, 0x01, 0x0a, 0x0d,
+	0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6e, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x46, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x4f, 0x0a,
+	0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x74,
+	0x0a, 0x13, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x27, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x50, 0x0a,
+	0x12, 0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22,
+	0x74, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
 	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x74, 0x0a, 0x13, 0x52, 0x65, 0x66, 0x72,
-	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66,
-	0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x27,
-	0x0a, 0x0f, 0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x32, 0x0a, 0x12, 0x41, 0x70, 0x70, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
-	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x74, 0x0a, 0x0e, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69,
-	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x22, 0xc6, 0x02, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72,
-	0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65,
-	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
-	0x64, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
-	0x12, 0x3c, 0x0a, 0x10, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66,
-	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0f, 0x73,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2b,
-	0x0a, 0x11, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72,
-	0x6d, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
-	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72,
-	0x65, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x1c, 0x52,
-	0x65, 0x73, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66,
-	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x22, 0x90, 0x01, 0x0a, 0x11, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
-	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a,
-	0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77,
-	0x6f, 0x72, 0x64, 0x22, 0x5a, 0x0a, 0x18, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x12,
-	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64,
-	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22,
-	0x33, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x52, 0x65,
-	0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x12, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
-	0x46, 0x6f, 0x72, 0x50, 0x57, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x10, 0x52, 0x65, 0x73,
-	0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77,
-	0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xa6, 0x01, 0x0a, 0x0e, 0x45, 0x6d, 0x61, 0x69, 0x6c,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
-	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x24, 0x0a,
-	0x0e, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x6f, 0x6c, 0x64, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x4f, 0x6c, 0x64, 0x45, 0x6d,
-	0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22,
-	0x6f, 0x0a, 0x11, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
-	0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49,
-	0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6c,
-	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x64, 0x65,
-	0x22, 0x9d, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f,
+	0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0xc6, 0x02, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x41, 0x75,
+	0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x10, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x0f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x12,
+	0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x83,
+	0x01, 0x0a, 0x1c, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12,
+	0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x11, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f,
 	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
 	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
 	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x12, 0x4d, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
-	0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x12, 0x63, 0x6f,
-	0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
-	0x22, 0x81, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f,
-	0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
-	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x38, 0x0a, 0x0c, 0x63, 0x6f,
-	0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
-	0x49, 0x6e, 0x66, 0x6f, 0x22, 0x22, 0x0a, 0x0a, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5b, 0x0a, 0x11, 0x52, 0x65, 0x66, 0x72,
-	0x65, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
-	0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xe4, 0x02, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d,
-	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x29, 0x0a,
-	0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c,
-	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a,
-	0x07, 0x75, 0x73, 0x65, 0x5f, 0x32, 0x66, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x75, 0x73, 0x65, 0x32, 0x66, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x73, 0x0a, 0x07,
-	0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x70, 0x0a, 0x07, 0x54, 0x4f, 0x54, 0x50, 0x4d,
+	0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x51, 0x0a, 0x16, 0x54, 0x4f, 0x54,
+	0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f,
+	0x74, 0x70, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x74, 0x70, 0x61, 0x75, 0x74, 0x68, 0x55, 0x72, 0x69, 0x22, 0xc7, 0x01, 0x0a,
+	0x0a, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66,
+	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x10, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65,
+	0x79, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x07, 0x50, 0x61, 0x73, 0x73,
+	0x6b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x73, 0x65, 0x64, 0x41, 0x74, 0x22, 0x54, 0x0a, 0x0b, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65,
+	0x79, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x65, 0x0a, 0x10, 0x52,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x73, 0x67, 0x12,
+	0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0x25, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x0a, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
 	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b,
 	0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d,
-	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c,
-	0x65, 0x22, 0xb3, 0x02, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72,
-	0x73, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xae, 0x01,
+	0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69,
+	0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x54,
+	0x0a, 0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x45, 0x0a,
+	0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0a, 0x4a, 0x53, 0x4f, 0x4e, 0x57, 0x65, 0x62,
+	0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x6c, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x72, 0x76, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x72, 0x76, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x01, 0x79, 0x22, 0x51, 0x0a, 0x0d, 0x4a, 0x53, 0x4f, 0x4e, 0x57, 0x65, 0x62,
+	0x4b, 0x65, 0x79, 0x53, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
+	0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x53, 0x4f, 0x4e, 0x57, 0x65, 0x62, 0x4b,
+	0x65, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x5a, 0x0a, 0x18, 0x49, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x52, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x55, 0x73, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0xab, 0x01, 0x0a, 0x07, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3d, 0x0a, 0x1b, 0x75, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x6d,
-	0x69, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x5f, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x75, 0x73, 0x65, 0x52,
-	0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72,
-	0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
-	0x72, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12,
-	0x36, 0x0a, 0x17, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65,
-	0x64, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x15, 0x6f, 0x6e, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x53, 0x0a, 0x1a, 0x46, 0x69, 0x6e, 0x64, 0x4e,
-	0x6f, 0x6e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
-	0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x0b,
-	0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x75,
-	0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6e, 0x66,
-	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72,
-	0x73, 0x22, 0x21, 0x0a, 0x09, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x84, 0x02, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66,
-	0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d,
-	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x12, 0x2d, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13,
-	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12,
-	0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
-	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72,
-	0x72, 0x65, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x32, 0x82, 0x1e, 0x0a, 0x11,
-	0x55, 0x73, 0x65, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x70,
-	0x69, 0x12, 0x51, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73,
+	0x46, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x4d, 0x73, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x12, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x46, 0x6f, 0x72, 0x50, 0x57, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x4b,
+	0x0a, 0x10, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d,
+	0x73, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xa6, 0x01, 0x0a, 0x0e,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x6f, 0x6c, 0x64, 0x5f, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70,
+	0x4f, 0x6c, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x22, 0x6f, 0x0a, 0x11, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x73, 0x67, 0x12,
+	0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x4d, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x38, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x3f, 0x0a, 0x0a, 0x4a, 0x57, 0x54,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a,
+	0x09, 0x61, 0x70, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x61, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6f, 0x0a, 0x12, 0x49, 0x6e,
+	0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x73, 0x67,
+	0x12, 0x1b, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x22, 0xd5, 0x02, 0x0a, 0x12,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x62,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x75, 0x62, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49,
+	0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6f, 0x74,
+	0x68, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x65, 0x78, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x78, 0x70, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x69, 0x61,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x69, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x75, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x61, 0x75, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x74, 0x69, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6a, 0x74, 0x69, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x11, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4a, 0x57,
+	0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xe4, 0x02, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64,
+	0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f,
+	0x32, 0x66, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x73, 0x65, 0x32, 0x66,
+	0x61, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x73, 0x0a, 0x07, 0x52, 0x6f, 0x6c, 0x65, 0x4d,
+	0x73, 0x67, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0xb3, 0x02, 0x0a,
+	0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67, 0x12,
+	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x52, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x38, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x4d, 0x73, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x07, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x1a, 0xab, 0x01, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x3d, 0x0a, 0x1b, 0x75, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72,
+	0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x75, 0x73, 0x65, 0x52, 0x65, 0x6d, 0x69, 0x6e, 0x64,
+	0x65, 0x72, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x29, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x77, 0x65, 0x65, 0x6b,
+	0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x69, 0x6e,
+	0x64, 0x65, 0x72, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x36, 0x0a, 0x17, 0x6f, 0x6e,
+	0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6f, 0x6e, 0x6c,
+	0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x22, 0x53, 0x0a, 0x1a, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67,
+	0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x21, 0x0a, 0x09,
+	0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x84, 0x02, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x66,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x32, 0x9d, 0x2e, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x4d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x70, 0x69, 0x12, 0x51, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2f,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x82, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
 	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
 	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x82, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x2e,
-	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
-	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
-	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x82, 0x01, 0x0a, 0x15, 0x41, 0x75,
-	0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x31, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x82, 0x01, 0x0a, 0x15, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x31,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x36, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x0e, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x33, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x4d, 0x73, 0x67,
+	0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x82, 0x01, 0x0a, 0x14, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45,
+	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x44, 0x50, 0x12, 0x39, 0x2e, 0x69, 0x6e, 0x66,
+	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49,
+	0x44, 0x50, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7f, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50,
+	0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x36, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x4d, 0x73, 0x67, 0x1a, 0x32, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
 	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x36, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x61,
-	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76,
-	0x0a, 0x0e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c,
-	0x12, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x7a, 0x0a, 0x10, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x12, 0x35, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4d,
+	0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x12, 0x36, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
 	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
-	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61,
-	0x69, 0x6c, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73,
+	0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x8a, 0x01, 0x0a, 0x1a, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c,
+	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
 	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
 	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x82, 0x01, 0x0a, 0x14, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
-	0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x44, 0x50, 0x12,
-	0x39, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70,
+	0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x75, 0x70, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x4d, 0x73, 0x67, 0x1a,
+	0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
 	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
-	0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x49, 0x44, 0x50, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
-	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f,
-	0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x0f, 0x53,
-	0x69, 0x67, 0x6e, 0x75, 0x70, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x34,
+	0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5c, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x57, 0x54, 0x12,
+	0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x52,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x4a, 0x57, 0x4b, 0x53, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x53, 0x4f, 0x4e, 0x57, 0x65, 0x62, 0x4b, 0x65, 0x79, 0x53,
+	0x65, 0x74, 0x12, 0x70, 0x0a, 0x08, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4a, 0x57, 0x54, 0x12, 0x33,
 	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
 	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x61, 0x69,
-	0x6c, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
-	0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x4a, 0x57, 0x54, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
-	0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
-	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x73, 0x12, 0x70, 0x0a, 0x08, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4a, 0x57, 0x54, 0x12,
-	0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x69, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41,
+	0x6c, 0x6c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12,
+	0x38, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
 	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
-	0x70, 0x69, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
-	0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
-	0x41, 0x6c, 0x6c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
-	0x12, 0x38, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
-	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
-	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
-	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x0d, 0x56,
-	0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x2b, 0x2e, 0x69,
-	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
-	0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x8c, 0x01, 0x0a, 0x19, 0x52, 0x65,
-	0x73, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
-	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x6e,
-	0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
-	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x7b, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x31, 0x2e, 0x69,
-	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
-	0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x70, 0x69, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x72, 0x0a, 0x11, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
 	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
-	0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x67, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22, 0x2e,
-	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x1a, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x70, 0x69, 0x2e, 0x4a, 0x57, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x7d,
+	0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
 	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x64,
-	0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
-	0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
 	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x58, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
-	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
-	0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x6f,
-	0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x12, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
-	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x2f,
-	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x6a, 0x0a, 0x13, 0x50, 0x75, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x6d, 0x70,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d,
-	0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x49, 0x6e, 0x74, 0x72, 0x6f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x6a, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66,
 	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
 	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a, 0x07, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x76, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x2f,
-	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x58, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x49, 0x44, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
-	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x71, 0x0a, 0x0d, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x6c, 0x0a, 0x0d, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x66,
 	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73,
-	0x65, 0x72, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x2f, 0x2e, 0x69, 0x6e,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x8c, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x7b, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x31, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x69, 0x6e,
 	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x5e, 0x0a, 0x17,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c,
-	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
-	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75,
-	0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69,
-	0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x84, 0x01, 0x0a,
-	0x15, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x3a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x41,
+	0x70, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x67, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x65, 0x6d, 0x70, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x2b, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x64, 0x0a, 0x11, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x1a, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x58, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x6f, 0x0a, 0x0f, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
+	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x6a, 0x0a, 0x13, 0x50,
+	0x75, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
 	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d,
-	0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
 	0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x8f, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73,
-	0x46, 0x6f, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74,
-	0x12, 0x3d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
-	0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x52,
-	0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x1a,
-	0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
-	0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x46, 0x6f, 0x72, 0x50, 0x57,
-	0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x74, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x32, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x76, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66,
 	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
 	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4f, 0x0a, 0x0b, 0x53,
-	0x61, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x58, 0x0a, 0x14, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x44, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x71, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x5e, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x12, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x7a, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x4f, 0x54, 0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x54, 0x4f, 0x54, 0x50, 0x4d, 0x73, 0x67, 0x1a, 0x38, 0x2e, 0x69, 0x6e, 0x66,
 	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69,
-	0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x51, 0x0a, 0x0d,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x30, 0x2e,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x4f,
+	0x54, 0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x54,
+	0x4f, 0x54, 0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x2e,
 	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
 	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
-	0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12,
-	0x73, 0x0a, 0x13, 0x55, 0x73, 0x65, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x2e, 0x54, 0x4f, 0x54, 0x50, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x12, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x4f, 0x54, 0x50, 0x4d,
+	0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x12, 0x7c, 0x0a, 0x18, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x73, 0x73, 0x6b,
+	0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4d, 0x73, 0x67, 0x1a, 0x32, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x12, 0x78, 0x0a, 0x19, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65,
+	0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50,
+	0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x6a, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73,
+	0x73, 0x6b, 0x65, 0x79, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b,
+	0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x6c, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b,
+	0x65, 0x79, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x7c, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x73,
+	0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x7e, 0x0a, 0x17, 0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x73,
+	0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x84, 0x01, 0x0a, 0x15, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x3a, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x8f, 0x01, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x3d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
 	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
-	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x63, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
-	0x12, 0x37, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
-	0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x08, 0x41, 0x64, 0x64,
-	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x73, 0x46, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x4d, 0x73, 0x67, 0x1a, 0x34, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
 	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
-	0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x66,
+	0x6f, 0x46, 0x6f, 0x72, 0x50, 0x57, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x74, 0x0a, 0x0d, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x32, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x73, 0x67,
+	0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x4f, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x12, 0x51, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x73, 0x0a, 0x13, 0x55, 0x73, 0x65, 0x55, 0x6e, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x54, 0x65, 0x6d, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x63, 0x0a, 0x18, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x37, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
 	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
 	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
-	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75,
-	0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4d, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4b, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x52, 0x6f,
-	0x6c, 0x65, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x6f, 0x6c,
-	0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
-	0x6c, 0x65, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x6f, 0x6c,
-	0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x12, 0x86, 0x01, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x6f, 0x6e,
-	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73,
-	0x12, 0x3c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
-	0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x74, 0x69,
-	0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67, 0x1a, 0x2d,
-	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x51, 0x0a,
-	0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x30, 0x2e, 0x69,
+	0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x73, 0x67, 0x1a,
+	0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x4c, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e,
+	0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4f, 0x0a,
+	0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x2e, 0x69,
+	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x73, 0x67, 0x1a, 0x0e,
+	0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4d,
+	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2f, 0x2e, 0x69,
 	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72,
 	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67, 0x1a, 0x0e,
-	0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x30, 0x01,
-	0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69,
-	0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2f, 0x75, 0x73, 0x65, 0x72,
-	0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2d, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e,
+	0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4b, 0x0a,
+	0x0e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x86, 0x01, 0x0a, 0x17, 0x46,
+	0x69, 0x6e, 0x64, 0x4e, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x6f,
+	0x6e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x4d, 0x73, 0x67, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
+	0x6e, 0x65, 0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x73, 0x67, 0x12, 0x51, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x30, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x69, 0x6e, 0x66, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x30, 0x01, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
+	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2829,154 +4491,216 @@ func file_user_management_user_management_service_proto_rawDescGZIP() []byte {
 }
 
 var file_user_management_user_management_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_user_management_user_management_service_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_user_management_user_management_service_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_user_management_user_management_service_proto_goTypes = []interface{}{
 	(ServiceStatus_StatusValue)(0),       // 0: influenzanet.user_management_api.ServiceStatus.StatusValue
 	(*ServiceStatus)(nil),                // 1: influenzanet.user_management_api.ServiceStatus
 	(*SignupWithEmailMsg)(nil),           // 2: influenzanet.user_management_api.SignupWithEmailMsg
 	(*LoginWithEmailMsg)(nil),            // 3: influenzanet.user_management_api.LoginWithEmailMsg
 	(*LoginWithExternalIDPMsg)(nil),      // 4: influenzanet.user_management_api.LoginWithExternalIDPMsg
-	(*AutoValidateReq)(nil),              // 5: influenzanet.user_management_api.AutoValidateReq
-	(*AutoValidateResponse)(nil),         // 6: influenzanet.user_management_api.AutoValidateResponse
-	(*SendVerificationCodeReq)(nil),      // 7: influenzanet.user_management_api.SendVerificationCodeReq
-	(*LoginResponse)(nil),                // 8: influenzanet.user_management_api.LoginResponse
-	(*UserReference)(nil),                // 9: influenzanet.user_management_api.UserReference
-	(*RevokeRefreshTokensReq)(nil),       // 10: influenzanet.user_management_api.RevokeRefreshTokensReq
-	(*RefreshTokenRequest)(nil),          // 11: influenzanet.user_management_api.RefreshTokenRequest
-	(*AppTokenRequest)(nil),              // 12: influenzanet.user_management_api.AppTokenRequest
-	(*AppTokenValidation)(nil),           // 13: influenzanet.user_management_api.AppTokenValidation
-	(*ProfileRequest)(nil),               // 14: influenzanet.user_management_api.ProfileRequest
-	(*UserAuthInfo)(nil),                 // 15: influenzanet.user_management_api.UserAuthInfo
-	(*ResendContactVerificationReq)(nil), // 16: influenzanet.user_management_api.ResendContactVerificationReq
-	(*PasswordChangeMsg)(nil),            // 17: influenzanet.user_management_api.PasswordChangeMsg
-	(*InitiateResetPasswordMsg)(nil),     // 18: influenzanet.user_management_api.InitiateResetPasswordMsg
-	(*GetInfosForResetPasswordMsg)(nil),  // 19: influenzanet.user_management_api.GetInfosForResetPasswordMsg
-	(*UserInfoForPWReset)(nil),           // 20: influenzanet.user_management_api.UserInfoForPWReset
-	(*ResetPasswordMsg)(nil),             // 21: influenzanet.user_management_api.ResetPasswordMsg
-	(*EmailChangeMsg)(nil),               // 22: influenzanet.user_management_api.EmailChangeMsg
-	(*LanguageChangeMsg)(nil),            // 23: influenzanet.user_management_api.LanguageChangeMsg
-	(*ContactPreferencesMsg)(nil),        // 24: influenzanet.user_management_api.ContactPreferencesMsg
-	(*ContactInfoMsg)(nil),               // 25: influenzanet.user_management_api.ContactInfoMsg
-	(*JWTRequest)(nil),                   // 26: influenzanet.user_management_api.JWTRequest
-	(*RefreshJWTRequest)(nil),            // 27: influenzanet.user_management_api.RefreshJWTRequest
-	(*CreateUserReq)(nil),                // 28: influenzanet.user_management_api.CreateUserReq
-	(*RoleMsg)(nil),                      // 29: influenzanet.user_management_api.RoleMsg
-	(*StreamUsersMsg)(nil),               // 30: influenzanet.user_management_api.StreamUsersMsg
-	(*FindNonParticipantUsersMsg)(nil),   // 31: influenzanet.user_management_api.FindNonParticipantUsersMsg
-	(*UserListMsg)(nil),                  // 32: influenzanet.user_management_api.UserListMsg
-	(*TempToken)(nil),                    // 33: influenzanet.user_management_api.TempToken
-	(*TokenResponse)(nil),                // 34: influenzanet.user_management_api.TokenResponse
-	(*StreamUsersMsg_Filters)(nil),       // 35: influenzanet.user_management_api.StreamUsersMsg.Filters
-	(*User)(nil),                         // 36: inf.user.User
-	(*api_types.TokenInfos)(nil),         // 37: influenzanet.shared.TokenInfos
-	(*Profile)(nil),                      // 38: inf.user.Profile
-	(*ContactPreferences)(nil),           // 39: inf.user.ContactPreferences
-	(*ContactInfo)(nil),                  // 40: inf.user.ContactInfo
-	(*emptypb.Empty)(nil),                // 41: google.protobuf.Empty
-	(*api_types.TempTokenInfo)(nil),      // 42: influenzanet.shared.TempTokenInfo
-	(*api_types.TempTokenInfos)(nil),     // 43: influenzanet.shared.TempTokenInfos
+	(*StartPasskeyLoginMsg)(nil),         // 5: influenzanet.user_management_api.StartPasskeyLoginMsg
+	(*LoginWithPasskeyMsg)(nil),          // 6: influenzanet.user_management_api.LoginWithPasskeyMsg
+	(*PasswordlessLoginMsg)(nil),         // 7: influenzanet.user_management_api.PasswordlessLoginMsg
+	(*PasswordlessLoginTokenMsg)(nil),    // 8: influenzanet.user_management_api.PasswordlessLoginTokenMsg
+	(*AutoValidateReq)(nil),              // 9: influenzanet.user_management_api.AutoValidateReq
+	(*AutoValidateResponse)(nil),         // 10: influenzanet.user_management_api.AutoValidateResponse
+	(*SendVerificationCodeReq)(nil),      // 11: influenzanet.user_management_api.SendVerificationCodeReq
+	(*LoginResponse)(nil),                // 12: influenzanet.user_management_api.LoginResponse
+	(*UserReference)(nil),                // 13: influenzanet.user_management_api.UserReference
+	(*RevokeRefreshTokensReq)(nil),       // 14: influenzanet.user_management_api.RevokeRefreshTokensReq
+	(*RefreshTokenRequest)(nil),          // 15: influenzanet.user_management_api.RefreshTokenRequest
+	(*AppTokenRequest)(nil),              // 16: influenzanet.user_management_api.AppTokenRequest
+	(*AppTokenValidation)(nil),           // 17: influenzanet.user_management_api.AppTokenValidation
+	(*ProfileRequest)(nil),               // 18: influenzanet.user_management_api.ProfileRequest
+	(*UserAuthInfo)(nil),                 // 19: influenzanet.user_management_api.UserAuthInfo
+	(*ResendContactVerificationReq)(nil), // 20: influenzanet.user_management_api.ResendContactVerificationReq
+	(*PasswordChangeMsg)(nil),            // 21: influenzanet.user_management_api.PasswordChangeMsg
+	(*TOTPMsg)(nil),                      // 22: influenzanet.user_management_api.TOTPMsg
+	(*TOTPEnrollmentResponse)(nil),       // 23: influenzanet.user_management_api.TOTPEnrollmentResponse
+	(*PasskeyMsg)(nil),                   // 24: influenzanet.user_management_api.PasskeyMsg
+	(*PasskeyChallenge)(nil),             // 25: influenzanet.user_management_api.PasskeyChallenge
+	(*Passkey)(nil),                      // 26: influenzanet.user_management_api.Passkey
+	(*PasskeyList)(nil),                  // 27: influenzanet.user_management_api.PasskeyList
+	(*RecoveryCodesMsg)(nil),             // 28: influenzanet.user_management_api.RecoveryCodesMsg
+	(*RecoveryCodes)(nil),                // 29: influenzanet.user_management_api.RecoveryCodes
+	(*SessionMsg)(nil),                   // 30: influenzanet.user_management_api.SessionMsg
+	(*Session)(nil),                      // 31: influenzanet.user_management_api.Session
+	(*SessionList)(nil),                  // 32: influenzanet.user_management_api.SessionList
+	(*JSONWebKey)(nil),                   // 33: influenzanet.user_management_api.JSONWebKey
+	(*JSONWebKeySet)(nil),                // 34: influenzanet.user_management_api.JSONWebKeySet
+	(*InitiateResetPasswordMsg)(nil),     // 35: influenzanet.user_management_api.InitiateResetPasswordMsg
+	(*GetInfosForResetPasswordMsg)(nil),  // 36: influenzanet.user_management_api.GetInfosForResetPasswordMsg
+	(*UserInfoForPWReset)(nil),           // 37: influenzanet.user_management_api.UserInfoForPWReset
+	(*ResetPasswordMsg)(nil),             // 38: influenzanet.user_management_api.ResetPasswordMsg
+	(*EmailChangeMsg)(nil),               // 39: influenzanet.user_management_api.EmailChangeMsg
+	(*LanguageChangeMsg)(nil),            // 40: influenzanet.user_management_api.LanguageChangeMsg
+	(*ContactPreferencesMsg)(nil),        // 41: influenzanet.user_management_api.ContactPreferencesMsg
+	(*ContactInfoMsg)(nil),               // 42: influenzanet.user_management_api.ContactInfoMsg
+	(*JWTRequest)(nil),                   // 43: influenzanet.user_management_api.JWTRequest
+	(*IntrospectTokenMsg)(nil),           // 44: influenzanet.user_management_api.IntrospectTokenMsg
+	(*TokenIntrospection)(nil),           // 45: influenzanet.user_management_api.TokenIntrospection
+	(*RefreshJWTRequest)(nil),            // 46: influenzanet.user_management_api.RefreshJWTRequest
+	(*CreateUserReq)(nil),                // 47: influenzanet.user_management_api.CreateUserReq
+	(*RoleMsg)(nil),                      // 48: influenzanet.user_management_api.RoleMsg
+	(*StreamUsersMsg)(nil),               // 49: influenzanet.user_management_api.StreamUsersMsg
+	(*FindNonParticipantUsersMsg)(nil),   // 50: influenzanet.user_management_api.FindNonParticipantUsersMsg
+	(*UserListMsg)(nil),                  // 51: influenzanet.user_management_api.UserListMsg
+	(*TempToken)(nil),                    // 52: influenzanet.user_management_api.TempToken
+	(*TokenResponse)(nil),                // 53: influenzanet.user_management_api.TokenResponse
+	(*StreamUsersMsg_Filters)(nil),       // 54: influenzanet.user_management_api.StreamUsersMsg.Filters
+	(*User)(nil),                         // 55: inf.user.User
+	(*api_types.TokenInfos)(nil),         // 56: influenzanet.shared.TokenInfos
+	(*Profile)(nil),                      // 57: inf.user.Profile
+	(*ContactPreferences)(nil),           // 58: inf.user.ContactPreferences
+	(*ContactInfo)(nil),                  // 59: inf.user.ContactInfo
+	(*emptypb.Empty)(nil),                // 60: google.protobuf.Empty
+	(*api_types.TempTokenInfo)(nil),      // 61: influenzanet.shared.TempTokenInfo
+	(*api_types.TempTokenInfos)(nil),     // 62: influenzanet.shared.TempTokenInfos
 }
 var file_user_management_user_management_service_proto_depIdxs = []int32{
 	0,  // 0: influenzanet.user_management_api.ServiceStatus.status:type_name -> influenzanet.user_management_api.ServiceStatus.StatusValue
-	34, // 1: influenzanet.user_management_api.LoginResponse.token:type_name -> influenzanet.user_management_api.TokenResponse
-	36, // 2: influenzanet.user_management_api.LoginResponse.user:type_name -> inf.user.User
-	37, // 3: influenzanet.user_management_api.UserReference.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 4: influenzanet.user_management_api.RevokeRefreshTokensReq.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 5: influenzanet.user_management_api.ProfileRequest.token:type_name -> influenzanet.shared.TokenInfos
-	38, // 6: influenzanet.user_management_api.ProfileRequest.profile:type_name -> inf.user.Profile
-	38, // 7: influenzanet.user_management_api.UserAuthInfo.profiles:type_name -> inf.user.Profile
-	38, // 8: influenzanet.user_management_api.UserAuthInfo.selected_profile:type_name -> inf.user.Profile
-	37, // 9: influenzanet.user_management_api.ResendContactVerificationReq.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 10: influenzanet.user_management_api.PasswordChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 11: influenzanet.user_management_api.EmailChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 12: influenzanet.user_management_api.LanguageChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 13: influenzanet.user_management_api.ContactPreferencesMsg.token:type_name -> influenzanet.shared.TokenInfos
-	39, // 14: influenzanet.user_management_api.ContactPreferencesMsg.contact_preferences:type_name -> inf.user.ContactPreferences
-	37, // 15: influenzanet.user_management_api.ContactInfoMsg.token:type_name -> influenzanet.shared.TokenInfos
-	40, // 16: influenzanet.user_management_api.ContactInfoMsg.contact_info:type_name -> inf.user.ContactInfo
-	37, // 17: influenzanet.user_management_api.CreateUserReq.token:type_name -> influenzanet.shared.TokenInfos
-	37, // 18: influenzanet.user_management_api.RoleMsg.token:type_name -> influenzanet.shared.TokenInfos
-	35, // 19: influenzanet.user_management_api.StreamUsersMsg.filters:type_name -> influenzanet.user_management_api.StreamUsersMsg.Filters
-	37, // 20: influenzanet.user_management_api.FindNonParticipantUsersMsg.token:type_name -> influenzanet.shared.TokenInfos
-	36, // 21: influenzanet.user_management_api.UserListMsg.users:type_name -> inf.user.User
-	38, // 22: influenzanet.user_management_api.TokenResponse.profiles:type_name -> inf.user.Profile
-	41, // 23: influenzanet.user_management_api.UserManagementApi.Status:input_type -> google.protobuf.Empty
-	7,  // 24: influenzanet.user_management_api.UserManagementApi.SendVerificationCode:input_type -> influenzanet.user_management_api.SendVerificationCodeReq
-	5,  // 25: influenzanet.user_management_api.UserManagementApi.AutoValidateTempToken:input_type -> influenzanet.user_management_api.AutoValidateReq
-	3,  // 26: influenzanet.user_management_api.UserManagementApi.LoginWithEmail:input_type -> influenzanet.user_management_api.LoginWithEmailMsg
-	4,  // 27: influenzanet.user_management_api.UserManagementApi.LoginWithExternalIDP:input_type -> influenzanet.user_management_api.LoginWithExternalIDPMsg
-	2,  // 28: influenzanet.user_management_api.UserManagementApi.SignupWithEmail:input_type -> influenzanet.user_management_api.SignupWithEmailMsg
-	26, // 29: influenzanet.user_management_api.UserManagementApi.ValidateJWT:input_type -> influenzanet.user_management_api.JWTRequest
-	27, // 30: influenzanet.user_management_api.UserManagementApi.RenewJWT:input_type -> influenzanet.user_management_api.RefreshJWTRequest
-	10, // 31: influenzanet.user_management_api.UserManagementApi.RevokeAllRefreshTokens:input_type -> influenzanet.user_management_api.RevokeRefreshTokensReq
-	33, // 32: influenzanet.user_management_api.UserManagementApi.VerifyContact:input_type -> influenzanet.user_management_api.TempToken
-	16, // 33: influenzanet.user_management_api.UserManagementApi.ResendContactVerification:input_type -> influenzanet.user_management_api.ResendContactVerificationReq
-	12, // 34: influenzanet.user_management_api.UserManagementApi.ValidateAppToken:input_type -> influenzanet.user_management_api.AppTokenRequest
-	42, // 35: influenzanet.user_management_api.UserManagementApi.GetOrCreateTemptoken:input_type -> influenzanet.shared.TempTokenInfo
-	42, // 36: influenzanet.user_management_api.UserManagementApi.GenerateTempToken:input_type -> influenzanet.shared.TempTokenInfo
-	42, // 37: influenzanet.user_management_api.UserManagementApi.GetTempTokens:input_type -> influenzanet.shared.TempTokenInfo
-	33, // 38: influenzanet.user_management_api.UserManagementApi.DeleteTempToken:input_type -> influenzanet.user_management_api.TempToken
-	42, // 39: influenzanet.user_management_api.UserManagementApi.PurgeUserTempTokens:input_type -> influenzanet.shared.TempTokenInfo
-	9,  // 40: influenzanet.user_management_api.UserManagementApi.GetUser:input_type -> influenzanet.user_management_api.UserReference
-	17, // 41: influenzanet.user_management_api.UserManagementApi.ChangePassword:input_type -> influenzanet.user_management_api.PasswordChangeMsg
-	22, // 42: influenzanet.user_management_api.UserManagementApi.ChangeAccountIDEmail:input_type -> influenzanet.user_management_api.EmailChangeMsg
-	9,  // 43: influenzanet.user_management_api.UserManagementApi.DeleteAccount:input_type -> influenzanet.user_management_api.UserReference
-	23, // 44: influenzanet.user_management_api.UserManagementApi.ChangePreferredLanguage:input_type -> influenzanet.user_management_api.LanguageChangeMsg
-	18, // 45: influenzanet.user_management_api.UserManagementApi.InitiatePasswordReset:input_type -> influenzanet.user_management_api.InitiateResetPasswordMsg
-	19, // 46: influenzanet.user_management_api.UserManagementApi.GetInfosForPasswordReset:input_type -> influenzanet.user_management_api.GetInfosForResetPasswordMsg
-	21, // 47: influenzanet.user_management_api.UserManagementApi.ResetPassword:input_type -> influenzanet.user_management_api.ResetPasswordMsg
-	14, // 48: influenzanet.user_management_api.UserManagementApi.SaveProfile:input_type -> influenzanet.user_management_api.ProfileRequest
-	14, // 49: influenzanet.user_management_api.UserManagementApi.RemoveProfile:input_type -> influenzanet.user_management_api.ProfileRequest
-	33, // 50: influenzanet.user_management_api.UserManagementApi.UseUnsubscribeToken:input_type -> influenzanet.user_management_api.TempToken
-	24, // 51: influenzanet.user_management_api.UserManagementApi.UpdateContactPreferences:input_type -> influenzanet.user_management_api.ContactPreferencesMsg
-	25, // 52: influenzanet.user_management_api.UserManagementApi.AddEmail:input_type -> influenzanet.user_management_api.ContactInfoMsg
-	25, // 53: influenzanet.user_management_api.UserManagementApi.RemoveEmail:input_type -> influenzanet.user_management_api.ContactInfoMsg
-	28, // 54: influenzanet.user_management_api.UserManagementApi.CreateUser:input_type -> influenzanet.user_management_api.CreateUserReq
-	29, // 55: influenzanet.user_management_api.UserManagementApi.AddRoleForUser:input_type -> influenzanet.user_management_api.RoleMsg
-	29, // 56: influenzanet.user_management_api.UserManagementApi.RemoveRoleForUser:input_type -> influenzanet.user_management_api.RoleMsg
-	31, // 57: influenzanet.user_management_api.UserManagementApi.FindNonParticipantUsers:input_type -> influenzanet.user_management_api.FindNonParticipantUsersMsg
-	30, // 58: influenzanet.user_management_api.UserManagementApi.StreamUsers:input_type -> influenzanet.user_management_api.StreamUsersMsg
-	1,  // 59: influenzanet.user_management_api.UserManagementApi.Status:output_type -> influenzanet.user_management_api.ServiceStatus
-	1,  // 60: influenzanet.user_management_api.UserManagementApi.SendVerificationCode:output_type -> influenzanet.user_management_api.ServiceStatus
-	6,  // 61: influenzanet.user_management_api.UserManagementApi.AutoValidateTempToken:output_type -> influenzanet.user_management_api.AutoValidateResponse
-	8,  // 62: influenzanet.user_management_api.UserManagementApi.LoginWithEmail:output_type -> influenzanet.user_management_api.LoginResponse
-	8,  // 63: influenzanet.user_management_api.UserManagementApi.LoginWithExternalIDP:output_type -> influenzanet.user_management_api.LoginResponse
-	34, // 64: influenzanet.user_management_api.UserManagementApi.SignupWithEmail:output_type -> influenzanet.user_management_api.TokenResponse
-	37, // 65: influenzanet.user_management_api.UserManagementApi.ValidateJWT:output_type -> influenzanet.shared.TokenInfos
-	34, // 66: influenzanet.user_management_api.UserManagementApi.RenewJWT:output_type -> influenzanet.user_management_api.TokenResponse
-	1,  // 67: influenzanet.user_management_api.UserManagementApi.RevokeAllRefreshTokens:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 68: influenzanet.user_management_api.UserManagementApi.VerifyContact:output_type -> inf.user.User
-	1,  // 69: influenzanet.user_management_api.UserManagementApi.ResendContactVerification:output_type -> influenzanet.user_management_api.ServiceStatus
-	13, // 70: influenzanet.user_management_api.UserManagementApi.ValidateAppToken:output_type -> influenzanet.user_management_api.AppTokenValidation
-	33, // 71: influenzanet.user_management_api.UserManagementApi.GetOrCreateTemptoken:output_type -> influenzanet.user_management_api.TempToken
-	33, // 72: influenzanet.user_management_api.UserManagementApi.GenerateTempToken:output_type -> influenzanet.user_management_api.TempToken
-	43, // 73: influenzanet.user_management_api.UserManagementApi.GetTempTokens:output_type -> influenzanet.shared.TempTokenInfos
-	1,  // 74: influenzanet.user_management_api.UserManagementApi.DeleteTempToken:output_type -> influenzanet.user_management_api.ServiceStatus
-	1,  // 75: influenzanet.user_management_api.UserManagementApi.PurgeUserTempTokens:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 76: influenzanet.user_management_api.UserManagementApi.GetUser:output_type -> inf.user.User
-	1,  // 77: influenzanet.user_management_api.UserManagementApi.ChangePassword:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 78: influenzanet.user_management_api.UserManagementApi.ChangeAccountIDEmail:output_type -> inf.user.User
-	1,  // 79: influenzanet.user_management_api.UserManagementApi.DeleteAccount:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 80: influenzanet.user_management_api.UserManagementApi.ChangePreferredLanguage:output_type -> inf.user.User
-	1,  // 81: influenzanet.user_management_api.UserManagementApi.InitiatePasswordReset:output_type -> influenzanet.user_management_api.ServiceStatus
-	20, // 82: influenzanet.user_management_api.UserManagementApi.GetInfosForPasswordReset:output_type -> influenzanet.user_management_api.UserInfoForPWReset
-	1,  // 83: influenzanet.user_management_api.UserManagementApi.ResetPassword:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 84: influenzanet.user_management_api.UserManagementApi.SaveProfile:output_type -> inf.user.User
-	36, // 85: influenzanet.user_management_api.UserManagementApi.RemoveProfile:output_type -> inf.user.User
-	1,  // 86: influenzanet.user_management_api.UserManagementApi.UseUnsubscribeToken:output_type -> influenzanet.user_management_api.ServiceStatus
-	36, // 87: influenzanet.user_management_api.UserManagementApi.UpdateContactPreferences:output_type -> inf.user.User
-	36, // 88: influenzanet.user_management_api.UserManagementApi.AddEmail:output_type -> inf.user.User
-	36, // 89: influenzanet.user_management_api.UserManagementApi.RemoveEmail:output_type -> inf.user.User
-	36, // 90: influenzanet.user_management_api.UserManagementApi.CreateUser:output_type -> inf.user.User
-	36, // 91: influenzanet.user_management_api.UserManagementApi.AddRoleForUser:output_type -> inf.user.User
-	36, // 92: influenzanet.user_management_api.UserManagementApi.RemoveRoleForUser:output_type -> inf.user.User
-	32, // 93: influenzanet.user_management_api.UserManagementApi.FindNonParticipantUsers:output_type -> influenzanet.user_management_api.UserListMsg
-	36, // 94: influenzanet.user_management_api.UserManagementApi.StreamUsers:output_type -> inf.user.User
-	59, // [59:95] is the sub-list for method output_type
-	23, // [23:59] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	53, // 1: influenzanet.user_management_api.LoginResponse.token:type_name -> influenzanet.user_management_api.TokenResponse
+	55, // 2: influenzanet.user_management_api.LoginResponse.user:type_name -> inf.user.User
+	56, // 3: influenzanet.user_management_api.UserReference.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 4: influenzanet.user_management_api.RevokeRefreshTokensReq.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 5: influenzanet.user_management_api.ProfileRequest.token:type_name -> influenzanet.shared.TokenInfos
+	57, // 6: influenzanet.user_management_api.ProfileRequest.profile:type_name -> inf.user.Profile
+	57, // 7: influenzanet.user_management_api.UserAuthInfo.profiles:type_name -> inf.user.Profile
+	57, // 8: influenzanet.user_management_api.UserAuthInfo.selected_profile:type_name -> inf.user.Profile
+	56, // 9: influenzanet.user_management_api.ResendContactVerificationReq.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 10: influenzanet.user_management_api.PasswordChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 11: influenzanet.user_management_api.TOTPMsg.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 12: influenzanet.user_management_api.PasskeyMsg.token:type_name -> influenzanet.shared.TokenInfos
+	26, // 13: influenzanet.user_management_api.PasskeyList.passkeys:type_name -> influenzanet.user_management_api.Passkey
+	56, // 14: influenzanet.user_management_api.RecoveryCodesMsg.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 15: influenzanet.user_management_api.SessionMsg.token:type_name -> influenzanet.shared.TokenInfos
+	31, // 16: influenzanet.user_management_api.SessionList.sessions:type_name -> influenzanet.user_management_api.Session
+	33, // 17: influenzanet.user_management_api.JSONWebKeySet.keys:type_name -> influenzanet.user_management_api.JSONWebKey
+	56, // 18: influenzanet.user_management_api.EmailChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 19: influenzanet.user_management_api.LanguageChangeMsg.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 20: influenzanet.user_management_api.ContactPreferencesMsg.token:type_name -> influenzanet.shared.TokenInfos
+	58, // 21: influenzanet.user_management_api.ContactPreferencesMsg.contact_preferences:type_name -> inf.user.ContactPreferences
+	56, // 22: influenzanet.user_management_api.ContactInfoMsg.token:type_name -> influenzanet.shared.TokenInfos
+	59, // 23: influenzanet.user_management_api.ContactInfoMsg.contact_info:type_name -> inf.user.ContactInfo
+	56, // 24: influenzanet.user_management_api.CreateUserReq.token:type_name -> influenzanet.shared.TokenInfos
+	56, // 25: influenzanet.user_management_api.RoleMsg.token:type_name -> influenzanet.shared.TokenInfos
+	54, // 26: influenzanet.user_management_api.StreamUsersMsg.filters:type_name -> influenzanet.user_management_api.StreamUsersMsg.Filters
+	56, // 27: influenzanet.user_management_api.FindNonParticipantUsersMsg.token:type_name -> influenzanet.shared.TokenInfos
+	55, // 28: influenzanet.user_management_api.UserListMsg.users:type_name -> inf.user.User
+	57, // 29: influenzanet.user_management_api.TokenResponse.profiles:type_name -> inf.user.Profile
+	60, // 30: influenzanet.user_management_api.UserManagementApi.Status:input_type -> google.protobuf.Empty
+	11, // 31: influenzanet.user_management_api.UserManagementApi.SendVerificationCode:input_type -> influenzanet.user_management_api.SendVerificationCodeReq
+	9,  // 32: influenzanet.user_management_api.UserManagementApi.AutoValidateTempToken:input_type -> influenzanet.user_management_api.AutoValidateReq
+	3,  // 33: influenzanet.user_management_api.UserManagementApi.LoginWithEmail:input_type -> influenzanet.user_management_api.LoginWithEmailMsg
+	4,  // 34: influenzanet.user_management_api.UserManagementApi.LoginWithExternalIDP:input_type -> influenzanet.user_management_api.LoginWithExternalIDPMsg
+	5,  // 35: influenzanet.user_management_api.UserManagementApi.StartPasskeyLogin:input_type -> influenzanet.user_management_api.StartPasskeyLoginMsg
+	6,  // 36: influenzanet.user_management_api.UserManagementApi.LoginWithPasskey:input_type -> influenzanet.user_management_api.LoginWithPasskeyMsg
+	7,  // 37: influenzanet.user_management_api.UserManagementApi.RequestPasswordlessLogin:input_type -> influenzanet.user_management_api.PasswordlessLoginMsg
+	8,  // 38: influenzanet.user_management_api.UserManagementApi.LoginWithPasswordlessToken:input_type -> influenzanet.user_management_api.PasswordlessLoginTokenMsg
+	2,  // 39: influenzanet.user_management_api.UserManagementApi.SignupWithEmail:input_type -> influenzanet.user_management_api.SignupWithEmailMsg
+	43, // 40: influenzanet.user_management_api.UserManagementApi.ValidateJWT:input_type -> influenzanet.user_management_api.JWTRequest
+	60, // 41: influenzanet.user_management_api.UserManagementApi.GetJWKS:input_type -> google.protobuf.Empty
+	46, // 42: influenzanet.user_management_api.UserManagementApi.RenewJWT:input_type -> influenzanet.user_management_api.RefreshJWTRequest
+	14, // 43: influenzanet.user_management_api.UserManagementApi.RevokeAllRefreshTokens:input_type -> influenzanet.user_management_api.RevokeRefreshTokensReq
+	43, // 44: influenzanet.user_management_api.UserManagementApi.RevokeAccessToken:input_type -> influenzanet.user_management_api.JWTRequest
+	44, // 45: influenzanet.user_management_api.UserManagementApi.IntrospectToken:input_type -> influenzanet.user_management_api.IntrospectTokenMsg
+	30, // 46: influenzanet.user_management_api.UserManagementApi.GetSessions:input_type -> influenzanet.user_management_api.SessionMsg
+	30, // 47: influenzanet.user_management_api.UserManagementApi.RevokeSession:input_type -> influenzanet.user_management_api.SessionMsg
+	52, // 48: influenzanet.user_management_api.UserManagementApi.VerifyContact:input_type -> influenzanet.user_management_api.TempToken
+	20, // 49: influenzanet.user_management_api.UserManagementApi.ResendContactVerification:input_type -> influenzanet.user_management_api.ResendContactVerificationReq
+	16, // 50: influenzanet.user_management_api.UserManagementApi.ValidateAppToken:input_type -> influenzanet.user_management_api.AppTokenRequest
+	61, // 51: influenzanet.user_management_api.UserManagementApi.GetOrCreateTemptoken:input_type -> influenzanet.shared.TempTokenInfo
+	61, // 52: influenzanet.user_management_api.UserManagementApi.GenerateTempToken:input_type -> influenzanet.shared.TempTokenInfo
+	61, // 53: influenzanet.user_management_api.UserManagementApi.GetTempTokens:input_type -> influenzanet.shared.TempTokenInfo
+	52, // 54: influenzanet.user_management_api.UserManagementApi.DeleteTempToken:input_type -> influenzanet.user_management_api.TempToken
+	61, // 55: influenzanet.user_management_api.UserManagementApi.PurgeUserTempTokens:input_type -> influenzanet.shared.TempTokenInfo
+	13, // 56: influenzanet.user_management_api.UserManagementApi.GetUser:input_type -> influenzanet.user_management_api.UserReference
+	21, // 57: influenzanet.user_management_api.UserManagementApi.ChangePassword:input_type -> influenzanet.user_management_api.PasswordChangeMsg
+	39, // 58: influenzanet.user_management_api.UserManagementApi.ChangeAccountIDEmail:input_type -> influenzanet.user_management_api.EmailChangeMsg
+	13, // 59: influenzanet.user_management_api.UserManagementApi.DeleteAccount:input_type -> influenzanet.user_management_api.UserReference
+	40, // 60: influenzanet.user_management_api.UserManagementApi.ChangePreferredLanguage:input_type -> influenzanet.user_management_api.LanguageChangeMsg
+	22, // 61: influenzanet.user_management_api.UserManagementApi.StartTOTPEnrollment:input_type -> influenzanet.user_management_api.TOTPMsg
+	22, // 62: influenzanet.user_management_api.UserManagementApi.ConfirmTOTPEnrollment:input_type -> influenzanet.user_management_api.TOTPMsg
+	22, // 63: influenzanet.user_management_api.UserManagementApi.DisableTOTP:input_type -> influenzanet.user_management_api.TOTPMsg
+	24, // 64: influenzanet.user_management_api.UserManagementApi.StartPasskeyRegistration:input_type -> influenzanet.user_management_api.PasskeyMsg
+	24, // 65: influenzanet.user_management_api.UserManagementApi.FinishPasskeyRegistration:input_type -> influenzanet.user_management_api.PasskeyMsg
+	24, // 66: influenzanet.user_management_api.UserManagementApi.GetPasskeys:input_type -> influenzanet.user_management_api.PasskeyMsg
+	24, // 67: influenzanet.user_management_api.UserManagementApi.RemovePasskey:input_type -> influenzanet.user_management_api.PasskeyMsg
+	28, // 68: influenzanet.user_management_api.UserManagementApi.GenerateRecoveryCodes:input_type -> influenzanet.user_management_api.RecoveryCodesMsg
+	28, // 69: influenzanet.user_management_api.UserManagementApi.RegenerateRecoveryCodes:input_type -> influenzanet.user_management_api.RecoveryCodesMsg
+	35, // 70: influenzanet.user_management_api.UserManagementApi.InitiatePasswordReset:input_type -> influenzanet.user_management_api.InitiateResetPasswordMsg
+	36, // 71: influenzanet.user_management_api.UserManagementApi.GetInfosForPasswordReset:input_type -> influenzanet.user_management_api.GetInfosForResetPasswordMsg
+	38, // 72: influenzanet.user_management_api.UserManagementApi.ResetPassword:input_type -> influenzanet.user_management_api.ResetPasswordMsg
+	18, // 73: influenzanet.user_management_api.UserManagementApi.SaveProfile:input_type -> influenzanet.user_management_api.ProfileRequest
+	18, // 74: influenzanet.user_management_api.UserManagementApi.RemoveProfile:input_type -> influenzanet.user_management_api.ProfileRequest
+	52, // 75: influenzanet.user_management_api.UserManagementApi.UseUnsubscribeToken:input_type -> influenzanet.user_management_api.TempToken
+	41, // 76: influenzanet.user_management_api.UserManagementApi.UpdateContactPreferences:input_type -> influenzanet.user_management_api.ContactPreferencesMsg
+	42, // 77: influenzanet.user_management_api.UserManagementApi.AddEmail:input_type -> influenzanet.user_management_api.ContactInfoMsg
+	42, // 78: influenzanet.user_management_api.UserManagementApi.RemoveEmail:input_type -> influenzanet.user_management_api.ContactInfoMsg
+	47, // 79: influenzanet.user_management_api.UserManagementApi.CreateUser:input_type -> influenzanet.user_management_api.CreateUserReq
+	48, // 80: influenzanet.user_management_api.UserManagementApi.AddRoleForUser:input_type -> influenzanet.user_management_api.RoleMsg
+	48, // 81: influenzanet.user_management_api.UserManagementApi.RemoveRoleForUser:input_type -> influenzanet.user_management_api.RoleMsg
+	50, // 82: influenzanet.user_management_api.UserManagementApi.FindNonParticipantUsers:input_type -> influenzanet.user_management_api.FindNonParticipantUsersMsg
+	49, // 83: influenzanet.user_management_api.UserManagementApi.StreamUsers:input_type -> influenzanet.user_management_api.StreamUsersMsg
+	1,  // 84: influenzanet.user_management_api.UserManagementApi.Status:output_type -> influenzanet.user_management_api.ServiceStatus
+	1,  // 85: influenzanet.user_management_api.UserManagementApi.SendVerificationCode:output_type -> influenzanet.user_management_api.ServiceStatus
+	10, // 86: influenzanet.user_management_api.UserManagementApi.AutoValidateTempToken:output_type -> influenzanet.user_management_api.AutoValidateResponse
+	12, // 87: influenzanet.user_management_api.UserManagementApi.LoginWithEmail:output_type -> influenzanet.user_management_api.LoginResponse
+	12, // 88: influenzanet.user_management_api.UserManagementApi.LoginWithExternalIDP:output_type -> influenzanet.user_management_api.LoginResponse
+	25, // 89: influenzanet.user_management_api.UserManagementApi.StartPasskeyLogin:output_type -> influenzanet.user_management_api.PasskeyChallenge
+	12, // 90: influenzanet.user_management_api.UserManagementApi.LoginWithPasskey:output_type -> influenzanet.user_management_api.LoginResponse
+	1,  // 91: influenzanet.user_management_api.UserManagementApi.RequestPasswordlessLogin:output_type -> influenzanet.user_management_api.ServiceStatus
+	12, // 92: influenzanet.user_management_api.UserManagementApi.LoginWithPasswordlessToken:output_type -> influenzanet.user_management_api.LoginResponse
+	53, // 93: influenzanet.user_management_api.UserManagementApi.SignupWithEmail:output_type -> influenzanet.user_management_api.TokenResponse
+	56, // 94: influenzanet.user_management_api.UserManagementApi.ValidateJWT:output_type -> influenzanet.shared.TokenInfos
+	34, // 95: influenzanet.user_management_api.UserManagementApi.GetJWKS:output_type -> influenzanet.user_management_api.JSONWebKeySet
+	53, // 96: influenzanet.user_management_api.UserManagementApi.RenewJWT:output_type -> influenzanet.user_management_api.TokenResponse
+	1,  // 97: influenzanet.user_management_api.UserManagementApi.RevokeAllRefreshTokens:output_type -> influenzanet.user_management_api.ServiceStatus
+	1,  // 98: influenzanet.user_management_api.UserManagementApi.RevokeAccessToken:output_type -> influenzanet.user_management_api.ServiceStatus
+	45, // 99: influenzanet.user_management_api.UserManagementApi.IntrospectToken:output_type -> influenzanet.user_management_api.TokenIntrospection
+	32, // 100: influenzanet.user_management_api.UserManagementApi.GetSessions:output_type -> influenzanet.user_management_api.SessionList
+	32, // 101: influenzanet.user_management_api.UserManagementApi.RevokeSession:output_type -> influenzanet.user_management_api.SessionList
+	55, // 102: influenzanet.user_management_api.UserManagementApi.VerifyContact:output_type -> inf.user.User
+	1,  // 103: influenzanet.user_management_api.UserManagementApi.ResendContactVerification:output_type -> influenzanet.user_management_api.ServiceStatus
+	17, // 104: influenzanet.user_management_api.UserManagementApi.ValidateAppToken:output_type -> influenzanet.user_management_api.AppTokenValidation
+	52, // 105: influenzanet.user_management_api.UserManagementApi.GetOrCreateTemptoken:output_type -> influenzanet.user_management_api.TempToken
+	52, // 106: influenzanet.user_management_api.UserManagementApi.GenerateTempToken:output_type -> influenzanet.user_management_api.TempToken
+	62, // 107: influenzanet.user_management_api.UserManagementApi.GetTempTokens:output_type -> influenzanet.shared.TempTokenInfos
+	1,  // 108: influenzanet.user_management_api.UserManagementApi.DeleteTempToken:output_type -> influenzanet.user_management_api.ServiceStatus
+	1,  // 109: influenzanet.user_management_api.UserManagementApi.PurgeUserTempTokens:output_type -> influenzanet.user_management_api.ServiceStatus
+	55, // 110: influenzanet.user_management_api.UserManagementApi.GetUser:output_type -> inf.user.User
+	1,  // 111: influenzanet.user_management_api.UserManagementApi.ChangePassword:output_type -> influenzanet.user_management_api.ServiceStatus
+	55, // 112: influenzanet.user_management_api.UserManagementApi.ChangeAccountIDEmail:output_type -> inf.user.User
+	1,  // 113: influenzanet.user_management_api.UserManagementApi.DeleteAccount:output_type -> influenzanet.user_management_api.ServiceStatus
+	55, // 114: influenzanet.user_management_api.UserManagementApi.ChangePreferredLanguage:output_type -> inf.user.User
+	23, // 115: influenzanet.user_management_api.UserManagementApi.StartTOTPEnrollment:output_type -> influenzanet.user_management_api.TOTPEnrollmentResponse
+	55, // 116: influenzanet.user_management_api.UserManagementApi.ConfirmTOTPEnrollment:output_type -> inf.user.User
+	55, // 117: influenzanet.user_management_api.UserManagementApi.DisableTOTP:output_type -> inf.user.User
+	25, // 118: influenzanet.user_management_api.UserManagementApi.StartPasskeyRegistration:output_type -> influenzanet.user_management_api.PasskeyChallenge
+	27, // 119: influenzanet.user_management_api.UserManagementApi.FinishPasskeyRegistration:output_type -> influenzanet.user_management_api.PasskeyList
+	27, // 120: influenzanet.user_management_api.UserManagementApi.GetPasskeys:output_type -> influenzanet.user_management_api.PasskeyList
+	27, // 121: influenzanet.user_management_api.UserManagementApi.RemovePasskey:output_type -> influenzanet.user_management_api.PasskeyList
+	29, // 122: influenzanet.user_management_api.UserManagementApi.GenerateRecoveryCodes:output_type -> influenzanet.user_management_api.RecoveryCodes
+	29, // 123: influenzanet.user_management_api.UserManagementApi.RegenerateRecoveryCodes:output_type -> influenzanet.user_management_api.RecoveryCodes
+	1,  // 124: influenzanet.user_management_api.UserManagementApi.InitiatePasswordReset:output_type -> influenzanet.user_management_api.ServiceStatus
+	37, // 125: influenzanet.user_management_api.UserManagementApi.GetInfosForPasswordReset:output_type -> influenzanet.user_management_api.UserInfoForPWReset
+	1,  // 126: influenzanet.user_management_api.UserManagementApi.ResetPassword:output_type -> influenzanet.user_management_api.ServiceStatus
+	55, // 127: influenzanet.user_management_api.UserManagementApi.SaveProfile:output_type -> inf.user.User
+	55, // 128: influenzanet.user_management_api.UserManagementApi.RemoveProfile:output_type -> inf.user.User
+	1,  // 129: influenzanet.user_management_api.UserManagementApi.UseUnsubscribeToken:output_type -> influenzanet.user_management_api.ServiceStatus
+	55, // 130: influenzanet.user_management_api.UserManagementApi.UpdateContactPreferences:output_type -> inf.user.User
+	55, // 131: influenzanet.user_management_api.UserManagementApi.AddEmail:output_type -> inf.user.User
+	55, // 132: influenzanet.user_management_api.UserManagementApi.RemoveEmail:output_type -> inf.user.User
+	55, // 133: influenzanet.user_management_api.UserManagementApi.CreateUser:output_type -> inf.user.User
+	55, // 134: influenzanet.user_management_api.UserManagementApi.AddRoleForUser:output_type -> inf.user.User
+	55, // 135: influenzanet.user_management_api.UserManagementApi.RemoveRoleForUser:output_type -> inf.user.User
+	51, // 136: influenzanet.user_management_api.UserManagementApi.FindNonParticipantUsers:output_type -> influenzanet.user_management_api.UserListMsg
+	55, // 137: influenzanet.user_management_api.UserManagementApi.StreamUsers:output_type -> inf.user.User
+	84, // [84:138] is the sub-list for method output_type
+	30, // [30:84] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_user_management_user_management_service_proto_init() }
@@ -3035,7 +4759,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AutoValidateReq); i {
+			switch v := v.(*StartPasskeyLoginMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3047,7 +4771,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AutoValidateResponse); i {
+			switch v := v.(*LoginWithPasskeyMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3059,7 +4783,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendVerificationCodeReq); i {
+			switch v := v.(*PasswordlessLoginMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3071,7 +4795,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginResponse); i {
+			switch v := v.(*PasswordlessLoginTokenMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3083,7 +4807,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserReference); i {
+			switch v := v.(*AutoValidateReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3095,7 +4819,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RevokeRefreshTokensReq); i {
+			switch v := v.(*AutoValidateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3107,7 +4831,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RefreshTokenRequest); i {
+			switch v := v.(*SendVerificationCodeReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3119,7 +4843,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppTokenRequest); i {
+			switch v := v.(*LoginResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3131,7 +4855,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppTokenValidation); i {
+			switch v := v.(*UserReference); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3143,7 +4867,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProfileRequest); i {
+			switch v := v.(*RevokeRefreshTokensReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3155,7 +4879,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserAuthInfo); i {
+			switch v := v.(*RefreshTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3167,7 +4891,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResendContactVerificationReq); i {
+			switch v := v.(*AppTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3179,7 +4903,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PasswordChangeMsg); i {
+			switch v := v.(*AppTokenValidation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3191,7 +4915,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitiateResetPasswordMsg); i {
+			switch v := v.(*ProfileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3203,7 +4927,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetInfosForResetPasswordMsg); i {
+			switch v := v.(*UserAuthInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3215,7 +4939,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserInfoForPWReset); i {
+			switch v := v.(*ResendContactVerificationReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3227,7 +4951,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResetPasswordMsg); i {
+			switch v := v.(*PasswordChangeMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3239,7 +4963,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmailChangeMsg); i {
+			switch v := v.(*TOTPMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3251,7 +4975,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LanguageChangeMsg); i {
+			switch v := v.(*TOTPEnrollmentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3263,7 +4987,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ContactPreferencesMsg); i {
+			switch v := v.(*PasskeyMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3275,7 +4999,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ContactInfoMsg); i {
+			switch v := v.(*PasskeyChallenge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3287,7 +5011,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JWTRequest); i {
+			switch v := v.(*Passkey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3299,7 +5023,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RefreshJWTRequest); i {
+			switch v := v.(*PasskeyList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3311,7 +5035,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateUserReq); i {
+			switch v := v.(*RecoveryCodesMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3323,7 +5047,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoleMsg); i {
+			switch v := v.(*RecoveryCodes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3335,7 +5059,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamUsersMsg); i {
+			switch v := v.(*SessionMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3347,7 +5071,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindNonParticipantUsersMsg); i {
+			switch v := v.(*Session); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3359,7 +5083,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserListMsg); i {
+			switch v := v.(*SessionList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3371,7 +5095,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TempToken); i {
+			switch v := v.(*JSONWebKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3383,7 +5107,7 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenResponse); i {
+			switch v := v.(*JSONWebKeySet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3395,6 +5119,234 @@ func file_user_management_user_management_service_proto_init() {
 			}
 		}
 		file_user_management_user_management_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitiateResetPasswordMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInfosForResetPasswordMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserInfoForPWReset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetPasswordMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmailChangeMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LanguageChangeMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContactPreferencesMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContactInfoMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JWTRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntrospectTokenMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenIntrospection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshJWTRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUserReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamUsersMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindNonParticipantUsersMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserListMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TempToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_management_user_management_service_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamUsersMsg_Filters); i {
 			case 0:
 				return &v.state
@@ -3413,7 +5365,7 @@ func file_user_management_user_management_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_user_management_user_management_service_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   35,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
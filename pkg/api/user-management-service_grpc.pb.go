@@ -25,11 +25,21 @@ type UserManagementApiClient interface {
 	SendVerificationCode(ctx context.Context, in *SendVerificationCodeReq, opts ...grpc.CallOption) (*ServiceStatus, error)
 	AutoValidateTempToken(ctx context.Context, in *AutoValidateReq, opts ...grpc.CallOption) (*AutoValidateResponse, error)
 	LoginWithEmail(ctx context.Context, in *LoginWithEmailMsg, opts ...grpc.CallOption) (*LoginResponse, error)
+	// The service does not track nonces: replay protection relies on the gateway, see LoginWithExternalIDPMsg.nonce
 	LoginWithExternalIDP(ctx context.Context, in *LoginWithExternalIDPMsg, opts ...grpc.CallOption) (*LoginResponse, error)
+	StartPasskeyLogin(ctx context.Context, in *StartPasskeyLoginMsg, opts ...grpc.CallOption) (*PasskeyChallenge, error)
+	LoginWithPasskey(ctx context.Context, in *LoginWithPasskeyMsg, opts ...grpc.CallOption) (*LoginResponse, error)
+	RequestPasswordlessLogin(ctx context.Context, in *PasswordlessLoginMsg, opts ...grpc.CallOption) (*ServiceStatus, error)
+	LoginWithPasswordlessToken(ctx context.Context, in *PasswordlessLoginTokenMsg, opts ...grpc.CallOption) (*LoginResponse, error)
 	SignupWithEmail(ctx context.Context, in *SignupWithEmailMsg, opts ...grpc.CallOption) (*TokenResponse, error)
 	ValidateJWT(ctx context.Context, in *JWTRequest, opts ...grpc.CallOption) (*api_types.TokenInfos, error)
+	GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JSONWebKeySet, error)
 	RenewJWT(ctx context.Context, in *RefreshJWTRequest, opts ...grpc.CallOption) (*TokenResponse, error)
 	RevokeAllRefreshTokens(ctx context.Context, in *RevokeRefreshTokensReq, opts ...grpc.CallOption) (*ServiceStatus, error)
+	RevokeAccessToken(ctx context.Context, in *JWTRequest, opts ...grpc.CallOption) (*ServiceStatus, error)
+	IntrospectToken(ctx context.Context, in *IntrospectTokenMsg, opts ...grpc.CallOption) (*TokenIntrospection, error)
+	GetSessions(ctx context.Context, in *SessionMsg, opts ...grpc.CallOption) (*SessionList, error)
+	RevokeSession(ctx context.Context, in *SessionMsg, opts ...grpc.CallOption) (*SessionList, error)
 	VerifyContact(ctx context.Context, in *TempToken, opts ...grpc.CallOption) (*User, error)
 	ResendContactVerification(ctx context.Context, in *ResendContactVerificationReq, opts ...grpc.CallOption) (*ServiceStatus, error)
 	ValidateAppToken(ctx context.Context, in *AppTokenRequest, opts ...grpc.CallOption) (*AppTokenValidation, error)
@@ -46,6 +56,15 @@ type UserManagementApiClient interface {
 	ChangeAccountIDEmail(ctx context.Context, in *EmailChangeMsg, opts ...grpc.CallOption) (*User, error)
 	DeleteAccount(ctx context.Context, in *UserReference, opts ...grpc.CallOption) (*ServiceStatus, error)
 	ChangePreferredLanguage(ctx context.Context, in *LanguageChangeMsg, opts ...grpc.CallOption) (*User, error)
+	StartTOTPEnrollment(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*TOTPEnrollmentResponse, error)
+	ConfirmTOTPEnrollment(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*User, error)
+	DisableTOTP(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*User, error)
+	StartPasskeyRegistration(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyChallenge, error)
+	FinishPasskeyRegistration(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error)
+	GetPasskeys(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error)
+	RemovePasskey(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error)
+	GenerateRecoveryCodes(ctx context.Context, in *RecoveryCodesMsg, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	RegenerateRecoveryCodes(ctx context.Context, in *RecoveryCodesMsg, opts ...grpc.CallOption) (*RecoveryCodes, error)
 	// PW reset:
 	InitiatePasswordReset(ctx context.Context, in *InitiateResetPasswordMsg, opts ...grpc.CallOption) (*ServiceStatus, error)
 	GetInfosForPasswordReset(ctx context.Context, in *GetInfosForResetPasswordMsg, opts ...grpc.CallOption) (*UserInfoForPWReset, error)
@@ -119,6 +138,42 @@ func (c *userManagementApiClient) LoginWithExternalIDP(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *userManagementApiClient) StartPasskeyLogin(ctx context.Context, in *StartPasskeyLoginMsg, opts ...grpc.CallOption) (*PasskeyChallenge, error) {
+	out := new(PasskeyChallenge)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/StartPasskeyLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) LoginWithPasskey(ctx context.Context, in *LoginWithPasskeyMsg, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/LoginWithPasskey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) RequestPasswordlessLogin(ctx context.Context, in *PasswordlessLoginMsg, opts ...grpc.CallOption) (*ServiceStatus, error) {
+	out := new(ServiceStatus)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RequestPasswordlessLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) LoginWithPasswordlessToken(ctx context.Context, in *PasswordlessLoginTokenMsg, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/LoginWithPasswordlessToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userManagementApiClient) SignupWithEmail(ctx context.Context, in *SignupWithEmailMsg, opts ...grpc.CallOption) (*TokenResponse, error) {
 	out := new(TokenResponse)
 	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/SignupWithEmail", in, out, opts...)
@@ -137,6 +192,15 @@ func (c *userManagementApiClient) ValidateJWT(ctx context.Context, in *JWTReques
 	return out, nil
 }
 
+func (c *userManagementApiClient) GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JSONWebKeySet, error) {
+	out := new(JSONWebKeySet)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/GetJWKS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userManagementApiClient) RenewJWT(ctx context.Context, in *RefreshJWTRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
 	out := new(TokenResponse)
 	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RenewJWT", in, out, opts...)
@@ -155,6 +219,42 @@ func (c *userManagementApiClient) RevokeAllRefreshTokens(ctx context.Context, in
 	return out, nil
 }
 
+func (c *userManagementApiClient) RevokeAccessToken(ctx context.Context, in *JWTRequest, opts ...grpc.CallOption) (*ServiceStatus, error) {
+	out := new(ServiceStatus)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RevokeAccessToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) IntrospectToken(ctx context.Context, in *IntrospectTokenMsg, opts ...grpc.CallOption) (*TokenIntrospection, error) {
+	out := new(TokenIntrospection)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/IntrospectToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) GetSessions(ctx context.Context, in *SessionMsg, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/GetSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) RevokeSession(ctx context.Context, in *SessionMsg, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userManagementApiClient) VerifyContact(ctx context.Context, in *TempToken, opts ...grpc.CallOption) (*User, error) {
 	out := new(User)
 	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/VerifyContact", in, out, opts...)
@@ -272,6 +372,87 @@ func (c *userManagementApiClient) ChangePreferredLanguage(ctx context.Context, i
 	return out, nil
 }
 
+func (c *userManagementApiClient) StartTOTPEnrollment(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*TOTPEnrollmentResponse, error) {
+	out := new(TOTPEnrollmentResponse)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/StartTOTPEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) ConfirmTOTPEnrollment(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/ConfirmTOTPEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) DisableTOTP(ctx context.Context, in *TOTPMsg, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/DisableTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) StartPasskeyRegistration(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyChallenge, error) {
+	out := new(PasskeyChallenge)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/StartPasskeyRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) FinishPasskeyRegistration(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error) {
+	out := new(PasskeyList)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/FinishPasskeyRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) GetPasskeys(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error) {
+	out := new(PasskeyList)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/GetPasskeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) RemovePasskey(ctx context.Context, in *PasskeyMsg, opts ...grpc.CallOption) (*PasskeyList, error) {
+	out := new(PasskeyList)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RemovePasskey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) GenerateRecoveryCodes(ctx context.Context, in *RecoveryCodesMsg, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/GenerateRecoveryCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userManagementApiClient) RegenerateRecoveryCodes(ctx context.Context, in *RecoveryCodesMsg, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/RegenerateRecoveryCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userManagementApiClient) InitiatePasswordReset(ctx context.Context, in *InitiateResetPasswordMsg, opts ...grpc.CallOption) (*ServiceStatus, error) {
 	out := new(ServiceStatus)
 	err := c.cc.Invoke(ctx, "/influenzanet.user_management_api.UserManagementApi/InitiatePasswordReset", in, out, opts...)
@@ -430,11 +611,21 @@ type UserManagementApiServer interface {
 	SendVerificationCode(context.Context, *SendVerificationCodeReq) (*ServiceStatus, error)
 	AutoValidateTempToken(context.Context, *AutoValidateReq) (*AutoValidateResponse, error)
 	LoginWithEmail(context.Context, *LoginWithEmailMsg) (*LoginResponse, error)
+	// The service does not track nonces: replay protection relies on the gateway, see LoginWithExternalIDPMsg.nonce
 	LoginWithExternalIDP(context.Context, *LoginWithExternalIDPMsg) (*LoginResponse, error)
+	StartPasskeyLogin(context.Context, *StartPasskeyLoginMsg) (*PasskeyChallenge, error)
+	LoginWithPasskey(context.Context, *LoginWithPasskeyMsg) (*LoginResponse, error)
+	RequestPasswordlessLogin(context.Context, *PasswordlessLoginMsg) (*ServiceStatus, error)
+	LoginWithPasswordlessToken(context.Context, *PasswordlessLoginTokenMsg) (*LoginResponse, error)
 	SignupWithEmail(context.Context, *SignupWithEmailMsg) (*TokenResponse, error)
 	ValidateJWT(context.Context, *JWTRequest) (*api_types.TokenInfos, error)
+	GetJWKS(context.Context, *emptypb.Empty) (*JSONWebKeySet, error)
 	RenewJWT(context.Context, *RefreshJWTRequest) (*TokenResponse, error)
 	RevokeAllRefreshTokens(context.Context, *RevokeRefreshTokensReq) (*ServiceStatus, error)
+	RevokeAccessToken(context.Context, *JWTRequest) (*ServiceStatus, error)
+	IntrospectToken(context.Context, *IntrospectTokenMsg) (*TokenIntrospection, error)
+	GetSessions(context.Context, *SessionMsg) (*SessionList, error)
+	RevokeSession(context.Context, *SessionMsg) (*SessionList, error)
 	VerifyContact(context.Context, *TempToken) (*User, error)
 	ResendContactVerification(context.Context, *ResendContactVerificationReq) (*ServiceStatus, error)
 	ValidateAppToken(context.Context, *AppTokenRequest) (*AppTokenValidation, error)
@@ -451,6 +642,15 @@ type UserManagementApiServer interface {
 	ChangeAccountIDEmail(context.Context, *EmailChangeMsg) (*User, error)
 	DeleteAccount(context.Context, *UserReference) (*ServiceStatus, error)
 	ChangePreferredLanguage(context.Context, *LanguageChangeMsg) (*User, error)
+	StartTOTPEnrollment(context.Context, *TOTPMsg) (*TOTPEnrollmentResponse, error)
+	ConfirmTOTPEnrollment(context.Context, *TOTPMsg) (*User, error)
+	DisableTOTP(context.Context, *TOTPMsg) (*User, error)
+	StartPasskeyRegistration(context.Context, *PasskeyMsg) (*PasskeyChallenge, error)
+	FinishPasskeyRegistration(context.Context, *PasskeyMsg) (*PasskeyList, error)
+	GetPasskeys(context.Context, *PasskeyMsg) (*PasskeyList, error)
+	RemovePasskey(context.Context, *PasskeyMsg) (*PasskeyList, error)
+	GenerateRecoveryCodes(context.Context, *RecoveryCodesMsg) (*RecoveryCodes, error)
+	RegenerateRecoveryCodes(context.Context, *RecoveryCodesMsg) (*RecoveryCodes, error)
 	// PW reset:
 	InitiatePasswordReset(context.Context, *InitiateResetPasswordMsg) (*ServiceStatus, error)
 	GetInfosForPasswordReset(context.Context, *GetInfosForResetPasswordMsg) (*UserInfoForPWReset, error)
@@ -491,18 +691,45 @@ func (UnimplementedUserManagementApiServer) LoginWithEmail(context.Context, *Log
 func (UnimplementedUserManagementApiServer) LoginWithExternalIDP(context.Context, *LoginWithExternalIDPMsg) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginWithExternalIDP not implemented")
 }
+func (UnimplementedUserManagementApiServer) StartPasskeyLogin(context.Context, *StartPasskeyLoginMsg) (*PasskeyChallenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPasskeyLogin not implemented")
+}
+func (UnimplementedUserManagementApiServer) LoginWithPasskey(context.Context, *LoginWithPasskeyMsg) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithPasskey not implemented")
+}
+func (UnimplementedUserManagementApiServer) RequestPasswordlessLogin(context.Context, *PasswordlessLoginMsg) (*ServiceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordlessLogin not implemented")
+}
+func (UnimplementedUserManagementApiServer) LoginWithPasswordlessToken(context.Context, *PasswordlessLoginTokenMsg) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithPasswordlessToken not implemented")
+}
 func (UnimplementedUserManagementApiServer) SignupWithEmail(context.Context, *SignupWithEmailMsg) (*TokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignupWithEmail not implemented")
 }
 func (UnimplementedUserManagementApiServer) ValidateJWT(context.Context, *JWTRequest) (*api_types.TokenInfos, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateJWT not implemented")
 }
+func (UnimplementedUserManagementApiServer) GetJWKS(context.Context, *emptypb.Empty) (*JSONWebKeySet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWKS not implemented")
+}
 func (UnimplementedUserManagementApiServer) RenewJWT(context.Context, *RefreshJWTRequest) (*TokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RenewJWT not implemented")
 }
 func (UnimplementedUserManagementApiServer) RevokeAllRefreshTokens(context.Context, *RevokeRefreshTokensReq) (*ServiceStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllRefreshTokens not implemented")
 }
+func (UnimplementedUserManagementApiServer) RevokeAccessToken(context.Context, *JWTRequest) (*ServiceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccessToken not implemented")
+}
+func (UnimplementedUserManagementApiServer) IntrospectToken(context.Context, *IntrospectTokenMsg) (*TokenIntrospection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IntrospectToken not implemented")
+}
+func (UnimplementedUserManagementApiServer) GetSessions(context.Context, *SessionMsg) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
+}
+func (UnimplementedUserManagementApiServer) RevokeSession(context.Context, *SessionMsg) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
 func (UnimplementedUserManagementApiServer) VerifyContact(context.Context, *TempToken) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyContact not implemented")
 }
@@ -542,6 +769,33 @@ func (UnimplementedUserManagementApiServer) DeleteAccount(context.Context, *User
 func (UnimplementedUserManagementApiServer) ChangePreferredLanguage(context.Context, *LanguageChangeMsg) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePreferredLanguage not implemented")
 }
+func (UnimplementedUserManagementApiServer) StartTOTPEnrollment(context.Context, *TOTPMsg) (*TOTPEnrollmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTOTPEnrollment not implemented")
+}
+func (UnimplementedUserManagementApiServer) ConfirmTOTPEnrollment(context.Context, *TOTPMsg) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTPEnrollment not implemented")
+}
+func (UnimplementedUserManagementApiServer) DisableTOTP(context.Context, *TOTPMsg) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedUserManagementApiServer) StartPasskeyRegistration(context.Context, *PasskeyMsg) (*PasskeyChallenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPasskeyRegistration not implemented")
+}
+func (UnimplementedUserManagementApiServer) FinishPasskeyRegistration(context.Context, *PasskeyMsg) (*PasskeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedUserManagementApiServer) GetPasskeys(context.Context, *PasskeyMsg) (*PasskeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasskeys not implemented")
+}
+func (UnimplementedUserManagementApiServer) RemovePasskey(context.Context, *PasskeyMsg) (*PasskeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePasskey not implemented")
+}
+func (UnimplementedUserManagementApiServer) GenerateRecoveryCodes(context.Context, *RecoveryCodesMsg) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateRecoveryCodes not implemented")
+}
+func (UnimplementedUserManagementApiServer) RegenerateRecoveryCodes(context.Context, *RecoveryCodesMsg) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
 func (UnimplementedUserManagementApiServer) InitiatePasswordReset(context.Context, *InitiateResetPasswordMsg) (*ServiceStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InitiatePasswordReset not implemented")
 }
@@ -687,6 +941,78 @@ func _UserManagementApi_LoginWithExternalIDP_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserManagementApi_StartPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPasskeyLoginMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).StartPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/StartPasskeyLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).StartPasskeyLogin(ctx, req.(*StartPasskeyLoginMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_LoginWithPasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithPasskeyMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).LoginWithPasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/LoginWithPasskey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).LoginWithPasskey(ctx, req.(*LoginWithPasskeyMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_RequestPasswordlessLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordlessLoginMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).RequestPasswordlessLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/RequestPasswordlessLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).RequestPasswordlessLogin(ctx, req.(*PasswordlessLoginMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_LoginWithPasswordlessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordlessLoginTokenMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).LoginWithPasswordlessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/LoginWithPasswordlessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).LoginWithPasswordlessToken(ctx, req.(*PasswordlessLoginTokenMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserManagementApi_SignupWithEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignupWithEmailMsg)
 	if err := dec(in); err != nil {
@@ -723,6 +1049,24 @@ func _UserManagementApi_ValidateJWT_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserManagementApi_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/GetJWKS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).GetJWKS(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserManagementApi_RenewJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RefreshJWTRequest)
 	if err := dec(in); err != nil {
@@ -759,6 +1103,78 @@ func _UserManagementApi_RevokeAllRefreshTokens_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserManagementApi_RevokeAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JWTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).RevokeAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/RevokeAccessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).RevokeAccessToken(ctx, req.(*JWTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_IntrospectToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectTokenMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).IntrospectToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/IntrospectToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).IntrospectToken(ctx, req.(*IntrospectTokenMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_GetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).GetSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/GetSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).GetSessions(ctx, req.(*SessionMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).RevokeSession(ctx, req.(*SessionMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserManagementApi_VerifyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TempToken)
 	if err := dec(in); err != nil {
@@ -993,6 +1409,168 @@ func _UserManagementApi_ChangePreferredLanguage_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserManagementApi_StartTOTPEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).StartTOTPEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/StartTOTPEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).StartTOTPEnrollment(ctx, req.(*TOTPMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_ConfirmTOTPEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).ConfirmTOTPEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/ConfirmTOTPEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).ConfirmTOTPEnrollment(ctx, req.(*TOTPMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/DisableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).DisableTOTP(ctx, req.(*TOTPMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_StartPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).StartPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/StartPasskeyRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).StartPasskeyRegistration(ctx, req.(*PasskeyMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).FinishPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/FinishPasskeyRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).FinishPasskeyRegistration(ctx, req.(*PasskeyMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_GetPasskeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).GetPasskeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/GetPasskeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).GetPasskeys(ctx, req.(*PasskeyMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_RemovePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).RemovePasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/RemovePasskey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).RemovePasskey(ctx, req.(*PasskeyMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_GenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoveryCodesMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).GenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/GenerateRecoveryCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).GenerateRecoveryCodes(ctx, req.(*RecoveryCodesMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserManagementApi_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoveryCodesMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserManagementApiServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.user_management_api.UserManagementApi/RegenerateRecoveryCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserManagementApiServer).RegenerateRecoveryCodes(ctx, req.(*RecoveryCodesMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserManagementApi_InitiatePasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InitiateResetPasswordMsg)
 	if err := dec(in); err != nil {
@@ -1275,6 +1853,22 @@ var UserManagementApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoginWithExternalIDP",
 			Handler:    _UserManagementApi_LoginWithExternalIDP_Handler,
 		},
+		{
+			MethodName: "StartPasskeyLogin",
+			Handler:    _UserManagementApi_StartPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "LoginWithPasskey",
+			Handler:    _UserManagementApi_LoginWithPasskey_Handler,
+		},
+		{
+			MethodName: "RequestPasswordlessLogin",
+			Handler:    _UserManagementApi_RequestPasswordlessLogin_Handler,
+		},
+		{
+			MethodName: "LoginWithPasswordlessToken",
+			Handler:    _UserManagementApi_LoginWithPasswordlessToken_Handler,
+		},
 		{
 			MethodName: "SignupWithEmail",
 			Handler:    _UserManagementApi_SignupWithEmail_Handler,
@@ -1283,6 +1877,10 @@ var UserManagementApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateJWT",
 			Handler:    _UserManagementApi_ValidateJWT_Handler,
 		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _UserManagementApi_GetJWKS_Handler,
+		},
 		{
 			MethodName: "RenewJWT",
 			Handler:    _UserManagementApi_RenewJWT_Handler,
@@ -1291,6 +1889,22 @@ var UserManagementApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeAllRefreshTokens",
 			Handler:    _UserManagementApi_RevokeAllRefreshTokens_Handler,
 		},
+		{
+			MethodName: "RevokeAccessToken",
+			Handler:    _UserManagementApi_RevokeAccessToken_Handler,
+		},
+		{
+			MethodName: "IntrospectToken",
+			Handler:    _UserManagementApi_IntrospectToken_Handler,
+		},
+		{
+			MethodName: "GetSessions",
+			Handler:    _UserManagementApi_GetSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _UserManagementApi_RevokeSession_Handler,
+		},
 		{
 			MethodName: "VerifyContact",
 			Handler:    _UserManagementApi_VerifyContact_Handler,
@@ -1343,6 +1957,42 @@ var UserManagementApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePreferredLanguage",
 			Handler:    _UserManagementApi_ChangePreferredLanguage_Handler,
 		},
+		{
+			MethodName: "StartTOTPEnrollment",
+			Handler:    _UserManagementApi_StartTOTPEnrollment_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTPEnrollment",
+			Handler:    _UserManagementApi_ConfirmTOTPEnrollment_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _UserManagementApi_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "StartPasskeyRegistration",
+			Handler:    _UserManagementApi_StartPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _UserManagementApi_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "GetPasskeys",
+			Handler:    _UserManagementApi_GetPasskeys_Handler,
+		},
+		{
+			MethodName: "RemovePasskey",
+			Handler:    _UserManagementApi_RemovePasskey_Handler,
+		},
+		{
+			MethodName: "GenerateRecoveryCodes",
+			Handler:    _UserManagementApi_GenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _UserManagementApi_RegenerateRecoveryCodes_Handler,
+		},
 		{
 			MethodName: "InitiatePasswordReset",
 			Handler:    _UserManagementApi_InitiatePasswordReset_Handler,
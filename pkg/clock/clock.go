@@ -0,0 +1,54 @@
+// Package clock provides the time source used by the service. Components get a Clock injected instead of calling
+// time.Now() directly, so tests can control the time with a Fake clock.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock returns the current time
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Real is the clock based on the system time
+var Real Clock = realClock{}
+
+// Fake is a manually controlled clock for tests. It is safe for concurrent use.
+type Fake struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewFake creates a fake clock starting at the given time
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.now
+}
+
+// Set moves the clock to the given time
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the clock forward by d and returns the new time
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	return f.now
+}
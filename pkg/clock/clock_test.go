@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	c := NewFake(start)
+
+	t.Run("returns set time", func(t *testing.T) {
+		if !c.Now().Equal(start) {
+			t.Errorf("unexpected time: %v", c.Now())
+		}
+	})
+
+	t.Run("advance", func(t *testing.T) {
+		n := c.Advance(90 * time.Second)
+		if n.Unix() != start.Unix()+90 || c.Now().Unix() != start.Unix()+90 {
+			t.Errorf("unexpected time: %v", c.Now())
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		c.Set(start)
+		if !c.Now().Equal(start) {
+			t.Errorf("unexpected time: %v", c.Now())
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -14,9 +15,11 @@ type GlobalDBService struct {
 	DBClient     *mongo.Client
 	timeout      int
 	DBNamePrefix string
+	crypt        *fieldcrypt.Keyring
 }
 
-func NewGlobalDBService(configs models.DBConfig) *GlobalDBService {
+// NewGlobalDBService connects to the global DB. If crypt is not nil, the infos of temp tokens are encrypted.
+func NewGlobalDBService(configs models.DBConfig, crypt *fieldcrypt.Keyring) *GlobalDBService {
 	var err error
 	dbClient, err := mongo.NewClient(
 		options.Client().ApplyURI(configs.URI),
@@ -46,6 +49,7 @@ func NewGlobalDBService(configs models.DBConfig) *GlobalDBService {
 		DBClient:     dbClient,
 		timeout:      configs.Timeout,
 		DBNamePrefix: configs.DBNamePrefix,
+		crypt:        crypt,
 	}
 }
 
@@ -58,11 +62,16 @@ func (dbService *GlobalDBService) collectionAppToken() *mongo.Collection {
 	return dbService.DBClient.Database(dbService.DBNamePrefix + "global-infos").Collection("app-tokens")
 }
 
+func (dbService *GlobalDBService) collectionRevokedTokens() *mongo.Collection {
+	return dbService.DBClient.Database(dbService.DBNamePrefix + "global-infos").Collection("revoked-tokens")
+}
+
 func (dbService *GlobalDBService) collectionRefInstances() *mongo.Collection {
 	return dbService.DBClient.Database(dbService.DBNamePrefix + "global-infos").Collection("instances")
 }
 
 // DB utils
-func (dbService *GlobalDBService) getContext() (ctx context.Context, cancel context.CancelFunc) {
-	return context.WithTimeout(context.Background(), time.Duration(dbService.timeout)*time.Second)
+// getContext derives the context for a single DB operation from the request context, adding the configured timeout
+func (dbService *GlobalDBService) getContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc) {
+	return context.WithTimeout(parent, time.Duration(dbService.timeout)*time.Second)
 }
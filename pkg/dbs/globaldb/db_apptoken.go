@@ -1,12 +1,14 @@
 package globaldb
 
 import (
+	"context"
+
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func (dbService *GlobalDBService) FindAppToken(token string) (appTokenInfos models.AppToken, err error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) FindAppToken(ctx context.Context, token string) (appTokenInfos models.AppToken, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"tokens": token}
@@ -14,8 +16,8 @@ func (dbService *GlobalDBService) FindAppToken(token string) (appTokenInfos mode
 	return
 }
 
-func (dbService *GlobalDBService) AddAppToken(appToken models.AppToken) (err error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) AddAppToken(ctx context.Context, appToken models.AppToken) (err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_, err = dbService.collectionAppToken().InsertOne(ctx, appToken)
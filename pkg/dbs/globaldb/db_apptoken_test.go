@@ -1,6 +1,7 @@
 package globaldb
 
 import (
+	"context"
 	"testing"
 
 	"github.com/influenzanet/user-management-service/pkg/models"
@@ -12,7 +13,7 @@ func TestDbInterfaceMethodsForAppToken(t *testing.T) {
 		Instances: []string{testInstanceID},
 		Tokens:    []string{"test1", "test2"},
 	}
-	ctx, cancel := testDBService.getContext()
+	ctx, cancel := testDBService.getContext(context.Background())
 	defer cancel()
 
 	_, err := testDBService.collectionAppToken().InsertOne(ctx, appToken)
@@ -21,7 +22,7 @@ func TestDbInterfaceMethodsForAppToken(t *testing.T) {
 	}
 
 	t.Run("Find existing app token", func(t *testing.T) {
-		res, err := testDBService.FindAppToken("test1")
+		res, err := testDBService.FindAppToken(context.Background(), "test1")
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
@@ -32,7 +33,7 @@ func TestDbInterfaceMethodsForAppToken(t *testing.T) {
 	})
 
 	t.Run("Try to find not existing app token", func(t *testing.T) {
-		_, err := testDBService.FindAppToken("test3")
+		_, err := testDBService.FindAppToken(context.Background(), "test3")
 		if err == nil {
 			t.Error("should not be found")
 			return
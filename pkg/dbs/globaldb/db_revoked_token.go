@@ -0,0 +1,52 @@
+package globaldb
+
+import (
+	"context"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// revokedTokenDoc adds the expiration as a date to the entry, so that the TTL index removes it once the revoked
+// tokens expired
+type revokedTokenDoc struct {
+	models.RevokedToken `bson:",inline"`
+	ExpireAt            time.Time `bson:"expireAt"`
+}
+
+func (dbService *GlobalDBService) AddRevokedToken(ctx context.Context, t models.RevokedToken) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	doc := revokedTokenDoc{RevokedToken: t, ExpireAt: time.Unix(t.Expiration, 0)}
+	_, err := dbService.collectionRevokedTokens().InsertOne(ctx, doc)
+	return err
+}
+
+func (dbService *GlobalDBService) IsTokenRevoked(ctx context.Context, instanceID string, userID string, tokenID string, issuedAt int64) (bool, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	conditions := bson.A{
+		bson.M{"userID": userID, "tokenID": bson.M{"$exists": false}, "issuedUntil": bson.M{"$gt": issuedAt}},
+	}
+	if len(tokenID) > 0 {
+		conditions = append(conditions, bson.M{"tokenID": tokenID})
+	}
+	filter := bson.M{"instanceID": instanceID, "$or": conditions}
+	count, err := dbService.collectionRevokedTokens().CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (dbService *GlobalDBService) DeleteRevokedTokensExpireBefore(ctx context.Context, expiresBefore int64) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"expiration": bson.M{"$lt": expiresBefore}}
+	_, err := dbService.collectionRevokedTokens().DeleteMany(ctx, filter)
+	return err
+}
@@ -0,0 +1,59 @@
+package globaldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func TestDbInterfaceMethodsForRevokedTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	entries := []models.RevokedToken{
+		{InstanceID: testInstanceID, UserID: "u1", TokenID: "jti1", Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u2", IssuedUntil: now, Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u3", IssuedUntil: now, Expiration: now - 10},
+	}
+	for _, e := range entries {
+		if err := testDBService.AddRevokedToken(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checks := []struct {
+		instanceID, userID, tokenID string
+		issuedAt                    int64
+		revoked                     bool
+	}{
+		{testInstanceID, "u1", "jti1", now, true},
+		{testInstanceID, "u1", "jti2", now, false},
+		{"other-instance", "u1", "jti1", now, false},
+		{testInstanceID, "u2", "jti3", now - 5, true},
+		{testInstanceID, "u2", "jti3", now - 1, true},
+		{testInstanceID, "u2", "jti3", now, false},
+		{testInstanceID, "u2", "", now - 1, true},
+		{testInstanceID, "u1", "", now, false},
+	}
+	for _, c := range checks {
+		revoked, err := testDBService.IsTokenRevoked(ctx, c.instanceID, c.userID, c.tokenID, c.issuedAt)
+		if err != nil || revoked != c.revoked {
+			t.Errorf("unexpected result for %v: %v, %v", c, revoked, err)
+		}
+	}
+
+	t.Run("delete expired entries", func(t *testing.T) {
+		if err := testDBService.DeleteRevokedTokensExpireBefore(ctx, now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u3", "", now-1); revoked {
+			t.Error("expired entry should be deleted")
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u2", "", now-1); !revoked {
+			t.Error("entry should be kept")
+		}
+	})
+}
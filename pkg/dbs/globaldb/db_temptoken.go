@@ -1,15 +1,24 @@
 package globaldb
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func (dbService *GlobalDBService) AddTempToken(t models.TempToken) (token string, err error) {
-	ctx, cancel := dbService.getContext()
+// tempTokenDoc adds the expiration as a date to the stored token, as required by the TTL index. Tokens without
+// expiration are not removed by the DB.
+type tempTokenDoc struct {
+	models.TempToken `bson:",inline"`
+	ExpireAt         time.Time `bson:"expireAt,omitempty"`
+}
+
+func (dbService *GlobalDBService) AddTempToken(ctx context.Context, t models.TempToken) (token string, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	t.Token, err = tokens.GenerateUniqueTokenString()
@@ -17,7 +26,14 @@ func (dbService *GlobalDBService) AddTempToken(t models.TempToken) (token string
 		return token, err
 	}
 
-	_, err = dbService.collectionRefTempToken().InsertOne(ctx, t)
+	doc := tempTokenDoc{TempToken: t}
+	if doc.Info, err = dbService.encryptInfo(t.Info); err != nil {
+		return token, err
+	}
+	if t.Expiration > 0 {
+		doc.ExpireAt = time.Unix(t.Expiration, 0)
+	}
+	_, err = dbService.collectionRefTempToken().InsertOne(ctx, doc)
 	if err != nil {
 		return token, err
 	}
@@ -25,8 +41,8 @@ func (dbService *GlobalDBService) AddTempToken(t models.TempToken) (token string
 	return
 }
 
-func (dbService *GlobalDBService) GetTempTokenForUser(instanceID string, uid string, purpose string) (tokens models.TempTokens, err error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) GetTempTokenForUser(ctx context.Context, instanceID string, uid string, purpose string) (tokens models.TempTokens, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"instanceID": instanceID, "userID": uid}
@@ -42,8 +58,7 @@ func (dbService *GlobalDBService) GetTempTokenForUser(instanceID string, uid str
 
 	tokens = []models.TempToken{}
 	for cur.Next(ctx) {
-		var result models.TempToken
-		err := cur.Decode(&result)
+		result, err := dbService.decodeTempToken(cur)
 		if err != nil {
 			return tokens, err
 		}
@@ -56,19 +71,17 @@ func (dbService *GlobalDBService) GetTempTokenForUser(instanceID string, uid str
 	return tokens, nil
 }
 
-func (dbService *GlobalDBService) GetTempToken(token string) (models.TempToken, error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) GetTempToken(ctx context.Context, token string) (models.TempToken, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"token": token}
 
-	t := models.TempToken{}
-	err := dbService.collectionRefTempToken().FindOne(ctx, filter).Decode(&t)
-	return t, err
+	return dbService.decodeTempToken(dbService.collectionRefTempToken().FindOne(ctx, filter))
 }
 
-func (dbService *GlobalDBService) DeleteTempToken(token string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) DeleteTempToken(ctx context.Context, token string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"token": token}
@@ -82,8 +95,8 @@ func (dbService *GlobalDBService) DeleteTempToken(token string) error {
 	return nil
 }
 
-func (dbService *GlobalDBService) DeleteAllTempTokenForUser(instanceID string, userID string, purpose string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) DeleteAllTempTokenForUser(ctx context.Context, instanceID string, userID string, purpose string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"instanceID": instanceID, "userID": userID}
@@ -97,8 +110,8 @@ func (dbService *GlobalDBService) DeleteAllTempTokenForUser(instanceID string, u
 	return nil
 }
 
-func (dbService *GlobalDBService) DeleteTempTokensExpireBefore(instanceID string, purpose string, expiresBefore int64) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) DeleteTempTokensExpireBefore(ctx context.Context, instanceID string, purpose string, expiresBefore int64) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{"expiration": bson.M{"$lt": expiresBefore}}
@@ -17,12 +17,12 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 		UserID:     "test_user_id",
 		Purpose:    "test_purpose1",
 		InstanceID: testInstanceID,
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
 	tokenStr := ""
 
 	t.Run("Add temporary token to DB", func(t *testing.T) {
-		ts, err := testDBService.AddTempToken(testTempToken)
+		ts, err := testDBService.AddTempToken(context.Background(), testTempToken)
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -31,7 +31,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 
 		testTempToken2 := testTempToken
 		testTempToken2.Purpose = "test_purpose2"
-		_, err = testDBService.AddTempToken(testTempToken2)
+		_, err = testDBService.AddTempToken(context.Background(), testTempToken2)
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -39,7 +39,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("try to get temporary token by wrong token string", func(t *testing.T) {
-		tempToken, err := testDBService.GetTempToken(tokenStr + "++")
+		tempToken, err := testDBService.GetTempToken(context.Background(), tokenStr+"++")
 		if err == nil || tempToken.UserID != "" {
 			t.Error(tempToken)
 			t.Error("token should not be found")
@@ -48,7 +48,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("get temporary token by token string", func(t *testing.T) {
-		tempToken, err := testDBService.GetTempToken(tokenStr)
+		tempToken, err := testDBService.GetTempToken(context.Background(), tokenStr)
 		if err != nil {
 			t.Error("token not found by token string")
 			return
@@ -61,7 +61,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("try to get temporary token by wrong user id", func(t *testing.T) {
-		tt, err := testDBService.GetTempTokenForUser(testInstanceID, testTempToken.UserID+"1", "")
+		tt, err := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID+"1", "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -74,7 +74,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("try to get temporary token by wrong instace id", func(t *testing.T) {
-		tt, err := testDBService.GetTempTokenForUser(testInstanceID+"1", testTempToken.UserID, "")
+		tt, err := testDBService.GetTempTokenForUser(context.Background(), testInstanceID+"1", testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -87,7 +87,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("try to get temporary token by wrong purpose", func(t *testing.T) {
-		tt, err := testDBService.GetTempTokenForUser(testInstanceID, testTempToken.UserID, testTempToken.Purpose+"1")
+		tt, err := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, testTempToken.Purpose+"1")
 		if err != nil {
 			t.Error(err)
 			return
@@ -100,7 +100,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("get temporary token by user_id+instance_id", func(t *testing.T) {
-		tt, err := testDBService.GetTempTokenForUser(testInstanceID, testTempToken.UserID, "")
+		tt, err := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -113,7 +113,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("get temporary token by user_id+instance_id+purpose", func(t *testing.T) {
-		tt, err := testDBService.GetTempTokenForUser(testInstanceID, testTempToken.UserID, testTempToken.Purpose)
+		tt, err := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, testTempToken.Purpose)
 		if err != nil {
 			t.Error(err)
 			return
@@ -125,7 +125,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("Try delete not existing temporary token", func(t *testing.T) {
-		err := testDBService.DeleteTempToken(tokenStr + "1")
+		err := testDBService.DeleteTempToken(context.Background(), tokenStr+"1")
 		if err == nil {
 			t.Error("doc should not be found")
 			return
@@ -133,17 +133,17 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("Delete temporary token", func(t *testing.T) {
-		err := testDBService.DeleteTempToken(tokenStr)
+		err := testDBService.DeleteTempToken(context.Background(), tokenStr)
 		if err != nil {
 			t.Error(err)
 			return
 		}
-		_, err = testDBService.GetTempToken(testTempToken.Token)
+		_, err = testDBService.GetTempToken(context.Background(), testTempToken.Token)
 		if err == nil {
 			t.Error("token should be deleted by now")
 			return
 		}
-		_, err = testDBService.AddTempToken(testTempToken)
+		_, err = testDBService.AddTempToken(context.Background(), testTempToken)
 		if err != nil {
 			t.Error(err)
 			return
@@ -151,7 +151,7 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("Delete all temporary token of a user_id with empty instance_id", func(t *testing.T) {
-		err := testDBService.DeleteAllTempTokenForUser("", testTempToken.UserID, "")
+		err := testDBService.DeleteAllTempTokenForUser(context.Background(), "", testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -159,19 +159,19 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("Try to delete all temporary token of a user_id with wrong id, correct instance_id", func(t *testing.T) {
-		err := testDBService.DeleteAllTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID+"3", "")
+		err := testDBService.DeleteAllTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID+"3", "")
 		if err != nil {
 			t.Error(err)
 		}
 	})
 
 	t.Run("Delete all temporary token of a user_id+instance_id+purpose", func(t *testing.T) {
-		err := testDBService.DeleteAllTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, testTempToken.Purpose)
+		err := testDBService.DeleteAllTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, testTempToken.Purpose)
 		if err != nil {
 			t.Error(err)
 			return
 		}
-		tokens, err := testDBService.GetTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		tokens, err := testDBService.GetTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -184,12 +184,12 @@ func TestDbInterfaceMethodsForTempToken(t *testing.T) {
 	})
 
 	t.Run("Delete all temporary token of a user_id+instance_id", func(t *testing.T) {
-		err := testDBService.DeleteAllTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		err := testDBService.DeleteAllTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
 		}
-		tokens, err := testDBService.GetTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		tokens, err := testDBService.GetTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -215,7 +215,7 @@ func TestDBDeleteExpiredTempTokens(t *testing.T) {
 	}
 
 	for _, token := range testTempTokens {
-		_, err := testDBService.AddTempToken(token)
+		_, err := testDBService.AddTempToken(context.Background(), token)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err.Error())
 			return
@@ -223,7 +223,7 @@ func TestDBDeleteExpiredTempTokens(t *testing.T) {
 	}
 
 	t.Run("Delete expired for single purpose", func(t *testing.T) {
-		err := testDBService.DeleteTempTokensExpireBefore("", "purpose1", time.Now().Unix()-15)
+		err := testDBService.DeleteTempTokensExpireBefore(context.Background(), "", "purpose1", time.Now().Unix()-15)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err.Error())
 			return
@@ -240,7 +240,7 @@ func TestDBDeleteExpiredTempTokens(t *testing.T) {
 	})
 
 	t.Run("Delete expired for single instance", func(t *testing.T) {
-		err := testDBService.DeleteTempTokensExpireBefore("testInstance1", "", time.Now().Unix()-5)
+		err := testDBService.DeleteTempTokensExpireBefore(context.Background(), "testInstance1", "", time.Now().Unix()-5)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err.Error())
 			return
@@ -257,7 +257,7 @@ func TestDBDeleteExpiredTempTokens(t *testing.T) {
 	})
 
 	t.Run("Delete expired for purpose and instance", func(t *testing.T) {
-		err := testDBService.DeleteTempTokensExpireBefore("testInstance3", "purpose3", time.Now().Unix()-5)
+		err := testDBService.DeleteTempTokensExpireBefore(context.Background(), "testInstance3", "purpose3", time.Now().Unix()-5)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err.Error())
 			return
@@ -274,7 +274,7 @@ func TestDBDeleteExpiredTempTokens(t *testing.T) {
 	})
 
 	t.Run("Delete expired everywhere", func(t *testing.T) {
-		err := testDBService.DeleteTempTokensExpireBefore("", "", time.Now().Unix()-5)
+		err := testDBService.DeleteTempTokensExpireBefore(context.Background(), "", "", time.Now().Unix()-5)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err.Error())
 			return
@@ -62,6 +62,7 @@ func setupTestDBService() {
 			MaxPoolSize:     MaxPoolSize,
 			DBNamePrefix:    testDBNamePrefix,
 		},
+		nil,
 	)
 }
 
@@ -0,0 +1,93 @@
+package globaldb
+
+import (
+	"context"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type decoder interface {
+	Decode(v interface{}) error
+}
+
+// encryptInfo returns a copy of the temp token infos with encrypted values. The infos can contain the email address
+// of the user and are never queried.
+func (dbService *GlobalDBService) encryptInfo(info map[string]string) (map[string]string, error) {
+	if dbService.crypt == nil || info == nil {
+		return info, nil
+	}
+	encrypted := make(map[string]string, len(info))
+	for k, v := range info {
+		enc, err := dbService.crypt.Encrypt(v)
+		if err != nil {
+			return nil, err
+		}
+		encrypted[k] = enc
+	}
+	return encrypted, nil
+}
+
+func (dbService *GlobalDBService) decodeTempToken(res decoder) (models.TempToken, error) {
+	t := models.TempToken{}
+	if err := res.Decode(&t); err != nil {
+		return t, err
+	}
+	for k, v := range t.Info {
+		dec, err := dbService.crypt.Decrypt(v)
+		if err != nil {
+			return t, err
+		}
+		t.Info[k] = dec
+	}
+	return t, nil
+}
+
+// ReencryptTempTokens encrypts the infos of all temp tokens stored in plaintext or with an old key. With dryRun, the
+// tokens are only counted.
+func (dbService *GlobalDBService) ReencryptTempTokens(ctx context.Context, dryRun bool) (count int, err error) {
+	if dbService.crypt == nil {
+		return 0, nil
+	}
+
+	cur, err := dbService.collectionRefTempToken().Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		stored := models.TempToken{}
+		if err := cur.Decode(&stored); err != nil {
+			return count, err
+		}
+		needed := false
+		for _, v := range stored.Info {
+			needed = needed || dbService.crypt.NeedsReencryption(v)
+		}
+		if !needed {
+			continue
+		}
+
+		count++
+		if dryRun {
+			continue
+		}
+		t, err := dbService.decodeTempToken(cur)
+		if err != nil {
+			return count, err
+		}
+		info, err := dbService.encryptInfo(t.Info)
+		if err != nil {
+			return count, err
+		}
+		// infos are not modified after the token was created, tokens deleted in the meantime are simply not matched
+		if _, err := dbService.collectionRefTempToken().UpdateOne(ctx,
+			bson.M{"_id": stored.ID},
+			bson.M{"$set": bson.M{"info": info}},
+		); err != nil {
+			return count, err
+		}
+	}
+	return count, cur.Err()
+}
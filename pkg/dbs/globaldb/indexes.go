@@ -0,0 +1,67 @@
+package globaldb
+
+import (
+	"context"
+
+	"github.com/influenzanet/user-management-service/pkg/dbs/indexes"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var tempTokenIndexes = []indexes.Index{
+	{
+		Keys:   bson.D{{Key: "token", Value: 1}},
+		Unique: true,
+	},
+	{
+		Keys: bson.D{{Key: "userID", Value: 1}, {Key: "purpose", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "expiration", Value: 1}},
+	},
+	// expired tokens are removed by the DB, see tempTokenDoc
+	{
+		Keys:               bson.D{{Key: "expireAt", Value: 1}},
+		ExpireAfterSeconds: indexes.TTL(0),
+	},
+}
+
+var appTokenIndexes = []indexes.Index{
+	{
+		Keys: bson.D{{Key: "tokens", Value: 1}},
+	},
+}
+
+var revokedTokenIndexes = []indexes.Index{
+	{
+		Keys: bson.D{{Key: "instanceID", Value: 1}, {Key: "tokenID", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "instanceID", Value: 1}, {Key: "userID", Value: 1}},
+	},
+	// entries are removed by the DB once the revoked tokens expired, see revokedTokenDoc
+	{
+		Keys:               bson.D{{Key: "expireAt", Value: 1}},
+		ExpireAfterSeconds: indexes.TTL(0),
+	},
+}
+
+// EnsureIndexes creates the missing indexes of the global-infos collections. With checkOnly, drift is only reported.
+func (dbService *GlobalDBService) EnsureIndexes(ctx context.Context, checkOnly bool) ([]indexes.Report, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	reports := []indexes.Report{}
+	r, err := indexes.Ensure(ctx, dbService.collectionRefTempToken(), tempTokenIndexes, checkOnly)
+	reports = append(reports, r)
+	if err != nil {
+		return reports, err
+	}
+	r, err = indexes.Ensure(ctx, dbService.collectionAppToken(), appTokenIndexes, checkOnly)
+	reports = append(reports, r)
+	if err != nil {
+		return reports, err
+	}
+	r, err = indexes.Ensure(ctx, dbService.collectionRevokedTokens(), revokedTokenIndexes, checkOnly)
+	reports = append(reports, r)
+	return reports, err
+}
@@ -0,0 +1,23 @@
+package globaldb
+
+import (
+	"context"
+
+	"testing"
+)
+
+func TestEnsureIndexes(t *testing.T) {
+	if _, err := testDBService.EnsureIndexes(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := testDBService.EnsureIndexes(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range reports {
+		if r.HasDrift() {
+			t.Errorf("unexpected drift after creating indexes: %v", r)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package globaldb
 
 import (
+	"context"
+
 	"github.com/influenzanet/go-utils/pkg/global_types"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func (dbService *GlobalDBService) GetAllInstances() ([]global_types.Instance, error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *GlobalDBService) GetAllInstances(ctx context.Context) ([]global_types.Instance, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{}
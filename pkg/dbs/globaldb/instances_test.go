@@ -1,12 +1,13 @@
 package globaldb
 
 import (
+	"context"
 	"testing"
 )
 
 func TestDbInterfaceMethods(t *testing.T) {
 	t.Run("Check fetching instances", func(t *testing.T) {
-		instances, err := testDBService.GetAllInstances()
+		instances, err := testDBService.GetAllInstances(context.Background())
 		if err != nil {
 			t.Errorf(err.Error())
 			return
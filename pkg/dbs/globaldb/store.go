@@ -0,0 +1,35 @@
+package globaldb
+
+import (
+	"context"
+
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// GlobalStore describes the storage layer for data shared across instances (temp tokens, app tokens, revoked access
+// tokens and instances).
+// GlobalDBService implements it on top of MongoDB, the in-memory store in pkg/dbs/memdb mirrors its filtering semantics.
+type GlobalStore interface {
+	// Temp tokens
+	AddTempToken(ctx context.Context, t models.TempToken) (token string, err error)
+	GetTempTokenForUser(ctx context.Context, instanceID string, uid string, purpose string) (tokens models.TempTokens, err error)
+	GetTempToken(ctx context.Context, token string) (models.TempToken, error)
+	DeleteTempToken(ctx context.Context, token string) error
+	DeleteAllTempTokenForUser(ctx context.Context, instanceID string, userID string, purpose string) error
+	DeleteTempTokensExpireBefore(ctx context.Context, instanceID string, purpose string, expiresBefore int64) error
+
+	// App tokens
+	FindAppToken(ctx context.Context, token string) (appTokenInfos models.AppToken, err error)
+	AddAppToken(ctx context.Context, appToken models.AppToken) (err error)
+
+	// Revoked access tokens
+	AddRevokedToken(ctx context.Context, t models.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, instanceID string, userID string, tokenID string, issuedAt int64) (bool, error)
+	DeleteRevokedTokensExpireBefore(ctx context.Context, expiresBefore int64) error
+
+	// Instances
+	GetAllInstances(ctx context.Context) ([]global_types.Instance, error)
+}
+
+var _ GlobalStore = &GlobalDBService{}
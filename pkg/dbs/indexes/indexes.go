@@ -0,0 +1,152 @@
+// Package indexes compares the indexes a collection is expected to have with the ones present in the DB and creates
+// the missing ones. Existing indexes are never dropped or modified, differences are only reported.
+package indexes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coneno/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index declares an index needed by the service. Indexes are identified by their keys, so that indexes created
+// by hand with a different name are recognised.
+type Index struct {
+	Keys               bson.D
+	Unique             bool
+	Sparse             bool
+	ExpireAfterSeconds *int32
+}
+
+// TTL returns a pointer usable as Index.ExpireAfterSeconds
+func TTL(seconds int32) *int32 {
+	return &seconds
+}
+
+func (i Index) keyString() string {
+	return keyString(i.Keys)
+}
+
+func (i Index) model() mongo.IndexModel {
+	opts := options.Index()
+	if i.Unique {
+		opts.SetUnique(true)
+	}
+	if i.Sparse {
+		opts.SetSparse(true)
+	}
+	if i.ExpireAfterSeconds != nil {
+		opts.SetExpireAfterSeconds(*i.ExpireAfterSeconds)
+	}
+	return mongo.IndexModel{Keys: i.Keys, Options: opts}
+}
+
+// existingIndex is the part of the index description returned by listIndexes we compare against
+type existingIndex struct {
+	Name               string `bson:"name"`
+	Keys               bson.D `bson:"key"`
+	Unique             bool   `bson:"unique"`
+	Sparse             bool   `bson:"sparse"`
+	ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+}
+
+func keyString(keys bson.D) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s:%v", k.Key, k.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Report describes the difference between declared and existing indexes of a collection
+type Report struct {
+	Collection string
+	// Missing indexes (created unless in check mode)
+	Missing []Index
+	// Mismatched lists existing indexes with the declared keys but other options (e.g. not unique)
+	Mismatched []string
+	// Unknown lists existing indexes that are not declared by the service
+	Unknown []string
+}
+
+// HasDrift is true if the collection does not have exactly the declared indexes
+func (r Report) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Mismatched) > 0 || len(r.Unknown) > 0
+}
+
+func compare(declared []Index, existing []existingIndex) Report {
+	r := Report{}
+	found := map[string]bool{}
+	for _, d := range declared {
+		match := false
+		for _, e := range existing {
+			if keyString(e.Keys) != d.keyString() {
+				continue
+			}
+			match = true
+			found[e.Name] = true
+			if e.Unique != d.Unique || e.Sparse != d.Sparse || !sameTTL(e.ExpireAfterSeconds, d.ExpireAfterSeconds) {
+				r.Mismatched = append(r.Mismatched, e.Name)
+			}
+			break
+		}
+		if !match {
+			r.Missing = append(r.Missing, d)
+		}
+	}
+	for _, e := range existing {
+		if e.Name == "_id_" || found[e.Name] {
+			continue
+		}
+		r.Unknown = append(r.Unknown, e.Name)
+	}
+	return r
+}
+
+func sameTTL(a *int32, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Ensure checks the indexes of the collection and creates the missing ones. With checkOnly, nothing is created.
+// Drift is logged in both cases.
+func Ensure(ctx context.Context, coll *mongo.Collection, declared []Index, checkOnly bool) (Report, error) {
+	collName := coll.Database().Name() + "." + coll.Name()
+
+	cur, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return Report{Collection: collName}, err
+	}
+	existing := []existingIndex{}
+	if err := cur.All(ctx, &existing); err != nil {
+		return Report{Collection: collName}, err
+	}
+
+	r := compare(declared, existing)
+	r.Collection = collName
+
+	for _, name := range r.Mismatched {
+		logger.Warning.Printf("%s: index %s has unexpected options, drop it to let it be recreated", collName, name)
+	}
+	for _, name := range r.Unknown {
+		logger.Info.Printf("%s: index %s is not used by the service", collName, name)
+	}
+	for _, index := range r.Missing {
+		if checkOnly {
+			logger.Warning.Printf("%s: missing index on %s", collName, index.keyString())
+			continue
+		}
+		name, err := coll.Indexes().CreateOne(ctx, index.model())
+		if err != nil {
+			return r, fmt.Errorf("%s: creating index on %s: %v", collName, index.keyString(), err)
+		}
+		logger.Info.Printf("%s: created index %s", collName, name)
+	}
+	return r, nil
+}
@@ -0,0 +1,48 @@
+package indexes
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCompare(t *testing.T) {
+	declared := []Index{
+		{Keys: bson.D{{Key: "account.accountID", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "account.accountConfirmedAt", Value: 1}, {Key: "timestamps.createdAt", Value: 1}}},
+		{Keys: bson.D{{Key: "expireAt", Value: 1}}, ExpireAfterSeconds: TTL(0)},
+		{Keys: bson.D{{Key: "accountIDIndex", Value: 1}}, Unique: true, Sparse: true},
+	}
+
+	t.Run("empty collection", func(t *testing.T) {
+		r := compare(declared, []existingIndex{{Name: "_id_", Keys: bson.D{{Key: "_id", Value: int32(1)}}}})
+		if len(r.Missing) != 4 || len(r.Mismatched) != 0 || len(r.Unknown) != 0 {
+			t.Errorf("unexpected report: %v", r)
+		}
+	})
+
+	t.Run("all indexes present", func(t *testing.T) {
+		r := compare(declared, []existingIndex{
+			{Name: "_id_", Keys: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Name: "custom", Keys: bson.D{{Key: "account.accountID", Value: int32(1)}}, Unique: true},
+			{Name: "a_1_b_1", Keys: bson.D{{Key: "account.accountConfirmedAt", Value: int32(1)}, {Key: "timestamps.createdAt", Value: int32(1)}}},
+			{Name: "expireAt_1", Keys: bson.D{{Key: "expireAt", Value: int32(1)}}, ExpireAfterSeconds: TTL(0)},
+			{Name: "accountIDIndex_1", Keys: bson.D{{Key: "accountIDIndex", Value: int32(1)}}, Unique: true, Sparse: true},
+		})
+		if r.HasDrift() {
+			t.Errorf("unexpected report: %v", r)
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		r := compare(declared, []existingIndex{
+			{Name: "account.accountID_1", Keys: bson.D{{Key: "account.accountID", Value: int32(1)}}},
+			{Name: "reversed", Keys: bson.D{{Key: "timestamps.createdAt", Value: int32(1)}, {Key: "account.accountConfirmedAt", Value: int32(1)}}},
+			{Name: "expireAt_1", Keys: bson.D{{Key: "expireAt", Value: int32(1)}}, ExpireAfterSeconds: TTL(60)},
+			{Name: "accountIDIndex_1", Keys: bson.D{{Key: "accountIDIndex", Value: int32(1)}}, Unique: true},
+		})
+		if len(r.Missing) != 1 || len(r.Mismatched) != 3 || len(r.Unknown) != 1 || r.Unknown[0] != "reversed" {
+			t.Errorf("unexpected report: %v", r)
+		}
+	})
+}
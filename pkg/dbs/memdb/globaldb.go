@@ -0,0 +1,238 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GlobalDBService is an in-memory implementation of globaldb.GlobalStore.
+type GlobalDBService struct {
+	mu            sync.RWMutex
+	tempTokens    []models.TempToken
+	appTokens     []models.AppToken
+	revokedTokens []models.RevokedToken
+	instances     []global_types.Instance
+}
+
+var _ globaldb.GlobalStore = &GlobalDBService{}
+
+func NewGlobalDBService() *GlobalDBService {
+	return &GlobalDBService{
+		tempTokens:    []models.TempToken{},
+		appTokens:     []models.AppToken{},
+		revokedTokens: []models.RevokedToken{},
+		instances:     []global_types.Instance{},
+	}
+}
+
+// copyDoc creates a deep copy of a document by encoding it to BSON and back
+func copyDoc(src interface{}, dst interface{}) error {
+	raw, err := bson.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, dst)
+}
+
+func matchTempToken(t models.TempToken, instanceID string, userID string, purpose string) bool {
+	return t.InstanceID == instanceID && t.UserID == userID && (len(purpose) == 0 || t.Purpose == purpose)
+}
+
+// deleteTempTokens removes all temp tokens accepted by the match function and returns the number of deleted tokens
+func (dbService *GlobalDBService) deleteTempTokens(match func(t models.TempToken) bool) int {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	kept := []models.TempToken{}
+	for _, t := range dbService.tempTokens {
+		if !match(t) {
+			kept = append(kept, t)
+		}
+	}
+	count := len(dbService.tempTokens) - len(kept)
+	dbService.tempTokens = kept
+	return count
+}
+
+func (dbService *GlobalDBService) AddTempToken(ctx context.Context, t models.TempToken) (token string, err error) {
+	stored := models.TempToken{}
+	if err := copyDoc(t, &stored); err != nil {
+		return token, err
+	}
+	stored.Token, err = tokens.GenerateUniqueTokenString()
+	if err != nil {
+		return token, err
+	}
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+	dbService.tempTokens = append(dbService.tempTokens, stored)
+	return stored.Token, nil
+}
+
+func (dbService *GlobalDBService) GetTempTokenForUser(ctx context.Context, instanceID string, uid string, purpose string) (tokens models.TempTokens, err error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	tokens = []models.TempToken{}
+	for _, t := range dbService.tempTokens {
+		if !matchTempToken(t, instanceID, uid, purpose) {
+			continue
+		}
+		var result models.TempToken
+		if err := copyDoc(t, &result); err != nil {
+			return tokens, err
+		}
+		tokens = append(tokens, result)
+	}
+	return tokens, nil
+}
+
+func (dbService *GlobalDBService) GetTempToken(ctx context.Context, token string) (models.TempToken, error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	t := models.TempToken{}
+	for _, stored := range dbService.tempTokens {
+		if stored.Token == token {
+			err := copyDoc(stored, &t)
+			return t, err
+		}
+	}
+	return t, mongo.ErrNoDocuments
+}
+
+func (dbService *GlobalDBService) DeleteTempToken(ctx context.Context, token string) error {
+	found := false
+	dbService.deleteTempTokens(func(t models.TempToken) bool {
+		// only delete the first match, same as DeleteOne
+		if !found && t.Token == token {
+			found = true
+			return true
+		}
+		return false
+	})
+	if !found {
+		return errors.New("document not found")
+	}
+	return nil
+}
+
+func (dbService *GlobalDBService) DeleteAllTempTokenForUser(ctx context.Context, instanceID string, userID string, purpose string) error {
+	dbService.deleteTempTokens(func(t models.TempToken) bool {
+		return matchTempToken(t, instanceID, userID, purpose)
+	})
+	return nil
+}
+
+func (dbService *GlobalDBService) DeleteTempTokensExpireBefore(ctx context.Context, instanceID string, purpose string, expiresBefore int64) error {
+	dbService.deleteTempTokens(func(t models.TempToken) bool {
+		if t.Expiration >= expiresBefore {
+			return false
+		}
+		if len(purpose) > 0 && t.Purpose != purpose {
+			return false
+		}
+		if len(instanceID) > 0 && t.InstanceID != instanceID {
+			return false
+		}
+		return true
+	})
+	return nil
+}
+
+func (dbService *GlobalDBService) FindAppToken(ctx context.Context, token string) (appTokenInfos models.AppToken, err error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	for _, at := range dbService.appTokens {
+		for _, t := range at.Tokens {
+			if t == token {
+				err = copyDoc(at, &appTokenInfos)
+				return
+			}
+		}
+	}
+	return appTokenInfos, mongo.ErrNoDocuments
+}
+
+func (dbService *GlobalDBService) AddAppToken(ctx context.Context, appToken models.AppToken) (err error) {
+	stored := models.AppToken{}
+	if err := copyDoc(appToken, &stored); err != nil {
+		return err
+	}
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+	dbService.appTokens = append(dbService.appTokens, stored)
+	return nil
+}
+
+func (dbService *GlobalDBService) AddRevokedToken(ctx context.Context, t models.RevokedToken) error {
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+	dbService.revokedTokens = append(dbService.revokedTokens, t)
+	return nil
+}
+
+func (dbService *GlobalDBService) IsTokenRevoked(ctx context.Context, instanceID string, userID string, tokenID string, issuedAt int64) (bool, error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	for _, t := range dbService.revokedTokens {
+		if t.Matches(instanceID, userID, tokenID, issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (dbService *GlobalDBService) DeleteRevokedTokensExpireBefore(ctx context.Context, expiresBefore int64) error {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	kept := []models.RevokedToken{}
+	for _, t := range dbService.revokedTokens {
+		if t.Expiration >= expiresBefore {
+			kept = append(kept, t)
+		}
+	}
+	dbService.revokedTokens = kept
+	return nil
+}
+
+func (dbService *GlobalDBService) GetAllInstances(ctx context.Context) ([]global_types.Instance, error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	instances := make([]global_types.Instance, len(dbService.instances))
+	copy(instances, dbService.instances)
+	return instances, nil
+}
+
+// AddInstance registers an instance. Instances are managed outside of this service, so the method is not
+// part of globaldb.GlobalStore and only exists to seed the in-memory store.
+func (dbService *GlobalDBService) AddInstance(instance global_types.Instance) {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+	dbService.instances = append(dbService.instances, instance)
+}
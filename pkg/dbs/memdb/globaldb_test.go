@@ -0,0 +1,247 @@
+package memdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func TestGlobalDBTempTokens(t *testing.T) {
+	testDBService := NewGlobalDBService()
+	now := time.Now().Unix()
+
+	testTempToken := models.TempToken{
+		UserID:     "test_user_id",
+		Purpose:    "test_purpose1",
+		InstanceID: testInstanceID,
+		Expiration: now + 10,
+		Info:       map[string]string{"type": "email"},
+	}
+	tokenStr := ""
+
+	t.Run("Add temporary token to DB", func(t *testing.T) {
+		ts, err := testDBService.AddTempToken(context.Background(), testTempToken)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		tokenStr = ts
+
+		testTempToken2 := testTempToken
+		testTempToken2.Purpose = "test_purpose2"
+		testTempToken2.Expiration = now - 10
+		if _, err = testDBService.AddTempToken(context.Background(), testTempToken2); err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		testTempToken3 := testTempToken2
+		testTempToken3.InstanceID = "other-instance"
+		if _, err = testDBService.AddTempToken(context.Background(), testTempToken3); err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	})
+
+	t.Run("get temporary token by token string", func(t *testing.T) {
+		if _, err := testDBService.GetTempToken(context.Background(), tokenStr+"++"); err == nil {
+			t.Error("token should not be found")
+			return
+		}
+		tempToken, err := testDBService.GetTempToken(context.Background(), tokenStr)
+		if err != nil {
+			t.Error("token not found by token string")
+			return
+		}
+		if tempToken.UserID != testTempToken.UserID || tempToken.Purpose != testTempToken.Purpose || tempToken.Info["type"] != "email" {
+			t.Errorf("temp token does not match: %v", tempToken)
+		}
+		tempToken.Info["type"] = "changed"
+		tempToken, _ = testDBService.GetTempToken(context.Background(), tokenStr)
+		if tempToken.Info["type"] != "email" {
+			t.Error("stored token should not be modified")
+		}
+	})
+
+	t.Run("get temporary tokens for user", func(t *testing.T) {
+		for _, tc := range []struct {
+			instanceID string
+			userID     string
+			purpose    string
+			count      int
+		}{
+			{testInstanceID, testTempToken.UserID, "", 2},
+			{testInstanceID, testTempToken.UserID, "test_purpose2", 1},
+			{testInstanceID, testTempToken.UserID + "1", "", 0},
+			{testInstanceID + "1", testTempToken.UserID, "", 0},
+		} {
+			tt, err := testDBService.GetTempTokenForUser(context.Background(), tc.instanceID, tc.userID, tc.purpose)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(tt) != tc.count {
+				t.Errorf("unexpected number of tokens for %v: %d", tc, len(tt))
+			}
+		}
+	})
+
+	t.Run("delete expired tokens of an instance", func(t *testing.T) {
+		if err := testDBService.DeleteTempTokensExpireBefore(context.Background(), testInstanceID, "", now); err != nil {
+			t.Error(err)
+			return
+		}
+		tt, _ := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, "")
+		if len(tt) != 1 {
+			t.Errorf("unexpected number of tokens: %d", len(tt))
+		}
+		tt, _ = testDBService.GetTempTokenForUser(context.Background(), "other-instance", testTempToken.UserID, "")
+		if len(tt) != 1 {
+			t.Errorf("token of other instance should not be deleted: %d", len(tt))
+		}
+	})
+
+	t.Run("delete expired tokens without instance and purpose", func(t *testing.T) {
+		if err := testDBService.DeleteTempTokensExpireBefore(context.Background(), "", "", now); err != nil {
+			t.Error(err)
+			return
+		}
+		tt, _ := testDBService.GetTempTokenForUser(context.Background(), "other-instance", testTempToken.UserID, "")
+		if len(tt) != 0 {
+			t.Errorf("unexpected number of tokens: %d", len(tt))
+		}
+	})
+
+	t.Run("delete temporary token", func(t *testing.T) {
+		if err := testDBService.DeleteTempToken(context.Background(), tokenStr); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := testDBService.DeleteTempToken(context.Background(), tokenStr); err == nil {
+			t.Error("token should not be found")
+		}
+	})
+
+	t.Run("delete all temporary tokens of user", func(t *testing.T) {
+		for _, p := range []string{"p1", "p2"} {
+			tt := testTempToken
+			tt.Purpose = p
+			if _, err := testDBService.AddTempToken(context.Background(), tt); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if err := testDBService.DeleteAllTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, "p1"); err != nil {
+			t.Error(err)
+			return
+		}
+		tt, _ := testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, "")
+		if len(tt) != 1 || tt[0].Purpose != "p2" {
+			t.Errorf("unexpected tokens: %v", tt)
+		}
+		if err := testDBService.DeleteAllTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, ""); err != nil {
+			t.Error(err)
+			return
+		}
+		tt, _ = testDBService.GetTempTokenForUser(context.Background(), testInstanceID, testTempToken.UserID, "")
+		if len(tt) != 0 {
+			t.Errorf("unexpected tokens: %v", tt)
+		}
+	})
+}
+
+func TestGlobalDBAppTokens(t *testing.T) {
+	testDBService := NewGlobalDBService()
+	err := testDBService.AddAppToken(context.Background(), models.AppToken{
+		AppName:   "test",
+		Tokens:    []string{"t1", "t2"},
+		Instances: []string{testInstanceID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("find by any of its tokens", func(t *testing.T) {
+		at, err := testDBService.FindAppToken(context.Background(), "t2")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if at.AppName != "test" || len(at.Instances) != 1 {
+			t.Errorf("unexpected app token: %v", at)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		if _, err := testDBService.FindAppToken(context.Background(), "t3"); err == nil {
+			t.Error("app token should not be found")
+		}
+	})
+}
+
+func TestGlobalDBRevokedTokens(t *testing.T) {
+	testDBService := NewGlobalDBService()
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	entries := []models.RevokedToken{
+		{InstanceID: testInstanceID, UserID: "u1", TokenID: "jti1", Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u2", IssuedUntil: now, Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u3", IssuedUntil: now, Expiration: now - 10},
+	}
+	for _, e := range entries {
+		if err := testDBService.AddRevokedToken(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checks := []struct {
+		instanceID, userID, tokenID string
+		issuedAt                    int64
+		revoked                     bool
+	}{
+		{testInstanceID, "u1", "jti1", now, true},
+		{testInstanceID, "u1", "jti2", now, false},
+		{"other-instance", "u1", "jti1", now, false},
+		{testInstanceID, "u2", "jti3", now - 5, true},
+		{testInstanceID, "u2", "jti3", now - 1, true},
+		{testInstanceID, "u2", "jti3", now, false},
+		{testInstanceID, "u2", "", now - 1, true},
+		{testInstanceID, "u1", "", now, false},
+	}
+	for _, c := range checks {
+		revoked, err := testDBService.IsTokenRevoked(ctx, c.instanceID, c.userID, c.tokenID, c.issuedAt)
+		if err != nil || revoked != c.revoked {
+			t.Errorf("unexpected result for %v: %v, %v", c, revoked, err)
+		}
+	}
+
+	t.Run("delete expired entries", func(t *testing.T) {
+		if err := testDBService.DeleteRevokedTokensExpireBefore(ctx, now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u3", "", now-1); revoked {
+			t.Error("expired entry should be deleted")
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u2", "", now-1); !revoked {
+			t.Error("entry should be kept")
+		}
+	})
+}
+
+func TestGlobalDBInstances(t *testing.T) {
+	testDBService := NewGlobalDBService()
+	instances, err := testDBService.GetAllInstances(context.Background())
+	if err != nil || len(instances) != 0 {
+		t.Errorf("unexpected result: %v, %v", instances, err)
+		return
+	}
+	testDBService.AddInstance(global_types.Instance{InstanceID: testInstanceID})
+	instances, err = testDBService.GetAllInstances(context.Background())
+	if err != nil || len(instances) != 1 || instances[0].InstanceID != testInstanceID {
+		t.Errorf("unexpected result: %v, %v", instances, err)
+	}
+}
@@ -0,0 +1,459 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UserDBService is an in-memory implementation of userdb.UserStore. Users are kept as encoded BSON documents,
+// so that values returned to the caller never share memory with the stored state - same as with MongoDB.
+type UserDBService struct {
+	mu             sync.RWMutex
+	instances      map[string][]userDoc
+	schemaVersions map[string]int
+	clock          clock.Clock
+}
+
+type userDoc struct {
+	id  primitive.ObjectID
+	raw []byte
+}
+
+var _ userdb.UserStore = &UserDBService{}
+
+func NewUserDBService(clk clock.Clock) *UserDBService {
+	return &UserDBService{
+		instances:      map[string][]userDoc{},
+		schemaVersions: map[string]int{},
+		clock:          clk,
+	}
+}
+
+// DB utils
+func encodeUser(user models.User) ([]byte, error) {
+	return bson.Marshal(user)
+}
+
+func decodeUser(raw []byte) (models.User, error) {
+	user := models.User{}
+	err := bson.Unmarshal(raw, &user)
+	return user, err
+}
+
+func (dbService *UserDBService) indexOf(instanceID string, id primitive.ObjectID) int {
+	for i, d := range dbService.instances[instanceID] {
+		if d.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findUsers returns a snapshot of all users of the instance accepted by the match function
+func (dbService *UserDBService) findUsers(instanceID string, match func(u models.User) bool) ([]models.User, error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	users := []models.User{}
+	for _, d := range dbService.instances[instanceID] {
+		u, err := decodeUser(d.raw)
+		if err != nil {
+			return users, err
+		}
+		if match(u) {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+// updateUser applies the update function to the stored user with the given id and increments its revision. The
+// updated user is returned, mongo.ErrNoDocuments if the user does not exist.
+func (dbService *UserDBService) updateUser(instanceID string, id primitive.ObjectID, update func(u *models.User) error) (models.User, error) {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	i := dbService.indexOf(instanceID, id)
+	if i < 0 {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	u, err := decodeUser(dbService.instances[instanceID][i].raw)
+	if err != nil {
+		return u, err
+	}
+	if err := update(&u); err != nil {
+		return u, err
+	}
+	u.Revision += 1
+	raw, err := encodeUser(u)
+	if err != nil {
+		return u, err
+	}
+	dbService.instances[instanceID][i].raw = raw
+	return decodeUser(raw)
+}
+
+func (dbService *UserDBService) AddUser(ctx context.Context, instanceID string, user models.User) (id string, err error) {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	for _, d := range dbService.instances[instanceID] {
+		u, err := decodeUser(d.raw)
+		if err != nil {
+			return "", err
+		}
+		if u.Account.AccountID == user.Account.AccountID {
+			return "", errors.New("user already exists")
+		}
+	}
+
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	raw, err := encodeUser(user)
+	if err != nil {
+		return "", err
+	}
+	dbService.instances[instanceID] = append(dbService.instances[instanceID], userDoc{id: user.ID, raw: raw})
+	return user.ID.Hex(), nil
+}
+
+func (dbService *UserDBService) UpdateUser(ctx context.Context, instanceID string, updatedUser models.User) (models.User, error) {
+	// Set last update time
+	updatedUser.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+	return dbService.updateUser(instanceID, updatedUser.ID, func(u *models.User) error {
+		if u.Revision != updatedUser.Revision {
+			return &userdb.RevisionConflictError{UserID: updatedUser.ID.Hex(), Revision: updatedUser.Revision}
+		}
+		*u = updatedUser
+		return nil
+	})
+}
+
+func (dbService *UserDBService) GetUserByID(ctx context.Context, instanceID string, id string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(id)
+
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+
+	i := dbService.indexOf(instanceID, _id)
+	if i < 0 {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	return decodeUser(dbService.instances[instanceID][i].raw)
+}
+
+func (dbService *UserDBService) GetUserByAccountID(ctx context.Context, instanceID string, username string) (models.User, error) {
+	users, err := dbService.findUsers(instanceID, func(u models.User) bool {
+		return u.Account.AccountID == username
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	if len(users) < 1 {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	return users[0], nil
+}
+
+func (dbService *UserDBService) GetUserByRefreshToken(ctx context.Context, instanceID string, token string) (models.User, error) {
+	users, err := dbService.findUsers(instanceID, func(u models.User) bool {
+		return u.FindRefreshTokenFamilyByToken(token) > -1
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	if len(users) < 1 {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	return users[0], nil
+}
+
+// setFields is used for methods that perform an UpdateOne: a missing user is not an error there.
+func (dbService *UserDBService) setFields(instanceID string, userID string, update func(u *models.User)) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		update(u)
+		return nil
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+func (dbService *UserDBService) UpdateUserPassword(ctx context.Context, instanceID string, userID string, newPassword string) error {
+	return dbService.setFields(instanceID, userID, func(u *models.User) {
+		u.Account.Password = newPassword
+		u.Timestamps.LastPasswordChange = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) SaveFailedLoginAttempt(ctx context.Context, instanceID string, userID string) error {
+	return dbService.setFields(instanceID, userID, func(u *models.User) {
+		u.Account.FailedLoginAttempts = append(u.Account.FailedLoginAttempts, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) SavePasswordResetTrigger(ctx context.Context, instanceID string, userID string) error {
+	return dbService.setFields(instanceID, userID, func(u *models.User) {
+		u.Account.PasswordResetTriggers = append(u.Account.PasswordResetTriggers, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) UpdateAccountPreferredLang(ctx context.Context, instanceID string, userID string, lang string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.Account.PreferredLanguage = lang
+		u.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+		return nil
+	})
+}
+
+func (dbService *UserDBService) UpdateContactPreferences(ctx context.Context, instanceID string, userID string, prefs models.ContactPreferences) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.ContactPreferences = prefs
+		u.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+		return nil
+	})
+}
+
+func (dbService *UserDBService) UpdateLoginTime(ctx context.Context, instanceID string, id string) error {
+	return dbService.setFields(instanceID, id, func(u *models.User) {
+		u.Timestamps.LastLogin = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) UpdateReminderToConfirmSentAtTime(ctx context.Context, instanceID string, id string) error {
+	return dbService.setFields(instanceID, id, func(u *models.User) {
+		u.Timestamps.ReminderToConfirmSentAt = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) AddRefreshTokenFamily(ctx context.Context, instanceID string, userID string, family models.RefreshTokenFamily, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.AddRefreshTokenFamily(family, maxFamilies)
+		return nil
+	})
+}
+
+func (dbService *UserDBService) RenewRefreshToken(ctx context.Context, instanceID string, userID string, oldToken string, rotatedToken string, newToken string, session models.SessionInfo, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		now := dbService.clock.Now().Unix()
+		if err := u.RenewRefreshToken(oldToken, rotatedToken, newToken, session, now, maxFamilies); err != nil {
+			return mongo.ErrNoDocuments
+		}
+		u.Timestamps.LastTokenRefresh = now
+		return nil
+	})
+}
+
+func (dbService *UserDBService) RemoveAllRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.RemoveAllRefreshTokens()
+		return nil
+	})
+	return err
+}
+
+func (dbService *UserDBService) RemoveLegacyRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.RemoveLegacyRefreshTokens()
+		return nil
+	})
+	return err
+}
+
+func (dbService *UserDBService) RemoveRefreshTokenFamily(ctx context.Context, instanceID string, userID string, familyID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		return u.RemoveRefreshTokenFamily(familyID)
+	})
+	return err
+}
+
+func (dbService *UserDBService) AddRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		return u.AddRole(role)
+	})
+}
+
+func (dbService *UserDBService) RemoveRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		return u.RemoveRole(role)
+	})
+}
+
+func (dbService *UserDBService) AddContactInfo(ctx context.Context, instanceID string, userID string, contactInfo models.ContactInfo) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		u.ContactInfos = append(u.ContactInfos, contactInfo)
+		return nil
+	})
+}
+
+func (dbService *UserDBService) RemoveContactInfo(ctx context.Context, instanceID string, userID string, contactInfoID string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(instanceID, _id, func(u *models.User) error {
+		if err := u.RemoveContactInfo(contactInfoID); err != nil {
+			return err
+		}
+		u.RemoveContactInfoFromContactPreferences(contactInfoID)
+		return nil
+	})
+}
+
+func (dbService *UserDBService) SetContactInfoVerificationSent(ctx context.Context, instanceID string, userID string, t string, addr string) error {
+	return dbService.setFields(instanceID, userID, func(u *models.User) {
+		u.SetContactInfoVerificationSent(t, addr, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) CountRecentlyCreatedUsers(ctx context.Context, instanceID string, interval int64) (count int64, err error) {
+	ref := dbService.clock.Now().Unix() - interval
+	users, err := dbService.findUsers(instanceID, func(u models.User) bool {
+		return u.Timestamps.CreatedAt > ref
+	})
+	return int64(len(users)), err
+}
+
+func (dbService *UserDBService) DeleteUser(ctx context.Context, instanceID string, id string) error {
+	_id, _ := primitive.ObjectIDFromHex(id)
+
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	i := dbService.indexOf(instanceID, _id)
+	if i < 0 {
+		return errors.New("no user found with the given id")
+	}
+	docs := dbService.instances[instanceID]
+	dbService.instances[instanceID] = append(docs[:i], docs[i+1:]...)
+	return nil
+}
+
+func (dbService *UserDBService) DeleteUnverfiedUsers(ctx context.Context, instanceID string, createdBefore int64) (int64, error) {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+
+	count := int64(0)
+	kept := []userDoc{}
+	for _, d := range dbService.instances[instanceID] {
+		u, err := decodeUser(d.raw)
+		if err != nil {
+			return count, err
+		}
+		if u.Account.AccountConfirmedAt == 0 && u.Timestamps.CreatedAt < createdBefore {
+			count++
+			continue
+		}
+		kept = append(kept, d)
+	}
+	dbService.instances[instanceID] = kept
+	return count, nil
+}
+
+func (dbService *UserDBService) FindNonParticipantUsers(ctx context.Context, instanceID string) (users []models.User, err error) {
+	return dbService.findUsers(instanceID, func(u models.User) bool {
+		return u.HasRole(constants.USER_ROLE_SERVICE_ACCOUNT) ||
+			u.HasRole(constants.USER_ROLE_RESEARCHER) ||
+			u.HasRole(constants.USER_ROLE_ADMIN)
+	})
+}
+
+func (dbService *UserDBService) PerfomActionForUsers(
+	ctx context.Context,
+	instanceID string,
+	filters userdb.UserFilter,
+	cbk func(instanceID string, user models.User, args ...interface{}) error,
+	args ...interface{},
+) (err error) {
+	users, err := dbService.findUsers(instanceID, func(u models.User) bool {
+		if filters.OnlyConfirmed && u.Account.AccountConfirmedAt <= 0 {
+			return false
+		}
+		if filters.ReminderWeekDay > -1 && u.ContactPreferences.ReceiveWeeklyMessageDayOfWeek != filters.ReminderWeekDay {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			logger.Debug.Println(ctx.Err())
+			return ctx.Err()
+		}
+		if err := cbk(instanceID, user, args...); err != nil {
+			logger.Debug.Printf("error in callback: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (dbService *UserDBService) SendReminderToConfirmAccountLoop(
+	ctx context.Context,
+	instanceID string,
+	createdBefore int64,
+	cbk func(instanceID string, user models.User, args ...interface{}) error,
+	args ...interface{},
+) (err error) {
+	users, err := dbService.findUsers(instanceID, func(u models.User) bool {
+		return u.Account.AccountConfirmedAt < 1 &&
+			u.Timestamps.ReminderToConfirmSentAt < 1 &&
+			u.Timestamps.CreatedAt < createdBefore
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			logger.Debug.Println(ctx.Err())
+			return ctx.Err()
+		}
+		if err := cbk(instanceID, user, args...); err != nil {
+			logger.Debug.Printf("error in callback: %v", err)
+			continue
+		}
+
+		if err := dbService.UpdateReminderToConfirmSentAtTime(ctx, instanceID, user.ID.Hex()); err != nil {
+			logger.Error.Printf("unexpected error: %v", err)
+			continue
+		}
+	}
+	return nil
+}
+
+func (dbService *UserDBService) GetSchemaVersion(ctx context.Context, instanceID string) (int, error) {
+	dbService.mu.RLock()
+	defer dbService.mu.RUnlock()
+	return dbService.schemaVersions[instanceID], nil
+}
+
+func (dbService *UserDBService) SetSchemaVersion(ctx context.Context, instanceID string, version int) error {
+	dbService.mu.Lock()
+	defer dbService.mu.Unlock()
+	dbService.schemaVersions[instanceID] = version
+	return nil
+}
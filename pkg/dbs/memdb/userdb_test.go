@@ -0,0 +1,562 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const testInstanceID = "test-instance"
+
+// Testing Database Interface methods
+func TestUserDBInterfaceMethods(t *testing.T) {
+	testDBService := NewUserDBService(clock.Real)
+
+	testUser := models.User{
+		Account: models.Account{
+			Type:      "email",
+			AccountID: "test@test.com",
+			Password:  "testhashedpassword-youcantreadme",
+		},
+		Roles: []string{"TEST"},
+		Timestamps: models.Timestamps{
+			CreatedAt: time.Now().Unix(),
+		},
+	}
+
+	t.Run("Testing create user", func(t *testing.T) {
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, testUser)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if len(id) == 0 {
+			t.Errorf("id is missing")
+			return
+		}
+		_id, _ := primitive.ObjectIDFromHex(id)
+		testUser.ID = _id
+	})
+
+	t.Run("Testing creating existing user", func(t *testing.T) {
+		testUser2 := testUser
+		testUser2.Roles = []string{"TEST2"}
+		_, err := testDBService.AddUser(context.Background(), testInstanceID, testUser2)
+		if err == nil {
+			t.Errorf("user already existed, but created again")
+			return
+		}
+		u, e := testDBService.GetUserByAccountID(context.Background(), testInstanceID, testUser2.Account.AccountID)
+		if e != nil {
+			t.Errorf(e.Error())
+			return
+		}
+		if len(u.Roles) > 0 && u.Roles[0] == "TEST2" {
+			t.Error("user should not be updated")
+		}
+	})
+
+	t.Run("Testing same account id in other instance", func(t *testing.T) {
+		_, err := testDBService.AddUser(context.Background(), "other-instance", testUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing find existing user by id", func(t *testing.T) {
+		user, err := testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if user.Account.AccountID != testUser.Account.AccountID {
+			t.Errorf("found user is not matching test user")
+			return
+		}
+	})
+
+	t.Run("Testing find not existing user by id", func(t *testing.T) {
+		_, err := testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex()+"1")
+		if err == nil {
+			t.Errorf("user should not be found")
+			return
+		}
+	})
+
+	t.Run("Testing find not existing user by email", func(t *testing.T) {
+		_, err := testDBService.GetUserByAccountID(context.Background(), testInstanceID, testUser.Account.AccountID+"1")
+		if err == nil {
+			t.Errorf("user should not be found")
+			return
+		}
+	})
+
+	t.Run("Testing returned user does not share state with the store", func(t *testing.T) {
+		user, err := testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		user.Roles[0] = "CHANGED"
+		user, err = testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if user.Roles[0] != "TEST" {
+			t.Errorf("stored user should not be modified: %v", user.Roles)
+		}
+	})
+
+	t.Run("Testing updating existing user's attributes", func(t *testing.T) {
+		testUser.Account.AccountConfirmedAt = time.Now().Unix()
+		user, err := testDBService.UpdateUser(context.Background(), testInstanceID, testUser)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if user.Timestamps.UpdatedAt == 0 || user.Account.AccountConfirmedAt != testUser.Account.AccountConfirmedAt {
+			t.Errorf("unexpected user: %v", user)
+		}
+	})
+
+	t.Run("Testing updating user with outdated revision", func(t *testing.T) {
+		// testUser still has the revision it was created with
+		_, err := testDBService.UpdateUser(context.Background(), testInstanceID, testUser)
+		if !userdb.IsRevisionConflict(err) {
+			t.Errorf("conflict expected: %v", err)
+		}
+	})
+
+	t.Run("Testing update with retry after conflict", func(t *testing.T) {
+		attempts := 0
+		user, err := userdb.UpdateUserWithRetry(context.Background(), testDBService, testInstanceID, testUser, func(user *models.User) error {
+			attempts += 1
+			return user.AddRole("RETRY")
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if attempts != 2 || !user.HasRole("RETRY") || user.Account.AccountConfirmedAt == 0 {
+			t.Errorf("unexpected user after %d attempts: %v", attempts, user)
+		}
+		testUser = user
+	})
+
+	t.Run("Testing failed update with retry keeps the user", func(t *testing.T) {
+		currentUser := testUser
+		currentUser.ID = primitive.NewObjectID()
+		user, err := userdb.UpdateUserWithRetry(context.Background(), testDBService, testInstanceID, currentUser, func(user *models.User) error {
+			return nil
+		})
+		if err == nil {
+			t.Error("cannot update not existing user")
+		}
+		if user.ID != currentUser.ID {
+			t.Errorf("unexpected user: %v", user)
+		}
+	})
+
+	t.Run("Testing updating not existing user's attributes", func(t *testing.T) {
+		currentUser := testUser
+		currentUser.ID = primitive.NewObjectID()
+		_, err := testDBService.UpdateUser(context.Background(), testInstanceID, currentUser)
+		if err == nil {
+			t.Errorf("cannot update not existing user")
+			return
+		}
+	})
+
+	t.Run("Testing field updates", func(t *testing.T) {
+		if err := testDBService.UpdateUserPassword(context.Background(), testInstanceID, testUser.ID.Hex(), "newpw"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := testDBService.SaveFailedLoginAttempt(context.Background(), testInstanceID, testUser.ID.Hex()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := testDBService.UpdateLoginTime(context.Background(), testInstanceID, primitive.NewObjectID().Hex()); err != nil {
+			t.Errorf("missing user should be ignored: %v", err)
+			return
+		}
+		user, err := testDBService.UpdateAccountPreferredLang(context.Background(), testInstanceID, testUser.ID.Hex(), "de")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user.Account.Password != "newpw" || len(user.Account.FailedLoginAttempts) != 1 || user.Account.PreferredLanguage != "de" {
+			t.Errorf("unexpected user: %v", user)
+		}
+		if _, err := testDBService.UpdateAccountPreferredLang(context.Background(), testInstanceID, primitive.NewObjectID().Hex(), "de"); err == nil {
+			t.Error("error expected for missing user")
+		}
+	})
+
+	t.Run("Testing refresh token updates", func(t *testing.T) {
+		for i := 0; i < models.MaxRefreshTokens+2; i++ {
+			family := models.NewRefreshTokenFamily(fmt.Sprintf("rt%d", i), models.SessionInfo{}, int64(i))
+			if _, err := testDBService.AddRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), family, models.MaxRefreshTokens); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+		}
+		user, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, testUser.ID.Hex(), "rt5", "rt5", "new", models.SessionInfo{IPAddress: "127.0.0.1"}, models.MaxRefreshTokens)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		families := user.Account.RefreshTokenFamilies
+		if len(families) != models.MaxRefreshTokens || families[0].Token != "rt2" ||
+			user.HasRefreshToken("rt5") || !user.HasRefreshToken("new") {
+			t.Errorf("unexpected refresh tokens: %v", families)
+		}
+		familyID, found := user.FindRotatedRefreshToken("rt5")
+		if i := user.FindRefreshTokenFamily(familyID); !found || i < 0 || families[i].Token != "new" || families[i].IPAddress != "127.0.0.1" || families[i].LastUsedAt < 100 {
+			t.Errorf("unexpected token family: %v", families)
+		}
+		if _, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, testUser.ID.Hex(), "rt5", "rt5", "new2", models.SessionInfo{}, models.MaxRefreshTokens); err != mongo.ErrNoDocuments {
+			t.Errorf("used token should not be accepted: %v", err)
+		}
+		// the least recently used session is removed
+		user, err = testDBService.AddRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), models.NewRefreshTokenFamily("rt12", models.SessionInfo{}, 100), models.MaxRefreshTokens)
+		if err != nil || len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens || user.HasRefreshToken("rt2") ||
+			!user.HasRefreshToken("rt3") || !user.HasRefreshToken("new") || !user.HasRefreshToken("rt12") {
+			t.Errorf("unexpected refresh tokens: %v, %v", user.Account.RefreshTokenFamilies, err)
+		}
+		if err := testDBService.RemoveRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), familyID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		user, _ = testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if user.HasRefreshToken("new") || len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens-1 {
+			t.Errorf("token family not removed: %v", user.Account.RefreshTokenFamilies)
+		}
+		if user, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "rt12"); err != nil || user.ID != testUser.ID {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "new"); err != mongo.ErrNoDocuments {
+			t.Errorf("token of removed family should not be found: %v", err)
+		}
+		if err := testDBService.RemoveAllRefreshTokens(context.Background(), testInstanceID, testUser.ID.Hex()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing renewal of legacy refresh token keeps the session limit", func(t *testing.T) {
+		legacyUser := models.User{Account: models.Account{Type: "email", AccountID: "legacy-rt@test.com", RefreshTokens: []string{"legacy-rt"}}}
+		for i := 0; i < models.MaxRefreshTokens; i++ {
+			legacyUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily(fmt.Sprintf("legacy-family-%d", i), models.SessionInfo{}, int64(i)), models.MaxRefreshTokens)
+		}
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, legacyUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, id, "legacy-rt", "legacy-rt-hash", "legacy-new", models.SessionInfo{}, models.MaxRefreshTokens)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens || len(user.Account.RefreshTokens) != 0 ||
+			!user.HasRefreshToken("legacy-new") || user.HasRefreshToken("legacy-family-0") {
+			t.Errorf("unexpected refresh tokens: %v", user.Account.RefreshTokenFamilies)
+		}
+		if _, found := user.FindRotatedRefreshToken("legacy-rt-hash"); !found {
+			t.Error("hashed legacy token should be kept as rotated token")
+		}
+		if _, found := user.FindRotatedRefreshToken("legacy-rt"); found {
+			t.Error("legacy token should not be kept in plaintext")
+		}
+	})
+
+	t.Run("Testing removal of legacy refresh tokens", func(t *testing.T) {
+		legacyUser := models.User{Account: models.Account{Type: "email", AccountID: "legacy-rt-removal@test.com", RefreshTokens: []string{"legacy-rt"}}}
+		legacyUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily("family-rt", models.SessionInfo{}, 1), models.MaxRefreshTokens)
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, legacyUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "family-rt"); err != nil || user.ID.Hex() != id {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "legacy-rt"); err != mongo.ErrNoDocuments {
+			t.Errorf("legacy refresh token should not be looked up: %v", err)
+		}
+		if err := testDBService.RemoveLegacyRefreshTokens(context.Background(), testInstanceID, id); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, err := testDBService.GetUserByID(context.Background(), testInstanceID, id)
+		if err != nil || user.HasRefreshToken("legacy-rt") || !user.HasRefreshToken("family-rt") {
+			t.Errorf("unexpected refresh tokens: %v, %v", user.Account, err)
+		}
+	})
+
+	t.Run("Testing role updates", func(t *testing.T) {
+		user, err := testDBService.AddRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN")
+		if err != nil || !user.HasRole("ADMIN") {
+			t.Errorf("unexpected result: %v %v", user.Roles, err)
+		}
+		if _, err := testDBService.AddRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN"); err == nil {
+			t.Error("error expected for existing role")
+		}
+		user, err = testDBService.RemoveRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN")
+		if err != nil || user.HasRole("ADMIN") {
+			t.Errorf("unexpected result: %v %v", user.Roles, err)
+		}
+		if _, err := testDBService.RemoveRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN"); err == nil {
+			t.Error("error expected for missing role")
+		}
+	})
+
+	t.Run("Testing contact info updates", func(t *testing.T) {
+		ci := models.NewEmailContactInfo("second@test.com", false, 0)
+		user, err := testDBService.AddContactInfo(context.Background(), testInstanceID, testUser.ID.Hex(), ci)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if _, found := user.FindContactInfoById(ci.ID.Hex()); !found {
+			t.Errorf("contact info not added: %v", user.ContactInfos)
+		}
+		if err := testDBService.SetContactInfoVerificationSent(context.Background(), testInstanceID, testUser.ID.Hex(), "email", ci.Email); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		user, _ = testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if c, _ := user.FindContactInfoById(ci.ID.Hex()); c.ConfirmationLinkSentAt == 0 {
+			t.Errorf("verification sent time not set: %v", c)
+		}
+		user, err = testDBService.RemoveContactInfo(context.Background(), testInstanceID, testUser.ID.Hex(), ci.ID.Hex())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if _, found := user.FindContactInfoById(ci.ID.Hex()); found {
+			t.Errorf("contact info not removed: %v", user.ContactInfos)
+		}
+	})
+
+	t.Run("Testing counting recently added users", func(t *testing.T) {
+		count, err := testDBService.CountRecentlyCreatedUsers(context.Background(), testInstanceID, 20)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if count != 1 {
+			t.Errorf("unexpected count: %d", count)
+		}
+	})
+
+	t.Run("Testing deleting existing user", func(t *testing.T) {
+		err := testDBService.DeleteUser(context.Background(), testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	})
+
+	t.Run("Testing deleting not existing user", func(t *testing.T) {
+		err := testDBService.DeleteUser(context.Background(), testInstanceID, testUser.ID.Hex())
+		if err == nil {
+			t.Errorf("user should not be found - error expected")
+			return
+		}
+	})
+}
+
+func TestUserDBPerformActionForUsers(t *testing.T) {
+	testDBService := NewUserDBService(clock.Real)
+	testUsers := []models.User{
+		{Account: models.Account{AccountID: "1", AccountConfirmedAt: 1}, ContactPreferences: models.ContactPreferences{ReceiveWeeklyMessageDayOfWeek: 2}},
+		{Account: models.Account{AccountID: "2"}, ContactPreferences: models.ContactPreferences{ReceiveWeeklyMessageDayOfWeek: 2}},
+		{Account: models.Account{AccountID: "3", AccountConfirmedAt: 1}, ContactPreferences: models.ContactPreferences{ReceiveWeeklyMessageDayOfWeek: 4}},
+	}
+	for _, u := range testUsers {
+		if _, err := testDBService.AddUser(context.Background(), testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	countUsers := func(filter userdb.UserFilter) (int, error) {
+		counter := 0
+		err := testDBService.PerfomActionForUsers(
+			context.TODO(),
+			testInstanceID,
+			filter,
+			func(instanceID string, user models.User, args ...interface{}) error {
+				if len(args) != 1 || args[0] != "hello" {
+					return errors.New("unexpected args")
+				}
+				counter++
+				return nil
+			},
+			"hello",
+		)
+		return counter, err
+	}
+
+	for _, tc := range []struct {
+		filter userdb.UserFilter
+		count  int
+	}{
+		{userdb.UserFilter{OnlyConfirmed: false, ReminderWeekDay: -1}, 3},
+		{userdb.UserFilter{OnlyConfirmed: true, ReminderWeekDay: -1}, 2},
+		{userdb.UserFilter{OnlyConfirmed: false, ReminderWeekDay: 2}, 2},
+		{userdb.UserFilter{OnlyConfirmed: true, ReminderWeekDay: 2}, 1},
+	} {
+		t.Run(fmt.Sprintf("filter %v", tc.filter), func(t *testing.T) {
+			count, err := countUsers(tc.filter)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if count != tc.count {
+				t.Errorf("unexpected count: %d instead of %d", count, tc.count)
+			}
+		})
+	}
+
+	t.Run("callback error stops the loop", func(t *testing.T) {
+		counter := 0
+		err := testDBService.PerfomActionForUsers(context.TODO(), testInstanceID, userdb.UserFilter{ReminderWeekDay: -1},
+			func(instanceID string, user models.User, args ...interface{}) error {
+				counter++
+				return errors.New("test")
+			})
+		if err == nil || counter != 1 {
+			t.Errorf("unexpected result: %v, %d", err, counter)
+		}
+	})
+}
+
+func TestUserDBSendReminderToConfirmAccountLoop(t *testing.T) {
+	testDBService := NewUserDBService(clock.Real)
+	now := time.Now().Unix()
+	testUsers := []models.User{
+		{Account: models.Account{AccountID: "1"}, Timestamps: models.Timestamps{CreatedAt: now - 100}},
+		{Account: models.Account{AccountID: "2", AccountConfirmedAt: now}, Timestamps: models.Timestamps{CreatedAt: now - 100}},
+		{Account: models.Account{AccountID: "3"}, Timestamps: models.Timestamps{CreatedAt: now}},
+	}
+	for _, u := range testUsers {
+		if _, err := testDBService.AddUser(context.Background(), testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sendReminders := func() int {
+		counter := 0
+		err := testDBService.SendReminderToConfirmAccountLoop(context.TODO(), testInstanceID, now-50,
+			func(instanceID string, user models.User, args ...interface{}) error {
+				counter++
+				return nil
+			})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return counter
+	}
+
+	if c := sendReminders(); c != 1 {
+		t.Errorf("unexpected number of reminders: %d", c)
+	}
+	if c := sendReminders(); c != 0 {
+		t.Errorf("reminder should be sent only once: %d", c)
+	}
+}
+
+func AssertNumberOfNonParticipantUsers(dbService *UserDBService, instanceID string, count int) error {
+	users, err := dbService.FindNonParticipantUsers(context.Background(), instanceID)
+	if err != nil {
+		return err
+	}
+	if len(users) != count {
+		return fmt.Errorf("wrong number of users found: %d instead of %d", len(users), count)
+	}
+	return nil
+}
+
+func TestUserDBDeleteUnverfiedUsers(t *testing.T) {
+	testDBService := NewUserDBService(clock.Real)
+	testUsers := []models.User{
+		{Account: models.Account{AccountID: "delete_1"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix() - 100}},
+		{Account: models.Account{AccountID: "delete_2"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix() - 50}},
+		{Account: models.Account{AccountID: "delete_3"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix()}},
+		{Account: models.Account{AccountID: "participant"}, Roles: []string{"PARTICIPANT"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix()}},
+	}
+	for _, u := range testUsers {
+		if _, err := testDBService.AddUser(context.Background(), testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		threshold int64
+		deleted   int64
+		remaining int
+	}{
+		{"remove no user", time.Now().Unix() - 105, 0, 3},
+		{"remove 1 user", time.Now().Unix() - 55, 1, 2},
+		{"remove an other user", time.Now().Unix() - 15, 1, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			count, err := testDBService.DeleteUnverfiedUsers(context.Background(), testInstanceID, tc.threshold)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if count != tc.deleted {
+				t.Errorf("unexpected number of deleted users: %d", count)
+			}
+			if err := AssertNumberOfNonParticipantUsers(testDBService, testInstanceID, tc.remaining); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserDBWithFakeClock(t *testing.T) {
+	c := clock.NewFake(time.Unix(1600000000, 0))
+	testDBService := NewUserDBService(c)
+
+	id, err := testDBService.AddUser(context.Background(), testInstanceID, models.User{
+		Account:    models.Account{AccountID: "clock@test.com"},
+		Timestamps: models.Timestamps{CreatedAt: c.Now().Unix()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("timestamps use the injected clock", func(t *testing.T) {
+		if err := testDBService.UpdateLoginTime(context.Background(), testInstanceID, id); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, _ := testDBService.GetUserByID(context.Background(), testInstanceID, id)
+		if user.Timestamps.LastLogin != c.Now().Unix() {
+			t.Errorf("unexpected login time: %d", user.Timestamps.LastLogin)
+		}
+	})
+
+	t.Run("recently created users relative to the clock", func(t *testing.T) {
+		count, _ := testDBService.CountRecentlyCreatedUsers(context.Background(), testInstanceID, 60)
+		if count != 1 {
+			t.Errorf("unexpected count: %d", count)
+		}
+		c.Advance(2 * time.Minute)
+		count, _ = testDBService.CountRecentlyCreatedUsers(context.Background(), testInstanceID, 60)
+		if count != 0 {
+			t.Errorf("unexpected count: %d", count)
+		}
+	})
+}
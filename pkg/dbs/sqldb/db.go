@@ -0,0 +1,109 @@
+// Package sqldb stores users and global data in a SQL database (PostgreSQL or SQLite) instead of MongoDB.
+// UserDBService and GlobalDBService implement userdb.UserStore and globaldb.GlobalStore with the same semantics as
+// the MongoDB implementations - including mongo.ErrNoDocuments for missing documents, which callers check for.
+// Both services can share one connection, the users of all instances are kept in the same tables.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+
+	// database driver, the SQLite driver is only included with cgo (sqlite.go)
+	_ "github.com/lib/pq"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Connect opens the database and applies the pending schema migrations
+func Connect(configs models.SQLDBConfig) (*sql.DB, error) {
+	if configs.Driver != DriverPostgres && configs.Driver != DriverSQLite {
+		return nil, fmt.Errorf("unsupported SQL driver %q", configs.Driver)
+	}
+	if configs.Driver == DriverSQLite && !SQLiteAvailable {
+		return nil, fmt.Errorf("SQLite is not available, the service was built without cgo")
+	}
+	db, err := sql.Open(configs.Driver, configs.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if configs.Driver == DriverSQLite {
+		// SQLite has a single writer, and each connection to ":memory:" would open a new database
+		db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(configs.Timeout)*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("fail to connect to DB: %v", err)
+	}
+	if err := migrateSchema(ctx, db, configs.Driver); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// conn holds what both services need to run queries
+type conn struct {
+	db      *sql.DB
+	driver  string
+	timeout int
+}
+
+// q converts the ? placeholders of the query to the format of the driver
+func (c conn) q(query string) string {
+	if c.driver != DriverPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// getContext derives the context for a single DB operation from the request context, adding the configured timeout
+func (c conn) getContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc) {
+	return context.WithTimeout(parent, time.Duration(c.timeout)*time.Second)
+}
+
+// inTx runs fn in a transaction, which is committed if fn returns no error
+func (c conn) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// placeholders returns "?, ?, ..." for n values
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
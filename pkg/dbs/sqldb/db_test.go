@@ -0,0 +1,109 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func testConfig() models.SQLDBConfig {
+	return models.SQLDBConfig{Driver: DriverSQLite, DSN: ":memory:", Timeout: 10}
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	if !SQLiteAvailable {
+		t.Skip("the tests use SQLite, which needs cgo")
+	}
+	db, err := Connect(testConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestConnect(t *testing.T) {
+	t.Run("unsupported driver", func(t *testing.T) {
+		if _, err := Connect(models.SQLDBConfig{Driver: "mysql", DSN: "test", Timeout: 10}); err == nil {
+			t.Error("error expected")
+		}
+	})
+
+	t.Run("SQLite without cgo", func(t *testing.T) {
+		if SQLiteAvailable {
+			t.Skip("built with cgo")
+		}
+		if _, err := Connect(testConfig()); err == nil {
+			t.Error("error expected")
+		}
+	})
+
+	t.Run("schema is created once", func(t *testing.T) {
+		db := newTestDB(t)
+		if err := migrateSchema(context.Background(), db, DriverSQLite); err != nil {
+			t.Errorf("migrations should be idempotent: %v", err)
+			return
+		}
+		version := 0
+		if err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if version != SchemaVersion() {
+			t.Errorf("unexpected schema version: %d", version)
+		}
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		db := newTestDB(t)
+		if _, err := db.Exec(`INSERT INTO schema_migrations (version, description) VALUES (?, 'future')`, SchemaVersion()+1); err != nil {
+			t.Fatal(err)
+		}
+		if err := migrateSchema(context.Background(), db, DriverSQLite); err == nil {
+			t.Error("error expected for unknown schema version")
+		}
+	})
+}
+
+func TestFillRefreshTokenFamilies(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	userDB := NewUserDBService(db, testConfig(), clock.Real)
+
+	u := models.User{Account: models.Account{Type: "email", AccountID: "fill-rt@test.com", RefreshTokens: []string{"legacy-rt"}}}
+	u.AddRefreshTokenFamily(models.NewRefreshTokenFamily("rt1", models.SessionInfo{}, 1), models.MaxRefreshTokens)
+	u.AddRefreshTokenFamily(models.NewRefreshTokenFamily("rt2", models.SessionInfo{}, 2), models.MaxRefreshTokens)
+	id, err := userDB.AddUser(ctx, "test-instance", u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as before the migration
+	if _, err := db.Exec(`DELETE FROM refresh_token_families`); err != nil {
+		t.Fatal(err)
+	}
+
+	c := conn{db: db, driver: DriverSQLite}
+	if err := c.inTx(ctx, func(tx *sql.Tx) error { return fillRefreshTokenFamilies(ctx, tx, c) }); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	for _, token := range []string{"rt1", "rt2"} {
+		if user, err := userDB.GetUserByRefreshToken(ctx, "test-instance", token); err != nil || user.ID.Hex() != id {
+			t.Errorf("user not found by %s: %v", token, err)
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	pg := conn{driver: DriverPostgres}
+	if q := pg.q(`SELECT a FROM t WHERE b = ? AND c IN (` + placeholders(2) + `)`); q != `SELECT a FROM t WHERE b = $1 AND c IN ($2, $3)` {
+		t.Errorf("unexpected query: %s", q)
+	}
+	lite := conn{driver: DriverSQLite}
+	if q := lite.q(`SELECT a FROM t WHERE b = ?`); q != `SELECT a FROM t WHERE b = ?` {
+		t.Errorf("unexpected query: %s", q)
+	}
+}
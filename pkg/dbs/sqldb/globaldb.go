@@ -0,0 +1,305 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GlobalDBService is the SQL implementation of globaldb.GlobalStore
+type GlobalDBService struct {
+	conn
+}
+
+var _ globaldb.GlobalStore = &GlobalDBService{}
+
+func NewGlobalDBService(db *sql.DB, configs models.SQLDBConfig) *GlobalDBService {
+	return &GlobalDBService{
+		conn: conn{db: db, driver: configs.Driver, timeout: configs.Timeout},
+	}
+}
+
+func (dbService *GlobalDBService) loadTempTokens(ctx context.Context, condition string, args ...interface{}) (models.TempTokens, error) {
+	rows, err := dbService.db.QueryContext(ctx,
+		dbService.q(`SELECT id, token, expiration, purpose, user_id, info, instance_id FROM temp_tokens WHERE `+condition),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := models.TempTokens{}
+	for rows.Next() {
+		var (
+			t        models.TempToken
+			id, info string
+		)
+		if err := rows.Scan(&id, &t.Token, &t.Expiration, &t.Purpose, &t.UserID, &info, &t.InstanceID); err != nil {
+			return tokens, err
+		}
+		t.ID, _ = primitive.ObjectIDFromHex(id)
+		if err := json.Unmarshal([]byte(info), &t.Info); err != nil {
+			return tokens, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+func (dbService *GlobalDBService) AddTempToken(ctx context.Context, t models.TempToken) (token string, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	t.Token, err = tokens.GenerateUniqueTokenString()
+	if err != nil {
+		return token, err
+	}
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+	info, err := json.Marshal(t.Info)
+	if err != nil {
+		return token, err
+	}
+	_, err = dbService.db.ExecContext(ctx,
+		dbService.q(`INSERT INTO temp_tokens (token, id, instance_id, user_id, purpose, expiration, info) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		t.Token, t.ID.Hex(), t.InstanceID, t.UserID, t.Purpose, t.Expiration, string(info),
+	)
+	if err != nil {
+		return token, err
+	}
+	return t.Token, nil
+}
+
+func (dbService *GlobalDBService) GetTempTokenForUser(ctx context.Context, instanceID string, uid string, purpose string) (tokens models.TempTokens, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	if len(purpose) > 0 {
+		return dbService.loadTempTokens(ctx, `instance_id = ? AND user_id = ? AND purpose = ?`, instanceID, uid, purpose)
+	}
+	return dbService.loadTempTokens(ctx, `instance_id = ? AND user_id = ?`, instanceID, uid)
+}
+
+func (dbService *GlobalDBService) GetTempToken(ctx context.Context, token string) (models.TempToken, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	tokens, err := dbService.loadTempTokens(ctx, `token = ?`, token)
+	if err != nil {
+		return models.TempToken{}, err
+	}
+	if len(tokens) < 1 {
+		return models.TempToken{}, mongo.ErrNoDocuments
+	}
+	return tokens[0], nil
+}
+
+func (dbService *GlobalDBService) DeleteTempToken(ctx context.Context, token string) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	res, err := dbService.db.ExecContext(ctx, dbService.q(`DELETE FROM temp_tokens WHERE token = ?`), token)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n < 1 {
+		return errors.New("document not found")
+	}
+	return nil
+}
+
+func (dbService *GlobalDBService) DeleteAllTempTokenForUser(ctx context.Context, instanceID string, userID string, purpose string) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM temp_tokens WHERE instance_id = ? AND user_id = ?`
+	args := []interface{}{instanceID, userID}
+	if len(purpose) > 0 {
+		query += ` AND purpose = ?`
+		args = append(args, purpose)
+	}
+	_, err := dbService.db.ExecContext(ctx, dbService.q(query), args...)
+	return err
+}
+
+func (dbService *GlobalDBService) DeleteTempTokensExpireBefore(ctx context.Context, instanceID string, purpose string, expiresBefore int64) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM temp_tokens WHERE expiration < ?`
+	args := []interface{}{expiresBefore}
+	if len(purpose) > 0 {
+		query += ` AND purpose = ?`
+		args = append(args, purpose)
+	}
+	if len(instanceID) > 0 {
+		query += ` AND instance_id = ?`
+		args = append(args, instanceID)
+	}
+	_, err := dbService.db.ExecContext(ctx, dbService.q(query), args...)
+	return err
+}
+
+func (dbService *GlobalDBService) FindAppToken(ctx context.Context, token string) (appTokenInfos models.AppToken, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	var id, instances, audiences string
+	err = dbService.db.QueryRowContext(ctx,
+		dbService.q(`SELECT id, app_name, instances, audiences FROM app_tokens
+			WHERE id = (SELECT app_token_id FROM app_token_values WHERE token = ? ORDER BY app_token_id LIMIT 1)`),
+		token,
+	).Scan(&id, &appTokenInfos.AppName, &instances, &audiences)
+	if err == sql.ErrNoRows {
+		return appTokenInfos, mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return appTokenInfos, err
+	}
+	appTokenInfos.ID, _ = primitive.ObjectIDFromHex(id)
+	if err := json.Unmarshal([]byte(instances), &appTokenInfos.Instances); err != nil {
+		return appTokenInfos, err
+	}
+	if err := json.Unmarshal([]byte(audiences), &appTokenInfos.Audiences); err != nil {
+		return appTokenInfos, err
+	}
+
+	rows, err := dbService.db.QueryContext(ctx,
+		dbService.q(`SELECT token FROM app_token_values WHERE app_token_id = ? ORDER BY position`), id,
+	)
+	if err != nil {
+		return appTokenInfos, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return appTokenInfos, err
+		}
+		appTokenInfos.Tokens = append(appTokenInfos.Tokens, t)
+	}
+	return appTokenInfos, rows.Err()
+}
+
+func (dbService *GlobalDBService) AddAppToken(ctx context.Context, appToken models.AppToken) (err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	if appToken.ID.IsZero() {
+		appToken.ID = primitive.NewObjectID()
+	}
+	instances, err := json.Marshal(appToken.Instances)
+	if err != nil {
+		return err
+	}
+	audiences := []byte("[]")
+	if len(appToken.Audiences) > 0 {
+		if audiences, err = json.Marshal(appToken.Audiences); err != nil {
+			return err
+		}
+	}
+	return dbService.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO app_tokens (id, app_name, instances, audiences) VALUES (?, ?, ?, ?)`),
+			appToken.ID.Hex(), appToken.AppName, string(instances), string(audiences),
+		); err != nil {
+			return err
+		}
+		for i, t := range appToken.Tokens {
+			if _, err := tx.ExecContext(ctx,
+				dbService.q(`INSERT INTO app_token_values (app_token_id, position, token) VALUES (?, ?, ?)`),
+				appToken.ID.Hex(), i, t,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dbService *GlobalDBService) AddRevokedToken(ctx context.Context, t models.RevokedToken) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+	_, err := dbService.db.ExecContext(ctx,
+		dbService.q(`INSERT INTO revoked_tokens (id, instance_id, user_id, token_id, issued_until, expiration) VALUES (?, ?, ?, ?, ?, ?)`),
+		t.ID.Hex(), t.InstanceID, t.UserID, t.TokenID, t.IssuedUntil, t.Expiration,
+	)
+	return err
+}
+
+func (dbService *GlobalDBService) IsTokenRevoked(ctx context.Context, instanceID string, userID string, tokenID string, issuedAt int64) (bool, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM revoked_tokens WHERE instance_id = ? AND (
+		(token_id = '' AND user_id = ? AND issued_until > ?)`
+	args := []interface{}{instanceID, userID, issuedAt}
+	if len(tokenID) > 0 {
+		query += ` OR token_id = ?`
+		args = append(args, tokenID)
+	}
+	query += `)`
+
+	var count int
+	if err := dbService.db.QueryRowContext(ctx, dbService.q(query), args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (dbService *GlobalDBService) DeleteRevokedTokensExpireBefore(ctx context.Context, expiresBefore int64) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	_, err := dbService.db.ExecContext(ctx, dbService.q(`DELETE FROM revoked_tokens WHERE expiration < ?`), expiresBefore)
+	return err
+}
+
+func (dbService *GlobalDBService) GetAllInstances(ctx context.Context) ([]global_types.Instance, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	rows, err := dbService.db.QueryContext(ctx, `SELECT instance_id FROM instances ORDER BY instance_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := []global_types.Instance{}
+	for rows.Next() {
+		var instance global_types.Instance
+		if err := rows.Scan(&instance.InstanceID); err != nil {
+			return instances, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, rows.Err()
+}
+
+// AddInstance registers an instance if it does not exist yet. Instances are managed outside of this service, so the
+// method is not part of globaldb.GlobalStore; it is used to register the instances listed in the config.
+func (dbService *GlobalDBService) AddInstance(ctx context.Context, instance global_types.Instance) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	_, err := dbService.db.ExecContext(ctx,
+		dbService.q(`INSERT INTO instances (instance_id) VALUES (?) ON CONFLICT DO NOTHING`), instance.InstanceID,
+	)
+	return err
+}
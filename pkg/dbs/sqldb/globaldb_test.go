@@ -0,0 +1,202 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGlobalDBTempTokens(t *testing.T) {
+	testDBService := NewGlobalDBService(newTestDB(t), testConfig())
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	testTempToken := models.TempToken{
+		UserID:     "test_user_id",
+		Purpose:    "test_purpose1",
+		InstanceID: testInstanceID,
+		Expiration: now + 10,
+		Info:       map[string]string{"type": "email"},
+	}
+	tokenStr := ""
+
+	t.Run("Add temporary token to DB", func(t *testing.T) {
+		ts, err := testDBService.AddTempToken(ctx, testTempToken)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		tokenStr = ts
+
+		testTempToken2 := testTempToken
+		testTempToken2.Purpose = "test_purpose2"
+		testTempToken2.Expiration = now - 10
+		if _, err = testDBService.AddTempToken(ctx, testTempToken2); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		testTempToken3 := testTempToken2
+		testTempToken3.InstanceID = "other-instance"
+		if _, err = testDBService.AddTempToken(ctx, testTempToken3); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("get temporary token by token string", func(t *testing.T) {
+		if _, err := testDBService.GetTempToken(ctx, tokenStr+"++"); err != mongo.ErrNoDocuments {
+			t.Errorf("token should not be found: %v", err)
+		}
+		tempToken, err := testDBService.GetTempToken(ctx, tokenStr)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if tempToken.ID.IsZero() || tempToken.UserID != testTempToken.UserID || tempToken.Purpose != testTempToken.Purpose ||
+			tempToken.Info["type"] != "email" || tempToken.Expiration != testTempToken.Expiration {
+			t.Errorf("temp token does not match: %v", tempToken)
+		}
+	})
+
+	t.Run("get temporary tokens of the user", func(t *testing.T) {
+		if tt, err := testDBService.GetTempTokenForUser(ctx, testInstanceID, "test_user_id", ""); err != nil || len(tt) != 2 {
+			t.Errorf("unexpected result: %v, %v", tt, err)
+		}
+		if tt, err := testDBService.GetTempTokenForUser(ctx, testInstanceID, "test_user_id", "test_purpose2"); err != nil || len(tt) != 1 {
+			t.Errorf("unexpected result: %v, %v", tt, err)
+		}
+	})
+
+	t.Run("delete expired tokens of an instance", func(t *testing.T) {
+		if err := testDBService.DeleteTempTokensExpireBefore(ctx, testInstanceID, "", now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if tt, _ := testDBService.GetTempTokenForUser(ctx, testInstanceID, "test_user_id", ""); len(tt) != 1 {
+			t.Errorf("unexpected tokens: %v", tt)
+		}
+		if tt, _ := testDBService.GetTempTokenForUser(ctx, "other-instance", "test_user_id", ""); len(tt) != 1 {
+			t.Errorf("token of other instance should be kept: %v", tt)
+		}
+	})
+
+	t.Run("delete token", func(t *testing.T) {
+		if err := testDBService.DeleteTempToken(ctx, tokenStr); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := testDBService.DeleteTempToken(ctx, tokenStr); err == nil {
+			t.Error("token should not be found")
+		}
+	})
+
+	t.Run("delete all tokens of the user", func(t *testing.T) {
+		if err := testDBService.DeleteAllTempTokenForUser(ctx, "other-instance", "test_user_id", ""); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if tt, _ := testDBService.GetTempTokenForUser(ctx, "other-instance", "test_user_id", ""); len(tt) != 0 {
+			t.Errorf("unexpected tokens: %v", tt)
+		}
+	})
+}
+
+func TestGlobalDBAppTokens(t *testing.T) {
+	testDBService := NewGlobalDBService(newTestDB(t), testConfig())
+	err := testDBService.AddAppToken(context.Background(), models.AppToken{
+		AppName:   "test",
+		Tokens:    []string{"t1", "t2"},
+		Instances: []string{testInstanceID},
+		Audiences: []string{"participant-app"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("find by any of its tokens", func(t *testing.T) {
+		at, err := testDBService.FindAppToken(context.Background(), "t2")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if at.AppName != "test" || len(at.Instances) != 1 || len(at.Tokens) != 2 || at.Tokens[0] != "t1" || len(at.Audiences) != 1 {
+			t.Errorf("unexpected app token: %v", at)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		if _, err := testDBService.FindAppToken(context.Background(), "t3"); err != mongo.ErrNoDocuments {
+			t.Errorf("app token should not be found: %v", err)
+		}
+	})
+}
+
+func TestGlobalDBRevokedTokens(t *testing.T) {
+	testDBService := NewGlobalDBService(newTestDB(t), testConfig())
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	entries := []models.RevokedToken{
+		{InstanceID: testInstanceID, UserID: "u1", TokenID: "jti1", Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u2", IssuedUntil: now, Expiration: now + 10},
+		{InstanceID: testInstanceID, UserID: "u3", IssuedUntil: now, Expiration: now - 10},
+	}
+	for _, e := range entries {
+		if err := testDBService.AddRevokedToken(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checks := []struct {
+		instanceID, userID, tokenID string
+		issuedAt                    int64
+		revoked                     bool
+	}{
+		{testInstanceID, "u1", "jti1", now, true},
+		{testInstanceID, "u1", "jti2", now, false},
+		{"other-instance", "u1", "jti1", now, false},
+		{testInstanceID, "u2", "jti3", now - 5, true},
+		{testInstanceID, "u2", "jti3", now - 1, true},
+		{testInstanceID, "u2", "jti3", now, false},
+		{testInstanceID, "u2", "", now - 1, true},
+		{testInstanceID, "u1", "", now, false},
+	}
+	for _, c := range checks {
+		revoked, err := testDBService.IsTokenRevoked(ctx, c.instanceID, c.userID, c.tokenID, c.issuedAt)
+		if err != nil || revoked != c.revoked {
+			t.Errorf("unexpected result for %v: %v, %v", c, revoked, err)
+		}
+	}
+
+	t.Run("delete expired entries", func(t *testing.T) {
+		if err := testDBService.DeleteRevokedTokensExpireBefore(ctx, now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u3", "", now-1); revoked {
+			t.Error("expired entry should be deleted")
+		}
+		if revoked, _ := testDBService.IsTokenRevoked(ctx, testInstanceID, "u2", "", now-1); !revoked {
+			t.Error("entry should be kept")
+		}
+	})
+}
+
+func TestGlobalDBInstances(t *testing.T) {
+	testDBService := NewGlobalDBService(newTestDB(t), testConfig())
+	instances, err := testDBService.GetAllInstances(context.Background())
+	if err != nil || len(instances) != 0 {
+		t.Errorf("unexpected result: %v, %v", instances, err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if err := testDBService.AddInstance(context.Background(), global_types.Instance{InstanceID: testInstanceID}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	instances, err = testDBService.GetAllInstances(context.Background())
+	if err != nil || len(instances) != 1 || instances[0].InstanceID != testInstanceID {
+		t.Errorf("unexpected result: %v, %v", instances, err)
+	}
+}
@@ -0,0 +1,240 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// schemaMigrations are applied in order, the number of applied migrations is the schema version. Applied migrations
+// must never be changed, add a new one instead. Statements must work for PostgreSQL and SQLite. migrate converts the
+// existing data after the statements ran, if the statements alone can't.
+var schemaMigrations = []struct {
+	description string
+	statements  []string
+	migrate     func(ctx context.Context, tx *sql.Tx, c conn) error
+}{
+	{
+		description: "initial schema",
+		statements: []string{
+			`CREATE TABLE users (
+				instance_id TEXT NOT NULL,
+				id TEXT NOT NULL,
+				account_id TEXT NOT NULL,
+				account_confirmed_at BIGINT NOT NULL DEFAULT 0,
+				created_at BIGINT NOT NULL DEFAULT 0,
+				reminder_to_confirm_sent_at BIGINT NOT NULL DEFAULT 0,
+				weekly_message_day INTEGER NOT NULL DEFAULT 0,
+				account TEXT NOT NULL,
+				timestamps TEXT NOT NULL,
+				contact_preferences TEXT NOT NULL,
+				revision BIGINT NOT NULL DEFAULT 0,
+				PRIMARY KEY (instance_id, id),
+				UNIQUE (instance_id, account_id)
+			)`,
+			`CREATE INDEX users_unverified ON users (instance_id, account_confirmed_at, created_at)`,
+			`CREATE TABLE user_roles (
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				role TEXT NOT NULL,
+				PRIMARY KEY (instance_id, user_id, position)
+			)`,
+			`CREATE INDEX user_roles_role ON user_roles (instance_id, role)`,
+			`CREATE TABLE profiles (
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				id TEXT NOT NULL,
+				alias TEXT NOT NULL DEFAULT '',
+				consent_confirmed_at BIGINT NOT NULL DEFAULT 0,
+				created_at BIGINT NOT NULL DEFAULT 0,
+				avatar_id TEXT NOT NULL DEFAULT '',
+				main_profile BOOLEAN NOT NULL DEFAULT FALSE,
+				PRIMARY KEY (instance_id, user_id, position)
+			)`,
+			`CREATE TABLE contact_infos (
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				id TEXT NOT NULL,
+				type TEXT NOT NULL DEFAULT '',
+				confirmed_at BIGINT NOT NULL DEFAULT 0,
+				confirmation_link_sent_at BIGINT NOT NULL DEFAULT 0,
+				email TEXT NOT NULL DEFAULT '',
+				phone TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (instance_id, user_id, position)
+			)`,
+			`CREATE TABLE user_schema_versions (
+				instance_id TEXT PRIMARY KEY,
+				version INTEGER NOT NULL
+			)`,
+			`CREATE TABLE temp_tokens (
+				token TEXT PRIMARY KEY,
+				id TEXT NOT NULL,
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				purpose TEXT NOT NULL,
+				expiration BIGINT NOT NULL,
+				info TEXT NOT NULL
+			)`,
+			`CREATE INDEX temp_tokens_user ON temp_tokens (instance_id, user_id, purpose)`,
+			`CREATE INDEX temp_tokens_expiration ON temp_tokens (expiration)`,
+			`CREATE TABLE app_tokens (
+				id TEXT PRIMARY KEY,
+				app_name TEXT NOT NULL,
+				instances TEXT NOT NULL
+			)`,
+			`CREATE TABLE app_token_values (
+				app_token_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				token TEXT NOT NULL,
+				PRIMARY KEY (app_token_id, position)
+			)`,
+			`CREATE INDEX app_token_values_token ON app_token_values (token)`,
+			`CREATE TABLE instances (
+				instance_id TEXT PRIMARY KEY
+			)`,
+		},
+	},
+	{
+		description: "revoked access tokens",
+		statements: []string{
+			`CREATE TABLE revoked_tokens (
+				id TEXT PRIMARY KEY,
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				token_id TEXT NOT NULL DEFAULT '',
+				issued_until BIGINT NOT NULL DEFAULT 0,
+				expiration BIGINT NOT NULL
+			)`,
+			`CREATE INDEX revoked_tokens_token ON revoked_tokens (instance_id, token_id)`,
+			`CREATE INDEX revoked_tokens_user ON revoked_tokens (instance_id, user_id)`,
+			`CREATE INDEX revoked_tokens_expiration ON revoked_tokens (expiration)`,
+		},
+	},
+	{
+		description: "app token audiences",
+		statements: []string{
+			`ALTER TABLE app_tokens ADD COLUMN audiences TEXT NOT NULL DEFAULT '[]'`,
+		},
+	},
+	{
+		description: "refresh token lookup",
+		statements: []string{
+			`CREATE TABLE refresh_token_families (
+				instance_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				token TEXT NOT NULL,
+				PRIMARY KEY (instance_id, user_id, position)
+			)`,
+			`CREATE INDEX refresh_token_families_token ON refresh_token_families (instance_id, token)`,
+		},
+		migrate: fillRefreshTokenFamilies,
+	},
+}
+
+// SchemaVersion is the version of the SQL schema created by this version of the service
+func SchemaVersion() int {
+	return len(schemaMigrations)
+}
+
+// migrateSchema applies the pending schema migrations in a single transaction. On PostgreSQL, concurrently starting
+// services wait for each other through an advisory lock.
+func migrateSchema(ctx context.Context, db *sql.DB, driver string) error {
+	c := conn{db: db, driver: driver}
+	return c.inTx(ctx, func(tx *sql.Tx) error {
+		if driver == DriverPostgres {
+			if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(4711)`); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL
+		)`); err != nil {
+			return err
+		}
+
+		current := 0
+		if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+			return err
+		}
+		if current > len(schemaMigrations) {
+			return fmt.Errorf("DB schema version %d is newer than the supported version %d", current, len(schemaMigrations))
+		}
+
+		for i := current; i < len(schemaMigrations); i++ {
+			m := schemaMigrations[i]
+			for _, stmt := range m.statements {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("schema migration %d (%s): %v", i+1, m.description, err)
+				}
+			}
+			if m.migrate != nil {
+				if err := m.migrate(ctx, tx, c); err != nil {
+					return fmt.Errorf("schema migration %d (%s): %v", i+1, m.description, err)
+				}
+			}
+			if _, err := tx.ExecContext(ctx, c.q(`INSERT INTO schema_migrations (version, description) VALUES (?, ?)`), i+1, m.description); err != nil {
+				return err
+			}
+			logger.Info.Printf("applied SQL schema migration %d: %s", i+1, m.description)
+		}
+		return nil
+	})
+}
+
+// fillRefreshTokenFamilies copies the current tokens of the refresh token families from the accounts, which are stored
+// as JSON, to the refresh_token_families table
+func fillRefreshTokenFamilies(ctx context.Context, tx *sql.Tx, c conn) error {
+	type userTokens struct {
+		instanceID, userID string
+		families           []models.RefreshTokenFamily
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT instance_id, id, account FROM users`)
+	if err != nil {
+		return err
+	}
+	users := []userTokens{}
+	for rows.Next() {
+		var (
+			u       userTokens
+			account string
+		)
+		if err := rows.Scan(&u.instanceID, &u.userID, &account); err != nil {
+			rows.Close()
+			return err
+		}
+		a := models.Account{}
+		if err := json.Unmarshal([]byte(account), &a); err != nil {
+			rows.Close()
+			return fmt.Errorf("user %s: %v", u.userID, err)
+		}
+		if len(a.RefreshTokenFamilies) > 0 {
+			u.families = a.RefreshTokenFamilies
+			users = append(users, u)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		for i, f := range u.families {
+			if _, err := tx.ExecContext(ctx,
+				c.q(`INSERT INTO refresh_token_families (instance_id, user_id, position, token) VALUES (?, ?, ?, ?)`),
+				u.instanceID, u.userID, i, f.Token,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
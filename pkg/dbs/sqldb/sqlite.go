@@ -0,0 +1,12 @@
+//go:build cgo
+// +build cgo
+
+package sqldb
+
+import (
+	// the SQLite driver needs cgo
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteAvailable is false if the binary was built without cgo (e.g. the docker image), SQLite cannot be used then
+const SQLiteAvailable = true
@@ -0,0 +1,7 @@
+//go:build !cgo
+// +build !cgo
+
+package sqldb
+
+// SQLiteAvailable is false if the binary was built without cgo (e.g. the docker image), SQLite cannot be used then
+const SQLiteAvailable = false
@@ -0,0 +1,740 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// pageSize is the number of users loaded at once when iterating over users
+	pageSize = 100
+	// maxFieldUpdateRetries limits how often a field update is repeated if the user was modified concurrently
+	maxFieldUpdateRetries = 10
+)
+
+// UserDBService is the SQL implementation of userdb.UserStore. Users are stored in the users table, with their
+// roles, profiles and contact infos in separate tables. The remaining account data, timestamps and contact preferences
+// are stored as JSON, the columns used in filters are kept next to them.
+type UserDBService struct {
+	conn
+	clock clock.Clock
+}
+
+var _ userdb.UserStore = &UserDBService{}
+
+func NewUserDBService(db *sql.DB, configs models.SQLDBConfig, clk clock.Clock) *UserDBService {
+	return &UserDBService{
+		conn:  conn{db: db, driver: configs.Driver, timeout: configs.Timeout},
+		clock: clk,
+	}
+}
+
+// loadUsers returns the users of the instance matching the condition (which can end with ORDER BY and LIMIT),
+// including their roles, profiles and contact infos
+func (dbService *UserDBService) loadUsers(ctx context.Context, q queryer, instanceID string, condition string, args ...interface{}) ([]models.User, error) {
+	users, err := dbService.loadUserRows(ctx, q, instanceID, condition, args...)
+	if err != nil || len(users) == 0 {
+		return users, err
+	}
+
+	byID := map[string]*models.User{}
+	ids := make([]interface{}, len(users))
+	for i := range users {
+		byID[users[i].ID.Hex()] = &users[i]
+		ids[i] = users[i].ID.Hex()
+	}
+	if err := dbService.loadRoles(ctx, q, instanceID, ids, byID); err != nil {
+		return users, err
+	}
+	if err := dbService.loadProfiles(ctx, q, instanceID, ids, byID); err != nil {
+		return users, err
+	}
+	if err := dbService.loadContactInfos(ctx, q, instanceID, ids, byID); err != nil {
+		return users, err
+	}
+	return users, nil
+}
+
+func (dbService *UserDBService) loadUserRows(ctx context.Context, q queryer, instanceID string, condition string, args ...interface{}) ([]models.User, error) {
+	rows, err := q.QueryContext(ctx,
+		dbService.q(`SELECT id, account, timestamps, contact_preferences, revision FROM users WHERE instance_id = ? AND `+condition),
+		append([]interface{}{instanceID}, args...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var (
+			u                             models.User
+			id, account, timestamps, pref string
+		)
+		if err := rows.Scan(&id, &account, &timestamps, &pref, &u.Revision); err != nil {
+			return users, err
+		}
+		if u.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return users, err
+		}
+		if err := json.Unmarshal([]byte(account), &u.Account); err != nil {
+			return users, err
+		}
+		if err := json.Unmarshal([]byte(timestamps), &u.Timestamps); err != nil {
+			return users, err
+		}
+		if err := json.Unmarshal([]byte(pref), &u.ContactPreferences); err != nil {
+			return users, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (dbService *UserDBService) loadRoles(ctx context.Context, q queryer, instanceID string, ids []interface{}, byID map[string]*models.User) error {
+	rows, err := q.QueryContext(ctx,
+		dbService.q(`SELECT user_id, role FROM user_roles WHERE instance_id = ? AND user_id IN (`+placeholders(len(ids))+`) ORDER BY user_id, position`),
+		append([]interface{}{instanceID}, ids...)...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, role string
+		if err := rows.Scan(&userID, &role); err != nil {
+			return err
+		}
+		u := byID[userID]
+		u.Roles = append(u.Roles, role)
+	}
+	return rows.Err()
+}
+
+func (dbService *UserDBService) loadProfiles(ctx context.Context, q queryer, instanceID string, ids []interface{}, byID map[string]*models.User) error {
+	rows, err := q.QueryContext(ctx,
+		dbService.q(`SELECT user_id, id, alias, consent_confirmed_at, created_at, avatar_id, main_profile FROM profiles
+			WHERE instance_id = ? AND user_id IN (`+placeholders(len(ids))+`) ORDER BY user_id, position`),
+		append([]interface{}{instanceID}, ids...)...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID, id string
+			p          models.Profile
+		)
+		if err := rows.Scan(&userID, &id, &p.Alias, &p.ConsentConfirmedAt, &p.CreatedAt, &p.AvatarID, &p.MainProfile); err != nil {
+			return err
+		}
+		p.ID, _ = primitive.ObjectIDFromHex(id)
+		u := byID[userID]
+		u.Profiles = append(u.Profiles, p)
+	}
+	return rows.Err()
+}
+
+func (dbService *UserDBService) loadContactInfos(ctx context.Context, q queryer, instanceID string, ids []interface{}, byID map[string]*models.User) error {
+	rows, err := q.QueryContext(ctx,
+		dbService.q(`SELECT user_id, id, type, confirmed_at, confirmation_link_sent_at, email, phone FROM contact_infos
+			WHERE instance_id = ? AND user_id IN (`+placeholders(len(ids))+`) ORDER BY user_id, position`),
+		append([]interface{}{instanceID}, ids...)...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID, id string
+			ci         models.ContactInfo
+		)
+		if err := rows.Scan(&userID, &id, &ci.Type, &ci.ConfirmedAt, &ci.ConfirmationLinkSentAt, &ci.Email, &ci.Phone); err != nil {
+			return err
+		}
+		ci.ID, _ = primitive.ObjectIDFromHex(id)
+		u := byID[userID]
+		u.ContactInfos = append(u.ContactInfos, ci)
+	}
+	return rows.Err()
+}
+
+// getUser loads a single user, mongo.ErrNoDocuments if it does not exist
+func (dbService *UserDBService) getUser(ctx context.Context, instanceID string, condition string, args ...interface{}) (models.User, error) {
+	users, err := dbService.loadUsers(ctx, dbService.db, instanceID, condition+" LIMIT 1", args...)
+	if err != nil {
+		return models.User{}, err
+	}
+	if len(users) < 1 {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	return users[0], nil
+}
+
+// userColumns returns the values of the users table columns, except instance_id and id
+func userColumns(u models.User) ([]interface{}, error) {
+	account, err := json.Marshal(u.Account)
+	if err != nil {
+		return nil, err
+	}
+	timestamps, err := json.Marshal(u.Timestamps)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := json.Marshal(u.ContactPreferences)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		u.Account.AccountID,
+		u.Account.AccountConfirmedAt,
+		u.Timestamps.CreatedAt,
+		u.Timestamps.ReminderToConfirmSentAt,
+		u.ContactPreferences.ReceiveWeeklyMessageDayOfWeek,
+		string(account),
+		string(timestamps),
+		string(prefs),
+		u.Revision,
+	}, nil
+}
+
+// writeChildren replaces the roles, profiles, contact infos and refresh token lookup entries of the user
+func (dbService *UserDBService) writeChildren(ctx context.Context, tx *sql.Tx, instanceID string, u models.User) error {
+	if err := dbService.deleteChildren(ctx, tx, instanceID, "user_id = ?", u.ID.Hex()); err != nil {
+		return err
+	}
+	for i, role := range u.Roles {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO user_roles (instance_id, user_id, position, role) VALUES (?, ?, ?, ?)`),
+			instanceID, u.ID.Hex(), i, role,
+		); err != nil {
+			return err
+		}
+	}
+	for i, p := range u.Profiles {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO profiles (instance_id, user_id, position, id, alias, consent_confirmed_at, created_at, avatar_id, main_profile)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			instanceID, u.ID.Hex(), i, p.ID.Hex(), p.Alias, p.ConsentConfirmedAt, p.CreatedAt, p.AvatarID, p.MainProfile,
+		); err != nil {
+			return err
+		}
+	}
+	for i, ci := range u.ContactInfos {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO contact_infos (instance_id, user_id, position, id, type, confirmed_at, confirmation_link_sent_at, email, phone)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			instanceID, u.ID.Hex(), i, ci.ID.Hex(), ci.Type, ci.ConfirmedAt, ci.ConfirmationLinkSentAt, ci.Email, ci.Phone,
+		); err != nil {
+			return err
+		}
+	}
+	for i, f := range u.Account.RefreshTokenFamilies {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO refresh_token_families (instance_id, user_id, position, token) VALUES (?, ?, ?, ?)`),
+			instanceID, u.ID.Hex(), i, f.Token,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteChildren removes the rows of the users matching the condition on user_id from the child tables
+func (dbService *UserDBService) deleteChildren(ctx context.Context, tx *sql.Tx, instanceID string, condition string, args ...interface{}) error {
+	for _, table := range []string{"user_roles", "profiles", "contact_infos", "refresh_token_families"} {
+		if _, err := tx.ExecContext(ctx,
+			dbService.q(`DELETE FROM `+table+` WHERE instance_id = ? AND `+condition),
+			append([]interface{}{instanceID}, args...)...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceUser stores the user if the stored revision is still readRevision, returns false otherwise
+func (dbService *UserDBService) replaceUser(ctx context.Context, instanceID string, u models.User, readRevision int64) (replaced bool, err error) {
+	cols, err := userColumns(u)
+	if err != nil {
+		return false, err
+	}
+	err = dbService.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			dbService.q(`UPDATE users SET account_id = ?, account_confirmed_at = ?, created_at = ?, reminder_to_confirm_sent_at = ?,
+				weekly_message_day = ?, account = ?, timestamps = ?, contact_preferences = ?, revision = ?
+				WHERE instance_id = ? AND id = ? AND revision = ?`),
+			append(cols, instanceID, u.ID.Hex(), readRevision)...,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n < 1 {
+			return err
+		}
+		replaced = true
+		return dbService.writeChildren(ctx, tx, instanceID, u)
+	})
+	return replaced, err
+}
+
+// updateUser applies the update function to the stored user and increments its revision. If the user is modified
+// concurrently, it is reloaded and the update is applied again. The updated user is returned, mongo.ErrNoDocuments if
+// the user does not exist.
+func (dbService *UserDBService) updateUser(ctx context.Context, instanceID string, id primitive.ObjectID, update func(u *models.User) error) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		u, err := dbService.getUser(ctx, instanceID, "id = ?", id.Hex())
+		if err != nil {
+			return u, err
+		}
+		if err := update(&u); err != nil {
+			return u, err
+		}
+		readRevision := u.Revision
+		u.Revision += 1
+		replaced, err := dbService.replaceUser(ctx, instanceID, u, readRevision)
+		if err != nil {
+			return u, err
+		}
+		if replaced {
+			return u, nil
+		}
+		if attempt >= maxFieldUpdateRetries {
+			return u, &userdb.RevisionConflictError{UserID: id.Hex(), Revision: readRevision}
+		}
+	}
+}
+
+// setFields is used for methods that perform an UpdateOne in the MongoDB implementation: a missing user is not an error there.
+func (dbService *UserDBService) setFields(ctx context.Context, instanceID string, userID string, update func(u *models.User)) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		update(u)
+		return nil
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+func (dbService *UserDBService) AddUser(ctx context.Context, instanceID string, user models.User) (id string, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	cols, err := userColumns(user)
+	if err != nil {
+		return "", err
+	}
+	err = dbService.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			dbService.q(`INSERT INTO users (instance_id, id, account_id, account_confirmed_at, created_at, reminder_to_confirm_sent_at,
+				weekly_message_day, account, timestamps, contact_preferences, revision)
+				VALUES (?, ?, `+placeholders(len(cols))+`) ON CONFLICT DO NOTHING`),
+			append([]interface{}{instanceID, user.ID.Hex()}, cols...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n < 1 {
+			return errors.New("user already exists")
+		}
+		return dbService.writeChildren(ctx, tx, instanceID, user)
+	})
+	if err != nil {
+		return "", err
+	}
+	return user.ID.Hex(), nil
+}
+
+func (dbService *UserDBService) UpdateUser(ctx context.Context, instanceID string, updatedUser models.User) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	// Set last update time
+	updatedUser.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+	readRevision := updatedUser.Revision
+	updatedUser.Revision += 1
+
+	replaced, err := dbService.replaceUser(ctx, instanceID, updatedUser, readRevision)
+	if err != nil {
+		return models.User{}, err
+	}
+	if !replaced {
+		if _, err := dbService.getUser(ctx, instanceID, "id = ?", updatedUser.ID.Hex()); err != nil {
+			return models.User{}, err
+		}
+		return models.User{}, &userdb.RevisionConflictError{UserID: updatedUser.ID.Hex(), Revision: readRevision}
+	}
+	return updatedUser, nil
+}
+
+func (dbService *UserDBService) GetUserByID(ctx context.Context, instanceID string, id string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	return dbService.getUser(ctx, instanceID, "id = ?", id)
+}
+
+func (dbService *UserDBService) GetUserByAccountID(ctx context.Context, instanceID string, username string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	return dbService.getUser(ctx, instanceID, "account_id = ?", username)
+}
+
+// GetUserByRefreshToken finds the user through the refresh_token_families table, which holds the current token of
+// each family
+func (dbService *UserDBService) GetUserByRefreshToken(ctx context.Context, instanceID string, token string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	if token == "" {
+		return models.User{}, mongo.ErrNoDocuments
+	}
+	return dbService.getUser(ctx, instanceID,
+		"id IN (SELECT user_id FROM refresh_token_families WHERE instance_id = ? AND token = ?)", instanceID, token)
+}
+
+func (dbService *UserDBService) UpdateUserPassword(ctx context.Context, instanceID string, userID string, newPassword string) error {
+	return dbService.setFields(ctx, instanceID, userID, func(u *models.User) {
+		u.Account.Password = newPassword
+		u.Timestamps.LastPasswordChange = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) SaveFailedLoginAttempt(ctx context.Context, instanceID string, userID string) error {
+	return dbService.setFields(ctx, instanceID, userID, func(u *models.User) {
+		u.Account.FailedLoginAttempts = append(u.Account.FailedLoginAttempts, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) SavePasswordResetTrigger(ctx context.Context, instanceID string, userID string) error {
+	return dbService.setFields(ctx, instanceID, userID, func(u *models.User) {
+		u.Account.PasswordResetTriggers = append(u.Account.PasswordResetTriggers, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) UpdateAccountPreferredLang(ctx context.Context, instanceID string, userID string, lang string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.Account.PreferredLanguage = lang
+		u.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+		return nil
+	})
+}
+
+func (dbService *UserDBService) UpdateContactPreferences(ctx context.Context, instanceID string, userID string, prefs models.ContactPreferences) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.ContactPreferences = prefs
+		u.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+		return nil
+	})
+}
+
+func (dbService *UserDBService) UpdateLoginTime(ctx context.Context, instanceID string, id string) error {
+	return dbService.setFields(ctx, instanceID, id, func(u *models.User) {
+		u.Timestamps.LastLogin = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) UpdateReminderToConfirmSentAtTime(ctx context.Context, instanceID string, id string) error {
+	return dbService.setFields(ctx, instanceID, id, func(u *models.User) {
+		u.Timestamps.ReminderToConfirmSentAt = dbService.clock.Now().Unix()
+	})
+}
+
+func (dbService *UserDBService) AddRefreshTokenFamily(ctx context.Context, instanceID string, userID string, family models.RefreshTokenFamily, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.AddRefreshTokenFamily(family, maxFamilies)
+		return nil
+	})
+}
+
+// RenewRefreshToken replaces oldToken by newToken. Returns mongo.ErrNoDocuments if oldToken is not stored (anymore).
+func (dbService *UserDBService) RenewRefreshToken(ctx context.Context, instanceID string, userID string, oldToken string, rotatedToken string, newToken string, session models.SessionInfo, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		now := dbService.clock.Now().Unix()
+		if err := u.RenewRefreshToken(oldToken, rotatedToken, newToken, session, now, maxFamilies); err != nil {
+			return mongo.ErrNoDocuments
+		}
+		u.Timestamps.LastTokenRefresh = now
+		return nil
+	})
+}
+
+func (dbService *UserDBService) RemoveAllRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.RemoveAllRefreshTokens()
+		return nil
+	})
+	return err
+}
+
+func (dbService *UserDBService) RemoveLegacyRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.RemoveLegacyRefreshTokens()
+		return nil
+	})
+	return err
+}
+
+func (dbService *UserDBService) RemoveRefreshTokenFamily(ctx context.Context, instanceID string, userID string, familyID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	_, err := dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		return u.RemoveRefreshTokenFamily(familyID)
+	})
+	return err
+}
+
+func (dbService *UserDBService) AddRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		return u.AddRole(role)
+	})
+}
+
+func (dbService *UserDBService) RemoveRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		return u.RemoveRole(role)
+	})
+}
+
+func (dbService *UserDBService) AddContactInfo(ctx context.Context, instanceID string, userID string, contactInfo models.ContactInfo) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		u.ContactInfos = append(u.ContactInfos, contactInfo)
+		return nil
+	})
+}
+
+// RemoveContactInfo removes the contact info and all references to it from the contact preferences. The address
+// used as account ID of email accounts cannot be removed.
+func (dbService *UserDBService) RemoveContactInfo(ctx context.Context, instanceID string, userID string, contactInfoID string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	return dbService.updateUser(ctx, instanceID, _id, func(u *models.User) error {
+		ci, found := u.FindContactInfoById(contactInfoID)
+		if !found {
+			return errors.New("contact not found")
+		}
+		if u.Account.Type == models.ACCOUNT_TYPE_EMAIL && ci.Email == u.Account.AccountID {
+			return errors.New("cannot remove main address")
+		}
+		if err := u.RemoveContactInfo(contactInfoID); err != nil {
+			return err
+		}
+		u.RemoveContactInfoFromContactPreferences(contactInfoID)
+		return nil
+	})
+}
+
+func (dbService *UserDBService) SetContactInfoVerificationSent(ctx context.Context, instanceID string, userID string, t string, addr string) error {
+	return dbService.setFields(ctx, instanceID, userID, func(u *models.User) {
+		u.SetContactInfoVerificationSent(t, addr, dbService.clock.Now().Unix())
+	})
+}
+
+func (dbService *UserDBService) CountRecentlyCreatedUsers(ctx context.Context, instanceID string, interval int64) (count int64, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	err = dbService.db.QueryRowContext(ctx,
+		dbService.q(`SELECT COUNT(*) FROM users WHERE instance_id = ? AND created_at > ?`),
+		instanceID, dbService.clock.Now().Unix()-interval,
+	).Scan(&count)
+	return
+}
+
+func (dbService *UserDBService) DeleteUser(ctx context.Context, instanceID string, id string) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	return dbService.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, dbService.q(`DELETE FROM users WHERE instance_id = ? AND id = ?`), instanceID, id)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n < 1 {
+			return errors.New("no user found with the given id")
+		}
+		return dbService.deleteChildren(ctx, tx, instanceID, "user_id = ?", id)
+	})
+}
+
+func (dbService *UserDBService) DeleteUnverfiedUsers(ctx context.Context, instanceID string, createdBefore int64) (count int64, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	err = dbService.inTx(ctx, func(tx *sql.Tx) error {
+		if err := dbService.deleteChildren(ctx, tx, instanceID,
+			"user_id IN (SELECT id FROM users WHERE instance_id = ? AND account_confirmed_at = 0 AND created_at < ?)",
+			instanceID, createdBefore,
+		); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx,
+			dbService.q(`DELETE FROM users WHERE instance_id = ? AND account_confirmed_at = 0 AND created_at < ?`),
+			instanceID, createdBefore,
+		)
+		if err != nil {
+			return err
+		}
+		count, err = res.RowsAffected()
+		return err
+	})
+	return count, err
+}
+
+func (dbService *UserDBService) FindNonParticipantUsers(ctx context.Context, instanceID string) (users []models.User, err error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	return dbService.loadUsers(ctx, dbService.db, instanceID,
+		"id IN (SELECT user_id FROM user_roles WHERE instance_id = ? AND role IN (?, ?, ?)) ORDER BY id",
+		instanceID, constants.USER_ROLE_SERVICE_ACCOUNT, constants.USER_ROLE_RESEARCHER, constants.USER_ROLE_ADMIN,
+	)
+}
+
+// forEachUser calls cbk for each user matching the conditions. Users are loaded page by page and the callback is
+// only called while no query is running, so it can use the DB itself.
+func (dbService *UserDBService) forEachUser(
+	ctx context.Context,
+	instanceID string,
+	conditions []string,
+	args []interface{},
+	cbk func(user models.User) error,
+) error {
+	lastID := ""
+	for {
+		pageCtx, cancel := dbService.getContext(ctx)
+		users, err := dbService.loadUsers(pageCtx, dbService.db, instanceID,
+			strings.Join(append(conditions, "id > ?"), " AND ")+" ORDER BY id LIMIT ?",
+			append(args, lastID, pageSize)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			if ctx.Err() != nil {
+				logger.Debug.Println(ctx.Err())
+				return ctx.Err()
+			}
+			if err := cbk(user); err != nil {
+				return err
+			}
+		}
+		if len(users) < pageSize {
+			return nil
+		}
+		lastID = users[len(users)-1].ID.Hex()
+	}
+}
+
+func (dbService *UserDBService) PerfomActionForUsers(
+	ctx context.Context,
+	instanceID string,
+	filters userdb.UserFilter,
+	cbk func(instanceID string, user models.User, args ...interface{}) error,
+	args ...interface{},
+) (err error) {
+	conditions := []string{}
+	condArgs := []interface{}{}
+	if filters.OnlyConfirmed {
+		conditions = append(conditions, "account_confirmed_at > 0")
+	}
+	if filters.ReminderWeekDay > -1 {
+		conditions = append(conditions, "weekly_message_day = ?")
+		condArgs = append(condArgs, filters.ReminderWeekDay)
+	}
+
+	return dbService.forEachUser(ctx, instanceID, conditions, condArgs, func(user models.User) error {
+		if err := cbk(instanceID, user, args...); err != nil {
+			logger.Debug.Printf("error in callback: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (dbService *UserDBService) SendReminderToConfirmAccountLoop(
+	ctx context.Context,
+	instanceID string,
+	createdBefore int64,
+	cbk func(instanceID string, user models.User, args ...interface{}) error,
+	args ...interface{},
+) (err error) {
+	return dbService.forEachUser(ctx, instanceID,
+		[]string{"account_confirmed_at < 1", "reminder_to_confirm_sent_at < 1", "created_at < ?"},
+		[]interface{}{createdBefore},
+		func(user models.User) error {
+			if err := cbk(instanceID, user, args...); err != nil {
+				logger.Debug.Printf("error in callback: %v", err)
+				return nil
+			}
+			if err := dbService.UpdateReminderToConfirmSentAtTime(ctx, instanceID, user.ID.Hex()); err != nil {
+				logger.Error.Printf("unexpected error: %v", err)
+			}
+			return nil
+		},
+	)
+}
+
+func (dbService *UserDBService) GetSchemaVersion(ctx context.Context, instanceID string) (int, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	version := 0
+	err := dbService.db.QueryRowContext(ctx,
+		dbService.q(`SELECT version FROM user_schema_versions WHERE instance_id = ?`), instanceID,
+	).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (dbService *UserDBService) SetSchemaVersion(ctx context.Context, instanceID string, version int) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	_, err := dbService.db.ExecContext(ctx,
+		dbService.q(`INSERT INTO user_schema_versions (instance_id, version) VALUES (?, ?)
+			ON CONFLICT (instance_id) DO UPDATE SET version = excluded.version`),
+		instanceID, version,
+	)
+	return err
+}
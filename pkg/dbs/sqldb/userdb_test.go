@@ -0,0 +1,450 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const testInstanceID = "test-instance"
+
+func newTestUserDBService(t *testing.T, clk clock.Clock) *UserDBService {
+	return NewUserDBService(newTestDB(t), testConfig(), clk)
+}
+
+func TestUserDBInterfaceMethods(t *testing.T) {
+	testDBService := newTestUserDBService(t, clock.Real)
+	ctx := context.Background()
+
+	testUser := models.User{
+		Account: models.Account{
+			Type:          "email",
+			AccountID:     "test@test.com",
+			Password:      "testhashedpassword-youcantreadme",
+			RefreshTokens: []string{"rt1"},
+		},
+		Roles: []string{"TEST"},
+		Profiles: []models.Profile{
+			{ID: primitive.NewObjectID(), Alias: "main", MainProfile: true, AvatarID: "a1"},
+			{ID: primitive.NewObjectID(), Alias: "second"},
+		},
+		ContactPreferences: models.ContactPreferences{ReceiveWeeklyMessageDayOfWeek: 3},
+		Timestamps: models.Timestamps{
+			CreatedAt: time.Now().Unix(),
+		},
+	}
+	testUser.AddNewEmail("test@test.com", false, 0)
+
+	t.Run("Testing create user", func(t *testing.T) {
+		id, err := testDBService.AddUser(ctx, testInstanceID, testUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(id) == 0 {
+			t.Errorf("id is missing")
+			return
+		}
+		testUser.ID, _ = primitive.ObjectIDFromHex(id)
+	})
+
+	t.Run("Testing creating existing user", func(t *testing.T) {
+		testUser2 := testUser
+		testUser2.ID = primitive.NilObjectID
+		testUser2.Roles = []string{"TEST2"}
+		if _, err := testDBService.AddUser(ctx, testInstanceID, testUser2); err == nil {
+			t.Errorf("user already existed, but created again")
+			return
+		}
+		u, err := testDBService.GetUserByAccountID(ctx, testInstanceID, testUser2.Account.AccountID)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(u.Roles) != 1 || u.Roles[0] != "TEST" {
+			t.Errorf("user should not be updated: %v", u.Roles)
+		}
+	})
+
+	t.Run("Testing same account id in other instance", func(t *testing.T) {
+		if _, err := testDBService.AddUser(ctx, "other-instance", testUser); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing find existing user by id", func(t *testing.T) {
+		user, err := testDBService.GetUserByID(ctx, testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user.Account.AccountID != testUser.Account.AccountID || user.Account.Password != testUser.Account.Password {
+			t.Errorf("found user is not matching test user: %v", user.Account)
+		}
+		if len(user.Profiles) != 2 || user.Profiles[0] != testUser.Profiles[0] || user.Profiles[1] != testUser.Profiles[1] {
+			t.Errorf("unexpected profiles: %v", user.Profiles)
+		}
+		if len(user.ContactInfos) != 1 || user.ContactInfos[0] != testUser.ContactInfos[0] {
+			t.Errorf("unexpected contact infos: %v", user.ContactInfos)
+		}
+		if user.ContactPreferences.ReceiveWeeklyMessageDayOfWeek != 3 || len(user.Account.RefreshTokens) != 1 {
+			t.Errorf("found user is not matching test user: %v", user)
+		}
+	})
+
+	t.Run("Testing find not existing user", func(t *testing.T) {
+		if _, err := testDBService.GetUserByID(ctx, testInstanceID, primitive.NewObjectID().Hex()); err != mongo.ErrNoDocuments {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testDBService.GetUserByAccountID(ctx, testInstanceID, "unknown@test.com"); err != mongo.ErrNoDocuments {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing update user", func(t *testing.T) {
+		user, err := testDBService.GetUserByID(ctx, testInstanceID, testUser.ID.Hex())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user.Account.AccountConfirmedAt = time.Now().Unix()
+		user.Roles = append(user.Roles, "TEST3")
+		user.Profiles = user.Profiles[:1]
+		updated, err := testDBService.UpdateUser(ctx, testInstanceID, user)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if updated.Revision != user.Revision+1 {
+			t.Errorf("revision not incremented: %d", updated.Revision)
+		}
+		stored, _ := testDBService.GetUserByID(ctx, testInstanceID, testUser.ID.Hex())
+		if stored.Account.AccountConfirmedAt != user.Account.AccountConfirmedAt || len(stored.Roles) != 2 || len(stored.Profiles) != 1 {
+			t.Errorf("user not updated: %v", stored)
+		}
+
+		// the revision read before is outdated now
+		_, err = testDBService.UpdateUser(ctx, testInstanceID, user)
+		var conflict *userdb.RevisionConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("revision conflict expected: %v", err)
+		}
+	})
+
+	t.Run("Testing update not existing user", func(t *testing.T) {
+		_, err := testDBService.UpdateUser(ctx, testInstanceID, models.User{ID: primitive.NewObjectID()})
+		if err != mongo.ErrNoDocuments {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing field updates", func(t *testing.T) {
+		id := testUser.ID.Hex()
+		if err := testDBService.UpdateUserPassword(ctx, testInstanceID, id, "new-password"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := testDBService.SaveFailedLoginAttempt(ctx, testInstanceID, id); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testDBService.AddRole(ctx, testInstanceID, id, "ADMIN"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testDBService.RenewRefreshToken(ctx, testInstanceID, id, "rt1", "rt1", "rt2", models.SessionInfo{}, models.MaxRefreshTokens); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testDBService.RenewRefreshToken(ctx, testInstanceID, id, "rt1", "rt1", "rt3", models.SessionInfo{}, models.MaxRefreshTokens); err != mongo.ErrNoDocuments {
+			t.Errorf("renewing a removed token should fail: %v", err)
+		}
+		if err := testDBService.UpdateLoginTime(ctx, testInstanceID, primitive.NewObjectID().Hex()); err != nil {
+			t.Errorf("missing user should be ignored: %v", err)
+		}
+
+		user, err := testDBService.GetUserByID(ctx, testInstanceID, id)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user.Account.Password != "new-password" || len(user.Account.FailedLoginAttempts) != 1 || !user.HasRole("ADMIN") ||
+			!user.HasRefreshToken("rt2") || user.HasRefreshToken("rt1") {
+			t.Errorf("unexpected user: %v", user)
+		}
+		if user, err := testDBService.GetUserByRefreshToken(ctx, testInstanceID, "rt2"); err != nil || user.ID.Hex() != id {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(ctx, testInstanceID, "rt1"); err != mongo.ErrNoDocuments {
+			t.Errorf("replaced refresh token should not be found: %v", err)
+		}
+	})
+
+	t.Run("Testing removal of legacy refresh tokens", func(t *testing.T) {
+		legacyUser := models.User{Account: models.Account{Type: "email", AccountID: "legacy-rt-removal@test.com", RefreshTokens: []string{"legacy-rt"}}}
+		legacyUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily("family-rt", models.SessionInfo{}, 1), models.MaxRefreshTokens)
+		id, err := testDBService.AddUser(ctx, testInstanceID, legacyUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user, err := testDBService.GetUserByRefreshToken(ctx, testInstanceID, "family-rt"); err != nil || user.ID.Hex() != id {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(ctx, testInstanceID, "legacy-rt"); err != mongo.ErrNoDocuments {
+			t.Errorf("legacy refresh token should not be looked up: %v", err)
+		}
+		if err := testDBService.RemoveLegacyRefreshTokens(ctx, testInstanceID, id); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, err := testDBService.GetUserByID(ctx, testInstanceID, id)
+		if err != nil || user.HasRefreshToken("legacy-rt") || !user.HasRefreshToken("family-rt") {
+			t.Errorf("unexpected refresh tokens: %v, %v", user.Account, err)
+		}
+	})
+
+	t.Run("Testing contact infos", func(t *testing.T) {
+		id := testUser.ID.Hex()
+		ci := models.NewEmailContactInfo("second@test.com", false, 0)
+		user, err := testDBService.AddContactInfo(ctx, testInstanceID, id, ci)
+		if err != nil || len(user.ContactInfos) != 2 {
+			t.Errorf("unexpected result: %v, %v", user.ContactInfos, err)
+			return
+		}
+		if err := testDBService.SetContactInfoVerificationSent(ctx, testInstanceID, id, "email", "second@test.com"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testDBService.RemoveContactInfo(ctx, testInstanceID, id, user.ContactInfos[0].ID.Hex()); err == nil {
+			t.Error("main address should not be removable")
+		}
+		user, err = testDBService.RemoveContactInfo(ctx, testInstanceID, id, ci.ID.Hex())
+		if err != nil || len(user.ContactInfos) != 1 {
+			t.Errorf("unexpected result: %v, %v", user.ContactInfos, err)
+		}
+	})
+
+	t.Run("Testing schema version", func(t *testing.T) {
+		if v, err := testDBService.GetSchemaVersion(ctx, testInstanceID); err != nil || v != 0 {
+			t.Errorf("unexpected result: %d, %v", v, err)
+		}
+		for _, version := range []int{1, 2} {
+			if err := testDBService.SetSchemaVersion(ctx, testInstanceID, version); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+		if v, err := testDBService.GetSchemaVersion(ctx, testInstanceID); err != nil || v != 2 {
+			t.Errorf("unexpected result: %d, %v", v, err)
+		}
+	})
+
+	t.Run("Testing delete user", func(t *testing.T) {
+		if err := testDBService.DeleteUser(ctx, testInstanceID, testUser.ID.Hex()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := testDBService.DeleteUser(ctx, testInstanceID, testUser.ID.Hex()); err == nil {
+			t.Error("user should not be found - error expected")
+		}
+		if _, err := testDBService.GetUserByID(ctx, "other-instance", testUser.ID.Hex()); err != nil {
+			t.Errorf("user of the other instance should be kept: %v", err)
+		}
+	})
+}
+
+func TestUserDBPerformActionForUsers(t *testing.T) {
+	testDBService := newTestUserDBService(t, clock.Real)
+	ctx := context.Background()
+
+	// more users than fit on a page
+	for i := 0; i < pageSize+20; i++ {
+		u := models.User{
+			Account:            models.Account{AccountID: fmt.Sprintf("user%d", i)},
+			ContactPreferences: models.ContactPreferences{ReceiveWeeklyMessageDayOfWeek: int32(i % 2)},
+		}
+		if i < 10 {
+			u.Account.AccountConfirmedAt = 1
+		}
+		if _, err := testDBService.AddUser(ctx, testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	countUsers := func(filter userdb.UserFilter) (int, error) {
+		counter := 0
+		err := testDBService.PerfomActionForUsers(ctx, testInstanceID, filter,
+			func(instanceID string, user models.User, args ...interface{}) error {
+				if len(args) != 1 || args[0] != "hello" {
+					return errors.New("unexpected args")
+				}
+				// the callback can use the DB while iterating
+				counter++
+				return testDBService.UpdateLoginTime(ctx, instanceID, user.ID.Hex())
+			},
+			"hello",
+		)
+		return counter, err
+	}
+
+	for _, tc := range []struct {
+		filter userdb.UserFilter
+		count  int
+	}{
+		{userdb.UserFilter{OnlyConfirmed: false, ReminderWeekDay: -1}, pageSize + 20},
+		{userdb.UserFilter{OnlyConfirmed: true, ReminderWeekDay: -1}, 10},
+		{userdb.UserFilter{OnlyConfirmed: false, ReminderWeekDay: 1}, (pageSize + 20) / 2},
+		{userdb.UserFilter{OnlyConfirmed: true, ReminderWeekDay: 1}, 5},
+	} {
+		t.Run(fmt.Sprintf("filter %v", tc.filter), func(t *testing.T) {
+			count, err := countUsers(tc.filter)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if count != tc.count {
+				t.Errorf("unexpected count: %d instead of %d", count, tc.count)
+			}
+		})
+	}
+
+	t.Run("callback error stops the loop", func(t *testing.T) {
+		counter := 0
+		err := testDBService.PerfomActionForUsers(ctx, testInstanceID, userdb.UserFilter{ReminderWeekDay: -1},
+			func(instanceID string, user models.User, args ...interface{}) error {
+				counter++
+				return errors.New("test")
+			})
+		if err == nil || counter != 1 {
+			t.Errorf("unexpected result: %v, %d", err, counter)
+		}
+	})
+
+	t.Run("cancelled context stops the loop", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		err := testDBService.PerfomActionForUsers(cancelled, testInstanceID, userdb.UserFilter{ReminderWeekDay: -1},
+			func(instanceID string, user models.User, args ...interface{}) error {
+				return nil
+			})
+		if err == nil {
+			t.Error("error expected")
+		}
+	})
+}
+
+func TestUserDBSendReminderToConfirmAccountLoop(t *testing.T) {
+	testDBService := newTestUserDBService(t, clock.Real)
+	now := time.Now().Unix()
+	testUsers := []models.User{
+		{Account: models.Account{AccountID: "1"}, Timestamps: models.Timestamps{CreatedAt: now - 100}},
+		{Account: models.Account{AccountID: "2", AccountConfirmedAt: now}, Timestamps: models.Timestamps{CreatedAt: now - 100}},
+		{Account: models.Account{AccountID: "3"}, Timestamps: models.Timestamps{CreatedAt: now}},
+	}
+	for _, u := range testUsers {
+		if _, err := testDBService.AddUser(context.Background(), testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sendReminders := func() int {
+		counter := 0
+		err := testDBService.SendReminderToConfirmAccountLoop(context.TODO(), testInstanceID, now-50,
+			func(instanceID string, user models.User, args ...interface{}) error {
+				counter++
+				return nil
+			})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return counter
+	}
+
+	if c := sendReminders(); c != 1 {
+		t.Errorf("unexpected number of reminders: %d", c)
+	}
+	if c := sendReminders(); c != 0 {
+		t.Errorf("reminder should be sent only once: %d", c)
+	}
+}
+
+func TestUserDBDeleteUnverfiedUsers(t *testing.T) {
+	testDBService := newTestUserDBService(t, clock.Real)
+	ctx := context.Background()
+	testUsers := []models.User{
+		{Account: models.Account{AccountID: "delete_1"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix() - 100}},
+		{Account: models.Account{AccountID: "delete_2"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix() - 50}},
+		{Account: models.Account{AccountID: "delete_3"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix()}},
+		{Account: models.Account{AccountID: "participant"}, Roles: []string{"PARTICIPANT"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix()}},
+	}
+	for _, u := range testUsers {
+		if _, err := testDBService.AddUser(ctx, testInstanceID, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		threshold int64
+		deleted   int64
+		remaining int
+	}{
+		{"remove no user", time.Now().Unix() - 105, 0, 3},
+		{"remove 1 user", time.Now().Unix() - 55, 1, 2},
+		{"remove an other user", time.Now().Unix() - 15, 1, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			count, err := testDBService.DeleteUnverfiedUsers(ctx, testInstanceID, tc.threshold)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if count != tc.deleted {
+				t.Errorf("unexpected number of deleted users: %d", count)
+			}
+			users, err := testDBService.FindNonParticipantUsers(ctx, testInstanceID)
+			if err != nil || len(users) != tc.remaining {
+				t.Errorf("unexpected result: %d, %v", len(users), err)
+			}
+		})
+	}
+
+	t.Run("roles of deleted users are removed", func(t *testing.T) {
+		count := 0
+		if err := testDBService.db.QueryRow(`SELECT COUNT(*) FROM user_roles`).Scan(&count); err != nil || count != 2 {
+			t.Errorf("unexpected result: %d, %v", count, err)
+		}
+	})
+}
+
+func TestUserDBWithFakeClock(t *testing.T) {
+	c := clock.NewFake(time.Unix(1600000000, 0))
+	testDBService := newTestUserDBService(t, c)
+	ctx := context.Background()
+
+	id, err := testDBService.AddUser(ctx, testInstanceID, models.User{
+		Account:    models.Account{AccountID: "clock@test.com"},
+		Timestamps: models.Timestamps{CreatedAt: c.Now().Unix()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testDBService.UpdateLoginTime(ctx, testInstanceID, id); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if user, _ := testDBService.GetUserByID(ctx, testInstanceID, id); user.Timestamps.LastLogin != c.Now().Unix() {
+		t.Errorf("unexpected login time: %d", user.Timestamps.LastLogin)
+	}
+
+	if count, _ := testDBService.CountRecentlyCreatedUsers(ctx, testInstanceID, 60); count != 1 {
+		t.Errorf("unexpected count: %d", count)
+	}
+	c.Advance(2 * time.Minute)
+	if count, _ := testDBService.CountRecentlyCreatedUsers(ctx, testInstanceID, 60); count != 0 {
+		t.Errorf("unexpected count: %d", count)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"time"
 
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -15,9 +17,12 @@ type UserDBService struct {
 	timeout         int
 	noCursorTimeout bool
 	DBNamePrefix    string
+	clock           clock.Clock
+	crypt           *fieldcrypt.Keyring
 }
 
-func NewUserDBService(configs models.DBConfig) *UserDBService {
+// NewUserDBService connects to the user DB. If crypt is not nil, personal data is encrypted before it is stored.
+func NewUserDBService(configs models.DBConfig, clk clock.Clock, crypt *fieldcrypt.Keyring) *UserDBService {
 	var err error
 	dbClient, err := mongo.NewClient(
 		options.Client().ApplyURI(configs.URI),
@@ -48,6 +53,8 @@ func NewUserDBService(configs models.DBConfig) *UserDBService {
 		timeout:         configs.Timeout,
 		noCursorTimeout: configs.NoCursorTimeout,
 		DBNamePrefix:    configs.DBNamePrefix,
+		clock:           clk,
+		crypt:           crypt,
 	}
 }
 
@@ -57,6 +64,7 @@ func (dbService *UserDBService) collectionRefUsers(instanceID string) *mongo.Col
 }
 
 // DB utils
-func (dbService *UserDBService) getContext() (ctx context.Context, cancel context.CancelFunc) {
-	return context.WithTimeout(context.Background(), time.Duration(dbService.timeout)*time.Second)
+// getContext derives the context for a single DB operation from the request context, adding the configured timeout
+func (dbService *UserDBService) getContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc) {
+	return context.WithTimeout(parent, time.Duration(dbService.timeout)*time.Second)
 }
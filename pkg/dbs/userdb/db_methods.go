@@ -3,27 +3,32 @@ package userdb
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/coneno/logger"
 	"github.com/influenzanet/go-utils/pkg/constants"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (dbService *UserDBService) AddUser(instanceID string, user models.User) (id string, err error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) AddUser(ctx context.Context, instanceID string, user models.User) (id string, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
-	filter := bson.M{"account.accountID": user.Account.AccountID}
+	doc, err := dbService.encodeUser(user)
+	if err != nil {
+		return
+	}
+
+	filter := dbService.accountIDFilter(user.Account.AccountID)
 	upsert := true
 	opts := options.UpdateOptions{
 		Upsert: &upsert,
 	}
 	res, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, bson.M{
-		"$setOnInsert": user,
+		"$setOnInsert": doc,
 	}, &opts)
 	if err != nil {
 		return
@@ -38,58 +43,72 @@ func (dbService *UserDBService) AddUser(instanceID string, user models.User) (id
 	return
 }
 
-// low level find and replace
-func (dbService *UserDBService) _updateUserInDB(orgID string, user models.User) (models.User, error) {
-	ctx, cancel := dbService.getContext()
+// low level find and replace, only succeeds if the stored user has the same revision
+func (dbService *UserDBService) _updateUserInDB(ctx context.Context, orgID string, user models.User) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
-	elem := models.User{}
-	filter := bson.M{"_id": user.ID}
+	filter := bson.M{"_id": user.ID, "revision": revisionFilter(user.Revision)}
+	readRevision := user.Revision
+	user.Revision += 1
+	doc, err := dbService.encodeUser(user)
+	if err != nil {
+		return models.User{}, err
+	}
+
 	rd := options.After
 	fro := options.FindOneAndReplaceOptions{
 		ReturnDocument: &rd,
 	}
-	err := dbService.collectionRefUsers(orgID).FindOneAndReplace(ctx, filter, user, &fro).Decode(&elem)
+	elem, err := dbService.decodeUser(dbService.collectionRefUsers(orgID).FindOneAndReplace(ctx, filter, doc, &fro))
+	if err == mongo.ErrNoDocuments && dbService.userExists(ctx, orgID, user.ID) {
+		return elem, &RevisionConflictError{UserID: user.ID.Hex(), Revision: readRevision}
+	}
 	return elem, err
 }
 
-func (dbService *UserDBService) UpdateUser(instanceID string, updatedUser models.User) (models.User, error) {
+func (dbService *UserDBService) UpdateUser(ctx context.Context, instanceID string, updatedUser models.User) (models.User, error) {
 	// Set last update time
-	updatedUser.Timestamps.UpdatedAt = time.Now().Unix()
-	return dbService._updateUserInDB(instanceID, updatedUser)
+	updatedUser.Timestamps.UpdatedAt = dbService.clock.Now().Unix()
+	return dbService._updateUserInDB(ctx, instanceID, updatedUser)
 }
 
-func (dbService *UserDBService) GetUserByID(instanceID string, id string) (models.User, error) {
+func (dbService *UserDBService) GetUserByID(ctx context.Context, instanceID string, id string) (models.User, error) {
 	_id, _ := primitive.ObjectIDFromHex(id)
 	filter := bson.M{"_id": _id}
 
-	ctx, cancel := dbService.getContext()
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
-	elem := models.User{}
-	err := dbService.collectionRefUsers(instanceID).FindOne(ctx, filter).Decode(&elem)
-
-	return elem, err
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOne(ctx, filter))
 }
 
-func (dbService *UserDBService) GetUserByAccountID(instanceID string, username string) (models.User, error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) GetUserByAccountID(ctx context.Context, instanceID string, username string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
-	elem := models.User{}
-	filter := bson.M{"account.accountID": username}
-	err := dbService.collectionRefUsers(instanceID).FindOne(ctx, filter).Decode(&elem)
+	filter := dbService.accountIDFilter(username)
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOne(ctx, filter))
+}
 
-	return elem, err
+func (dbService *UserDBService) GetUserByRefreshToken(ctx context.Context, instanceID string, token string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"account.refreshTokenFamilies.token": token}
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOne(ctx, filter))
 }
 
-func (dbService *UserDBService) UpdateUserPassword(instanceID string, userID string, newPassword string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) UpdateUserPassword(ctx context.Context, instanceID string, userID string, newPassword string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"_id": _id}
-	update := bson.M{"$set": bson.M{"account.password": newPassword, "timestamps.lastPasswordChange": time.Now().Unix()}}
+	update := bson.M{
+		"$set": bson.M{"account.password": newPassword, "timestamps.lastPasswordChange": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
 	_, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -97,13 +116,16 @@ func (dbService *UserDBService) UpdateUserPassword(instanceID string, userID str
 	return nil
 }
 
-func (dbService *UserDBService) SaveFailedLoginAttempt(instanceID string, userID string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) SaveFailedLoginAttempt(ctx context.Context, instanceID string, userID string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"_id": _id}
-	update := bson.M{"$push": bson.M{"account.failedLoginAttempts": time.Now().Unix()}}
+	update := bson.M{
+		"$push": bson.M{"account.failedLoginAttempts": dbService.clock.Now().Unix()},
+		"$inc":  bson.M{"revision": 1},
+	}
 	_, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -111,13 +133,16 @@ func (dbService *UserDBService) SaveFailedLoginAttempt(instanceID string, userID
 	return nil
 }
 
-func (dbService *UserDBService) SavePasswordResetTrigger(instanceID string, userID string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) SavePasswordResetTrigger(ctx context.Context, instanceID string, userID string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"_id": _id}
-	update := bson.M{"$push": bson.M{"account.passwordResetTriggers": time.Now().Unix()}}
+	update := bson.M{
+		"$push": bson.M{"account.passwordResetTriggers": dbService.clock.Now().Unix()},
+		"$inc":  bson.M{"revision": 1},
+	}
 	_, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -125,49 +150,52 @@ func (dbService *UserDBService) SavePasswordResetTrigger(instanceID string, user
 	return nil
 }
 
-func (dbService *UserDBService) UpdateAccountPreferredLang(instanceID string, userID string, lang string) (models.User, error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) UpdateAccountPreferredLang(ctx context.Context, instanceID string, userID string, lang string) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"_id": _id}
 
-	elem := models.User{}
-
 	rd := options.After
 	fro := options.FindOneAndUpdateOptions{
 		ReturnDocument: &rd,
 	}
-	update := bson.M{"$set": bson.M{"account.preferredLanguage": lang, "timestamps.updatedAt": time.Now().Unix()}}
-	err := dbService.collectionRefUsers(instanceID).FindOneAndUpdate(ctx, filter, update, &fro).Decode(&elem)
-	return elem, err
+	update := bson.M{
+		"$set": bson.M{"account.preferredLanguage": lang, "timestamps.updatedAt": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOneAndUpdate(ctx, filter, update, &fro))
 }
 
-func (dbService *UserDBService) UpdateContactPreferences(instanceID string, userID string, prefs models.ContactPreferences) (models.User, error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) UpdateContactPreferences(ctx context.Context, instanceID string, userID string, prefs models.ContactPreferences) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"_id": _id}
 
-	elem := models.User{}
-
 	rd := options.After
 	fro := options.FindOneAndUpdateOptions{
 		ReturnDocument: &rd,
 	}
-	update := bson.M{"$set": bson.M{"contactPreferences": prefs, "timestamps.updatedAt": time.Now().Unix()}}
-	err := dbService.collectionRefUsers(instanceID).FindOneAndUpdate(ctx, filter, update, &fro).Decode(&elem)
-	return elem, err
+	update := bson.M{
+		"$set": bson.M{"contactPreferences": prefs, "timestamps.updatedAt": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOneAndUpdate(ctx, filter, update, &fro))
 }
 
-func (dbService *UserDBService) UpdateLoginTime(instanceID string, id string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) UpdateLoginTime(ctx context.Context, instanceID string, id string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(id)
 	filter := bson.M{"_id": _id}
-	update := bson.M{"$set": bson.M{"timestamps.lastLogin": time.Now().Unix()}}
+	update := bson.M{
+		"$set": bson.M{"timestamps.lastLogin": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
 	_, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -175,13 +203,16 @@ func (dbService *UserDBService) UpdateLoginTime(instanceID string, id string) er
 	return nil
 }
 
-func (dbService *UserDBService) UpdateReminderToConfirmSentAtTime(instanceID string, id string) error {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) UpdateReminderToConfirmSentAtTime(ctx context.Context, instanceID string, id string) error {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	_id, _ := primitive.ObjectIDFromHex(id)
 	filter := bson.M{"_id": _id}
-	update := bson.M{"$set": bson.M{"timestamps.reminderToConfirmSentAt": time.Now().Unix()}}
+	update := bson.M{
+		"$set": bson.M{"timestamps.reminderToConfirmSentAt": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
 	_, err := dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -189,20 +220,227 @@ func (dbService *UserDBService) UpdateReminderToConfirmSentAtTime(instanceID str
 	return nil
 }
 
-func (dbService *UserDBService) CountRecentlyCreatedUsers(instanceID string, interval int64) (count int64, err error) {
-	ctx, cancel := dbService.getContext()
+// _findAndUpdateUser applies the update to the user matching the filter and returns the updated user. The revision is
+// incremented, so that concurrent replacements of the whole user are detected.
+func (dbService *UserDBService) _findAndUpdateUser(ctx context.Context, instanceID string, filter bson.M, update bson.M) (models.User, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	update["$inc"] = bson.M{"revision": 1}
+
+	rd := options.After
+	fro := options.FindOneAndUpdateOptions{
+		ReturnDocument: &rd,
+	}
+	return dbService.decodeUser(dbService.collectionRefUsers(instanceID).FindOneAndUpdate(ctx, filter, update, &fro))
+}
+
+// AddRefreshTokenFamily starts a new session, keeping only the maxFamilies most recently used ones
+func (dbService *UserDBService) AddRefreshTokenFamily(ctx context.Context, instanceID string, userID string, family models.RefreshTokenFamily, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id}
+	update := bson.M{"$push": bson.M{"account.refreshTokenFamilies": bson.M{
+		"$each":  bson.A{family},
+		"$sort":  bson.M{"lastUsedAt": 1},
+		"$slice": -maxFamilies,
+	}}}
+	return dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+}
+
+// RenewRefreshToken replaces oldToken by newToken in its family and updates the session. Only one of several
+// concurrent calls with the same oldToken succeeds, the others get mongo.ErrNoDocuments - same as if oldToken was
+// never issued. A token from before the families were introduced starts a new family, keeping only the maxFamilies
+// most recently used ones. rotatedToken, the hashed form of oldToken, is kept to detect reuse.
+func (dbService *UserDBService) RenewRefreshToken(ctx context.Context, instanceID string, userID string, oldToken string, rotatedToken string, newToken string, session models.SessionInfo, maxFamilies int) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	now := dbService.clock.Now().Unix()
+	filter := bson.M{"_id": _id, "account.refreshTokenFamilies.token": oldToken}
+	set := bson.M{
+		"account.refreshTokenFamilies.$.token":      newToken,
+		"account.refreshTokenFamilies.$.lastUsedAt": now,
+		"timestamps.lastTokenRefresh":               now,
+	}
+	for field, value := range map[string]string{
+		"userAgent": session.UserAgent,
+		"ipAddress": session.IPAddress,
+		"label":     session.Label,
+	} {
+		if value != "" {
+			set["account.refreshTokenFamilies.$."+field] = value
+		}
+	}
+	update := bson.M{
+		"$set": set,
+		"$push": bson.M{"account.refreshTokenFamilies.$.rotatedTokens": bson.M{
+			"$each":  bson.A{rotatedToken},
+			"$slice": -models.MaxRotatedRefreshTokens,
+		}},
+	}
+	user, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	if err != mongo.ErrNoDocuments {
+		return user, err
+	}
+
+	// token issued before the families were introduced
+	family := models.NewRefreshTokenFamily(newToken, session, now)
+	family.RotatedTokens = append(family.RotatedTokens, rotatedToken)
+	filter = bson.M{"_id": _id, "account.refreshTokens": oldToken}
+	update = bson.M{
+		"$pull": bson.M{"account.refreshTokens": oldToken},
+		"$push": bson.M{"account.refreshTokenFamilies": bson.M{
+			"$each":  bson.A{family},
+			"$sort":  bson.M{"lastUsedAt": 1},
+			"$slice": -maxFamilies,
+		}},
+		"$set": bson.M{"timestamps.lastTokenRefresh": now},
+	}
+	return dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+}
+
+func (dbService *UserDBService) RemoveAllRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id}
+	update := bson.M{"$set": bson.M{
+		"account.refreshTokens":        bson.A{},
+		"account.refreshTokenFamilies": bson.A{},
+	}}
+	_, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	return err
+}
+
+// RemoveLegacyRefreshTokens removes the refresh tokens stored before the token families were introduced
+func (dbService *UserDBService) RemoveLegacyRefreshTokens(ctx context.Context, instanceID string, userID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id}
+	update := bson.M{"$set": bson.M{"account.refreshTokens": bson.A{}}}
+	_, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	return err
+}
+
+// RemoveRefreshTokenFamily revokes all refresh tokens of the family
+func (dbService *UserDBService) RemoveRefreshTokenFamily(ctx context.Context, instanceID string, userID string, familyID string) error {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id}
+	update := bson.M{"$pull": bson.M{"account.refreshTokenFamilies": bson.M{"id": familyID}}}
+	_, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	return err
+}
+
+func (dbService *UserDBService) AddRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id, "roles": bson.M{"$ne": role}}
+	update := bson.M{"$push": bson.M{"roles": role}}
+	user, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	if err == mongo.ErrNoDocuments && dbService.userExists(ctx, instanceID, _id) {
+		return user, errors.New("role already added")
+	}
+	return user, err
+}
+
+func (dbService *UserDBService) RemoveRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id, "roles": role}
+	update := bson.M{"$pull": bson.M{"roles": role}}
+	user, err := dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	if err == mongo.ErrNoDocuments && dbService.userExists(ctx, instanceID, _id) {
+		return user, errors.New("role not found")
+	}
+	return user, err
+}
+
+func (dbService *UserDBService) AddContactInfo(ctx context.Context, instanceID string, userID string, contactInfo models.ContactInfo) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	filter := bson.M{"_id": _id}
+	contactInfo, err := dbService.encodeContactInfo(contactInfo)
+	if err != nil {
+		return models.User{}, err
+	}
+	update := bson.M{"$push": bson.M{"contactInfos": contactInfo}}
+	return dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+}
+
+// RemoveContactInfo removes the contact info and all references to it from the contact preferences. The address
+// used as account ID of email accounts cannot be removed.
+func (dbService *UserDBService) RemoveContactInfo(ctx context.Context, instanceID string, userID string, contactInfoID string) (models.User, error) {
+	_id, _ := primitive.ObjectIDFromHex(userID)
+	ciID, _ := primitive.ObjectIDFromHex(contactInfoID)
+
+	user, err := dbService.GetUserByID(ctx, instanceID, userID)
+	if err != nil {
+		return user, err
+	}
+	ci, found := user.FindContactInfoById(contactInfoID)
+	if !found {
+		return user, errors.New("contact not found")
+	}
+	if user.Account.Type == models.ACCOUNT_TYPE_EMAIL && ci.Email == user.Account.AccountID {
+		return user, errors.New("cannot remove main address")
+	}
+
+	filter := bson.M{"_id": _id, "contactInfos._id": ciID}
+	if ci.Email != "" {
+		// the email of a contact info never changes, but the account ID could have been changed concurrently
+		mainAddress := dbService.accountIDFilter(ci.Email)
+		mainAddress["account.type"] = models.ACCOUNT_TYPE_EMAIL
+		filter["$nor"] = bson.A{mainAddress}
+	}
+	update := bson.M{"$pull": bson.M{
+		"contactInfos":                        bson.M{"_id": ciID},
+		"contactPreferences.sendNewsletterTo": contactInfoID,
+	}}
+	user, err = dbService._findAndUpdateUser(ctx, instanceID, filter, update)
+	if err == mongo.ErrNoDocuments {
+		return user, errors.New("contact not found")
+	}
+	return user, err
+}
+
+// SetContactInfoVerificationSent updates the time the confirmation message was sent to the contact with the given address.
+// The contact is looked up first, since the stored address can be encrypted.
+func (dbService *UserDBService) SetContactInfoVerificationSent(ctx context.Context, instanceID string, userID string, t string, addr string) error {
+	user, err := dbService.GetUserByID(ctx, instanceID, userID)
+	if err != nil {
+		return err
+	}
+	ci, found := user.FindContactInfoByTypeAndAddr(t, addr)
+	if !found {
+		return nil
+	}
+
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"_id": user.ID, "contactInfos._id": ci.ID}
+	update := bson.M{
+		"$set": bson.M{"contactInfos.$.confirmationLinkSentAt": dbService.clock.Now().Unix()},
+		"$inc": bson.M{"revision": 1},
+	}
+	_, err = dbService.collectionRefUsers(instanceID).UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (dbService *UserDBService) userExists(ctx context.Context, instanceID string, id primitive.ObjectID) bool {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	count, err := dbService.collectionRefUsers(instanceID).CountDocuments(ctx, bson.M{"_id": id})
+	return err == nil && count > 0
+}
+
+func (dbService *UserDBService) CountRecentlyCreatedUsers(ctx context.Context, instanceID string, interval int64) (count int64, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
-	filter := bson.M{"timestamps.createdAt": bson.M{"$gt": time.Now().Unix() - interval}}
+	filter := bson.M{"timestamps.createdAt": bson.M{"$gt": dbService.clock.Now().Unix() - interval}}
 	count, err = dbService.collectionRefUsers(instanceID).CountDocuments(ctx, filter)
 	return
 }
 
-func (dbService *UserDBService) DeleteUser(instanceID string, id string) error {
+func (dbService *UserDBService) DeleteUser(ctx context.Context, instanceID string, id string) error {
 	_id, _ := primitive.ObjectIDFromHex(id)
 	filter := bson.M{"_id": _id}
 
-	ctx, cancel := dbService.getContext()
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 	res, err := dbService.collectionRefUsers(instanceID).DeleteOne(ctx, filter, nil)
 	if err != nil {
@@ -214,14 +452,14 @@ func (dbService *UserDBService) DeleteUser(instanceID string, id string) error {
 	return nil
 }
 
-func (dbService *UserDBService) DeleteUnverfiedUsers(instanceID string, createdBefore int64) (int64, error) {
+func (dbService *UserDBService) DeleteUnverfiedUsers(ctx context.Context, instanceID string, createdBefore int64) (int64, error) {
 	filter := bson.M{}
 	filter["$and"] = bson.A{
 		bson.M{"account.accountConfirmedAt": 0},
 		bson.M{"timestamps.createdAt": bson.M{"$lt": createdBefore}},
 	}
 
-	ctx, cancel := dbService.getContext()
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 	res, err := dbService.collectionRefUsers(instanceID).DeleteMany(ctx, filter, nil)
 	if err != nil {
@@ -231,8 +469,8 @@ func (dbService *UserDBService) DeleteUnverfiedUsers(instanceID string, createdB
 	return res.DeletedCount, nil
 }
 
-func (dbService *UserDBService) FindNonParticipantUsers(instanceID string) (users []models.User, err error) {
-	ctx, cancel := dbService.getContext()
+func (dbService *UserDBService) FindNonParticipantUsers(ctx context.Context, instanceID string) (users []models.User, err error) {
+	ctx, cancel := dbService.getContext(ctx)
 	defer cancel()
 
 	filter := bson.M{
@@ -254,8 +492,7 @@ func (dbService *UserDBService) FindNonParticipantUsers(instanceID string) (user
 
 	users = []models.User{}
 	for cur.Next(ctx) {
-		var result models.User
-		err := cur.Decode(&result)
+		result, err := dbService.decodeUser(cur)
 		if err != nil {
 			return users, err
 		}
@@ -310,8 +547,7 @@ func (dbService *UserDBService) PerfomActionForUsers(
 			logger.Debug.Println(ctx.Err())
 			return ctx.Err()
 		}
-		var result models.User
-		err := cur.Decode(&result)
+		result, err := dbService.decodeUser(cur)
 		if err != nil {
 			logger.Error.Printf("wrong user model %v, %v", result, err)
 			continue
@@ -363,8 +599,7 @@ func (dbService *UserDBService) SendReminderToConfirmAccountLoop(
 			logger.Debug.Println(ctx.Err())
 			return ctx.Err()
 		}
-		var result models.User
-		err := cur.Decode(&result)
+		result, err := dbService.decodeUser(cur)
 		if err != nil {
 			logger.Error.Printf("wrong user model %v, %v", result, err)
 			continue
@@ -375,7 +610,7 @@ func (dbService *UserDBService) SendReminderToConfirmAccountLoop(
 			continue
 		}
 
-		if err := dbService.UpdateReminderToConfirmSentAtTime(instanceID, result.ID.Hex()); err != nil {
+		if err := dbService.UpdateReminderToConfirmSentAtTime(ctx, instanceID, result.ID.Hex()); err != nil {
 			logger.Error.Printf("unexpected error: %v", err)
 			continue
 		}
@@ -10,8 +10,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var testDBService *UserDBService
@@ -56,6 +58,8 @@ func setupTestDBService() {
 			MaxPoolSize:     MaxPoolSize,
 			DBNamePrefix:    testDBNamePrefix,
 		},
+		clock.Real,
+		nil,
 	)
 }
 
@@ -93,7 +97,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	}
 
 	t.Run("Testing create user", func(t *testing.T) {
-		id, err := testDBService.AddUser(testInstanceID, testUser)
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, testUser)
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -109,12 +113,12 @@ func TestDbInterfaceMethods(t *testing.T) {
 	t.Run("Testing creating existing user", func(t *testing.T) {
 		testUser2 := testUser
 		testUser2.Roles = []string{"TEST2"}
-		_, err := testDBService.AddUser(testInstanceID, testUser2)
+		_, err := testDBService.AddUser(context.Background(), testInstanceID, testUser2)
 		if err == nil {
 			t.Errorf("user already existed, but created again")
 			return
 		}
-		u, e := testDBService.GetUserByAccountID(testInstanceID, testUser2.Account.AccountID)
+		u, e := testDBService.GetUserByAccountID(context.Background(), testInstanceID, testUser2.Account.AccountID)
 		if e != nil {
 			t.Errorf(e.Error())
 			return
@@ -125,7 +129,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing find existing user by id", func(t *testing.T) {
-		user, err := testDBService.GetUserByID(testInstanceID, testUser.ID.Hex())
+		user, err := testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -137,7 +141,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing find not existing user by id", func(t *testing.T) {
-		_, err := testDBService.GetUserByID(testInstanceID, testUser.ID.Hex()+"1")
+		_, err := testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex()+"1")
 		if err == nil {
 			t.Errorf("user should not be found")
 			return
@@ -145,7 +149,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing find existing user by email", func(t *testing.T) {
-		user, err := testDBService.GetUserByAccountID(testInstanceID, testUser.Account.AccountID)
+		user, err := testDBService.GetUserByAccountID(context.Background(), testInstanceID, testUser.Account.AccountID)
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -157,7 +161,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing find not existing user by email", func(t *testing.T) {
-		_, err := testDBService.GetUserByAccountID(testInstanceID, testUser.Account.AccountID+"1")
+		_, err := testDBService.GetUserByAccountID(context.Background(), testInstanceID, testUser.Account.AccountID+"1")
 		if err == nil {
 			t.Errorf("user should not be found")
 			return
@@ -166,13 +170,34 @@ func TestDbInterfaceMethods(t *testing.T) {
 
 	t.Run("Testing updating existing user's attributes", func(t *testing.T) {
 		testUser.Account.AccountConfirmedAt = time.Now().Unix()
-		_, err := testDBService.UpdateUser(testInstanceID, testUser)
+		_, err := testDBService.UpdateUser(context.Background(), testInstanceID, testUser)
 		if err != nil {
 			t.Errorf(err.Error())
 			return
 		}
 	})
 
+	t.Run("Testing updating user with outdated revision", func(t *testing.T) {
+		_, err := testDBService.UpdateUser(context.Background(), testInstanceID, testUser)
+		if !IsRevisionConflict(err) {
+			t.Errorf("conflict expected: %v", err)
+		}
+	})
+
+	t.Run("Testing update with retry after conflict", func(t *testing.T) {
+		user, err := UpdateUserWithRetry(context.Background(), testDBService, testInstanceID, testUser, func(user *models.User) error {
+			user.Account.PreferredLanguage = "fr"
+			return nil
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user.Account.PreferredLanguage != "fr" || user.Revision != 2 {
+			t.Errorf("unexpected user: %v", user)
+		}
+	})
+
 	t.Run("Testing updating not existing user's attributes", func(t *testing.T) {
 		testUser.Account.AccountConfirmedAt = time.Now().Unix()
 		currentUser := testUser
@@ -183,15 +208,159 @@ func TestDbInterfaceMethods(t *testing.T) {
 			return
 		}
 		currentUser.ID = id
-		_, err = testDBService.UpdateUser(testInstanceID, currentUser)
+		_, err = testDBService.UpdateUser(context.Background(), testInstanceID, currentUser)
 		if err == nil {
 			t.Errorf("cannot update not existing user")
 			return
 		}
 	})
 
+	t.Run("Testing refresh token updates", func(t *testing.T) {
+		for i := 0; i < models.MaxRefreshTokens+2; i++ {
+			family := models.NewRefreshTokenFamily(fmt.Sprintf("rt%d", i), models.SessionInfo{}, int64(i))
+			if _, err := testDBService.AddRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), family, models.MaxRefreshTokens); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+		}
+		user, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, testUser.ID.Hex(), "rt5", "rt5", "new", models.SessionInfo{IPAddress: "127.0.0.1"}, models.MaxRefreshTokens)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		families := user.Account.RefreshTokenFamilies
+		if len(families) != models.MaxRefreshTokens || families[0].Token != "rt2" ||
+			user.HasRefreshToken("rt5") || !user.HasRefreshToken("new") {
+			t.Errorf("unexpected refresh tokens: %v", families)
+		}
+		familyID, found := user.FindRotatedRefreshToken("rt5")
+		if i := user.FindRefreshTokenFamily(familyID); !found || i < 0 || families[i].Token != "new" || families[i].IPAddress != "127.0.0.1" || families[i].LastUsedAt < 100 {
+			t.Errorf("unexpected token family: %v", families)
+		}
+		if _, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, testUser.ID.Hex(), "rt5", "rt5", "new2", models.SessionInfo{}, models.MaxRefreshTokens); err != mongo.ErrNoDocuments {
+			t.Errorf("used token should not be accepted: %v", err)
+		}
+		// the least recently used session is removed
+		user, err = testDBService.AddRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), models.NewRefreshTokenFamily("rt12", models.SessionInfo{}, 100), models.MaxRefreshTokens)
+		if err != nil || len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens || user.HasRefreshToken("rt2") ||
+			!user.HasRefreshToken("rt3") || !user.HasRefreshToken("new") || !user.HasRefreshToken("rt12") {
+			t.Errorf("unexpected refresh tokens: %v, %v", user.Account.RefreshTokenFamilies, err)
+		}
+		if err := testDBService.RemoveRefreshTokenFamily(context.Background(), testInstanceID, testUser.ID.Hex(), familyID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		user, _ = testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if user.HasRefreshToken("new") || len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens-1 {
+			t.Errorf("token family not removed: %v", user.Account.RefreshTokenFamilies)
+		}
+		if user, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "rt12"); err != nil || user.ID != testUser.ID {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "new"); err != mongo.ErrNoDocuments {
+			t.Errorf("token of removed family should not be found: %v", err)
+		}
+		if err := testDBService.RemoveAllRefreshTokens(context.Background(), testInstanceID, testUser.ID.Hex()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Testing renewal of legacy refresh token keeps the session limit", func(t *testing.T) {
+		legacyUser := models.User{Account: models.Account{Type: "email", AccountID: "legacy-rt@test.com", RefreshTokens: []string{"legacy-rt"}}}
+		for i := 0; i < models.MaxRefreshTokens; i++ {
+			legacyUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily(fmt.Sprintf("legacy-family-%d", i), models.SessionInfo{}, int64(i)), models.MaxRefreshTokens)
+		}
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, legacyUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, err := testDBService.RenewRefreshToken(context.Background(), testInstanceID, id, "legacy-rt", "legacy-rt-hash", "legacy-new", models.SessionInfo{}, models.MaxRefreshTokens)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(user.Account.RefreshTokenFamilies) != models.MaxRefreshTokens || len(user.Account.RefreshTokens) != 0 ||
+			!user.HasRefreshToken("legacy-new") || user.HasRefreshToken("legacy-family-0") {
+			t.Errorf("unexpected refresh tokens: %v", user.Account.RefreshTokenFamilies)
+		}
+		if _, found := user.FindRotatedRefreshToken("legacy-rt-hash"); !found {
+			t.Error("hashed legacy token should be kept as rotated token")
+		}
+		if _, found := user.FindRotatedRefreshToken("legacy-rt"); found {
+			t.Error("legacy token should not be kept in plaintext")
+		}
+	})
+
+	t.Run("Testing removal of legacy refresh tokens", func(t *testing.T) {
+		legacyUser := models.User{Account: models.Account{Type: "email", AccountID: "legacy-rt-removal@test.com", RefreshTokens: []string{"legacy-rt"}}}
+		legacyUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily("family-rt", models.SessionInfo{}, 1), models.MaxRefreshTokens)
+		id, err := testDBService.AddUser(context.Background(), testInstanceID, legacyUser)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "family-rt"); err != nil || user.ID.Hex() != id {
+			t.Errorf("user not found by refresh token: %v", err)
+		}
+		if _, err := testDBService.GetUserByRefreshToken(context.Background(), testInstanceID, "legacy-rt"); err != mongo.ErrNoDocuments {
+			t.Errorf("legacy refresh token should not be looked up: %v", err)
+		}
+		if err := testDBService.RemoveLegacyRefreshTokens(context.Background(), testInstanceID, id); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		user, err := testDBService.GetUserByID(context.Background(), testInstanceID, id)
+		if err != nil || user.HasRefreshToken("legacy-rt") || !user.HasRefreshToken("family-rt") {
+			t.Errorf("unexpected refresh tokens: %v, %v", user.Account, err)
+		}
+	})
+
+	t.Run("Testing role updates", func(t *testing.T) {
+		user, err := testDBService.AddRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN")
+		if err != nil || !user.HasRole("ADMIN") {
+			t.Errorf("unexpected result: %v %v", user.Roles, err)
+		}
+		if _, err := testDBService.AddRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN"); err == nil {
+			t.Error("error expected for existing role")
+		}
+		user, err = testDBService.RemoveRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN")
+		if err != nil || user.HasRole("ADMIN") {
+			t.Errorf("unexpected result: %v %v", user.Roles, err)
+		}
+		if _, err := testDBService.RemoveRole(context.Background(), testInstanceID, testUser.ID.Hex(), "ADMIN"); err == nil {
+			t.Error("error expected for missing role")
+		}
+	})
+
+	t.Run("Testing contact info updates", func(t *testing.T) {
+		ci := models.NewEmailContactInfo("second@test.com", false, 0)
+		user, err := testDBService.AddContactInfo(context.Background(), testInstanceID, testUser.ID.Hex(), ci)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if _, found := user.FindContactInfoById(ci.ID.Hex()); !found {
+			t.Errorf("contact info not added: %v", user.ContactInfos)
+		}
+		if err := testDBService.SetContactInfoVerificationSent(context.Background(), testInstanceID, testUser.ID.Hex(), "email", ci.Email); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		user, _ = testDBService.GetUserByID(context.Background(), testInstanceID, testUser.ID.Hex())
+		if c, _ := user.FindContactInfoById(ci.ID.Hex()); c.ConfirmationLinkSentAt == 0 {
+			t.Errorf("verification sent time not set: %v", c)
+		}
+		user, err = testDBService.RemoveContactInfo(context.Background(), testInstanceID, testUser.ID.Hex(), ci.ID.Hex())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if _, found := user.FindContactInfoById(ci.ID.Hex()); found {
+			t.Errorf("contact info not removed: %v", user.ContactInfos)
+		}
+	})
+
 	t.Run("Testing counting recently added users", func(t *testing.T) {
-		count, err := testDBService.CountRecentlyCreatedUsers(testInstanceID, 20)
+		count, err := testDBService.CountRecentlyCreatedUsers(context.Background(), testInstanceID, 20)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -204,7 +373,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing deleting existing user", func(t *testing.T) {
-		err := testDBService.DeleteUser(testInstanceID, testUser.ID.Hex())
+		err := testDBService.DeleteUser(context.Background(), testInstanceID, testUser.ID.Hex())
 		if err != nil {
 			t.Errorf(err.Error())
 			return
@@ -212,7 +381,7 @@ func TestDbInterfaceMethods(t *testing.T) {
 	})
 
 	t.Run("Testing deleting not existing user", func(t *testing.T) {
-		err := testDBService.DeleteUser(testInstanceID, testUser.ID.Hex()+"1")
+		err := testDBService.DeleteUser(context.Background(), testInstanceID, testUser.ID.Hex()+"1")
 		if err == nil {
 			t.Errorf("user should not be found - error expected")
 			return
@@ -227,7 +396,7 @@ func TestDbPerformActionForUsers(t *testing.T) {
 		{Account: models.Account{AccountID: "3"}},
 	}
 	for _, u := range testUsers {
-		_, err := testDBService.AddUser(testInstanceID, u)
+		_, err := testDBService.AddUser(context.Background(), testInstanceID, u)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -271,7 +440,7 @@ func TestDbPerformActionForUsers(t *testing.T) {
 }
 
 func AssertNumberOfNonParticipantUsers(instanceID string, count int) error {
-	users, err := testDBService.FindNonParticipantUsers(instanceID)
+	users, err := testDBService.FindNonParticipantUsers(context.Background(), instanceID)
 	if err != nil {
 		return err
 	}
@@ -288,14 +457,14 @@ func TestDeleteUnverfiedUsers(t *testing.T) {
 		{Account: models.Account{AccountID: "delete_3"}, Roles: []string{"RESEARCHER"}, Timestamps: models.Timestamps{CreatedAt: time.Now().Unix()}},
 	}
 	for _, u := range testUsers {
-		_, err := testDBService.AddUser(testInstanceID, u)
+		_, err := testDBService.AddUser(context.Background(), testInstanceID, u)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 
 	t.Run("remove any other user not in the test set", func(t *testing.T) {
-		count, err := testDBService.DeleteUnverfiedUsers(testInstanceID, time.Now().Unix()-105)
+		count, err := testDBService.DeleteUnverfiedUsers(context.Background(), testInstanceID, time.Now().Unix()-105)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -309,7 +478,7 @@ func TestDeleteUnverfiedUsers(t *testing.T) {
 	})
 
 	t.Run("remove 1 user", func(t *testing.T) {
-		count, err := testDBService.DeleteUnverfiedUsers(testInstanceID, time.Now().Unix()-55)
+		count, err := testDBService.DeleteUnverfiedUsers(context.Background(), testInstanceID, time.Now().Unix()-55)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -323,7 +492,7 @@ func TestDeleteUnverfiedUsers(t *testing.T) {
 	})
 
 	t.Run("remove an other user", func(t *testing.T) {
-		count, err := testDBService.DeleteUnverfiedUsers(testInstanceID, time.Now().Unix()-15)
+		count, err := testDBService.DeleteUnverfiedUsers(context.Background(), testInstanceID, time.Now().Unix()-15)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -0,0 +1,142 @@
+package userdb
+
+import (
+	"context"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// userDoc is the user as stored in the DB. With field encryption, the account ID and the email addresses are
+// encrypted and the account ID is found through its blind index.
+type userDoc struct {
+	models.User    `bson:",inline"`
+	AccountIDIndex string `bson:"accountIDIndex,omitempty"`
+}
+
+type decoder interface {
+	Decode(v interface{}) error
+}
+
+// encodeUser encrypts the personal data of the user. The contact infos are copied, so the caller's user is unchanged.
+func (dbService *UserDBService) encodeUser(user models.User) (userDoc, error) {
+	doc := userDoc{User: user}
+	if dbService.crypt == nil {
+		return doc, nil
+	}
+
+	var err error
+	doc.AccountIDIndex = dbService.crypt.BlindIndex(user.Account.AccountID)
+	if doc.Account.AccountID, err = dbService.crypt.Encrypt(user.Account.AccountID); err != nil {
+		return doc, err
+	}
+	if user.ContactInfos != nil {
+		doc.ContactInfos = make([]models.ContactInfo, len(user.ContactInfos))
+		for i, ci := range user.ContactInfos {
+			if ci, err = dbService.encodeContactInfo(ci); err != nil {
+				return doc, err
+			}
+			doc.ContactInfos[i] = ci
+		}
+	}
+	return doc, nil
+}
+
+func (dbService *UserDBService) encodeContactInfo(ci models.ContactInfo) (models.ContactInfo, error) {
+	var err error
+	ci.Email, err = dbService.crypt.Encrypt(ci.Email)
+	return ci, err
+}
+
+// decodeUser reads a user from a query result and decrypts its personal data. Plaintext values are returned as they
+// are, so users stored before encryption was enabled can still be read.
+func (dbService *UserDBService) decodeUser(res decoder) (models.User, error) {
+	user := models.User{}
+	if err := res.Decode(&user); err != nil {
+		return user, err
+	}
+
+	var err error
+	if user.Account.AccountID, err = dbService.crypt.Decrypt(user.Account.AccountID); err != nil {
+		return user, err
+	}
+	for i := range user.ContactInfos {
+		if user.ContactInfos[i].Email, err = dbService.crypt.Decrypt(user.ContactInfos[i].Email); err != nil {
+			return user, err
+		}
+	}
+	return user, nil
+}
+
+// accountIDFilter matches the user with the account ID. With encryption, the blind index is used. Users that were
+// not re-encrypted yet still have the plaintext account ID, they are matched too.
+func (dbService *UserDBService) accountIDFilter(accountID string) bson.M {
+	if dbService.crypt == nil {
+		return bson.M{"account.accountID": accountID}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"accountIDIndex": dbService.crypt.BlindIndex(accountID)},
+		bson.M{"account.accountID": accountID},
+	}}
+}
+
+// needsReencryption checks the stored document for plaintext values, values encrypted with an old key or an
+// outdated blind index.
+func (dbService *UserDBService) needsReencryption(stored userDoc, user models.User) bool {
+	if dbService.crypt.NeedsReencryption(stored.Account.AccountID) ||
+		stored.AccountIDIndex != dbService.crypt.BlindIndex(user.Account.AccountID) {
+		return true
+	}
+	for _, ci := range stored.ContactInfos {
+		if dbService.crypt.NeedsReencryption(ci.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReencryptUsers stores all users of the instance again whose personal data is in plaintext or encrypted with an old
+// key. It is used after enabling encryption or adding a new key. With dryRun, the users are only counted.
+func (dbService *UserDBService) ReencryptUsers(ctx context.Context, instanceID string, dryRun bool) (count int, err error) {
+	if dbService.crypt == nil {
+		return 0, nil
+	}
+
+	batchSize := int32(32)
+	opts := options.FindOptions{
+		NoCursorTimeout: &dbService.noCursorTimeout,
+		BatchSize:       &batchSize,
+	}
+	cur, err := dbService.collectionRefUsers(instanceID).Find(ctx, bson.M{}, &opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		stored := userDoc{}
+		if err := cur.Decode(&stored); err != nil {
+			logger.Error.Printf("wrong user model %v, %v", stored, err)
+			continue
+		}
+		user, err := dbService.decodeUser(cur)
+		if err != nil {
+			return count, err
+		}
+		if !dbService.needsReencryption(stored, user) {
+			continue
+		}
+
+		count++
+		if dryRun {
+			continue
+		}
+		// a revision conflict means the user was saved concurrently, and is already encrypted with the current key
+		if _, err := dbService._updateUserInDB(ctx, instanceID, user); err != nil && !IsRevisionConflict(err) {
+			return count, err
+		}
+	}
+	return count, cur.Err()
+}
@@ -0,0 +1,135 @@
+package userdb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newEncryptingTestDBService(t *testing.T) *UserDBService {
+	keyring, err := fieldcrypt.NewKeyring(
+		map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}, "k1", bytes.Repeat([]byte{9}, 32),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := *testDBService
+	s.crypt = keyring
+	return &s
+}
+
+func findStoredUser(t *testing.T, id string) userDoc {
+	_id, _ := primitive.ObjectIDFromHex(id)
+	doc := userDoc{}
+	if err := testDBService.collectionRefUsers(testInstanceID).FindOne(context.Background(), bson.M{"_id": _id}).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestFieldEncryption(t *testing.T) {
+	encDBService := newEncryptingTestDBService(t)
+	ctx := context.Background()
+
+	user := models.User{
+		Account: models.Account{
+			Type:      models.ACCOUNT_TYPE_EMAIL,
+			AccountID: "encrypted@test.com",
+		},
+	}
+	user.AddNewEmail("encrypted@test.com", true, 100)
+
+	id, err := encDBService.AddUser(ctx, testInstanceID, user)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	t.Run("stored encrypted", func(t *testing.T) {
+		doc := findStoredUser(t, id)
+		if !fieldcrypt.IsEncrypted(doc.Account.AccountID) || !fieldcrypt.IsEncrypted(doc.ContactInfos[0].Email) {
+			t.Errorf("personal data stored in plaintext: %v", doc)
+		}
+		if doc.AccountIDIndex == "" {
+			t.Error("blind index missing")
+		}
+		if user.ContactInfos[0].Email != "encrypted@test.com" {
+			t.Error("user of the caller should not be modified")
+		}
+	})
+
+	t.Run("find by account ID", func(t *testing.T) {
+		u, err := encDBService.GetUserByAccountID(ctx, testInstanceID, "encrypted@test.com")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if u.Account.AccountID != "encrypted@test.com" || u.ContactInfos[0].Email != "encrypted@test.com" {
+			t.Errorf("user not decrypted: %v", u)
+		}
+	})
+
+	t.Run("duplicate account", func(t *testing.T) {
+		if _, err := encDBService.AddUser(ctx, testInstanceID, user); err == nil {
+			t.Error("user already exists, but was created again")
+		}
+	})
+
+	t.Run("contact infos", func(t *testing.T) {
+		u, err := encDBService.AddContactInfo(ctx, testInstanceID, id, models.NewEmailContactInfo("second@test.com", false, 0))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if len(u.ContactInfos) != 2 || u.ContactInfos[1].Email != "second@test.com" {
+			t.Errorf("unexpected contact infos: %v", u.ContactInfos)
+		}
+		if err := encDBService.SetContactInfoVerificationSent(ctx, testInstanceID, id, "email", "second@test.com"); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if doc := findStoredUser(t, id); doc.ContactInfos[1].ConfirmationLinkSentAt == 0 || !fieldcrypt.IsEncrypted(doc.ContactInfos[1].Email) {
+			t.Errorf("unexpected contact info: %v", doc.ContactInfos[1])
+		}
+		if _, err := encDBService.RemoveContactInfo(ctx, testInstanceID, id, u.ContactInfos[0].ID.Hex()); err == nil {
+			t.Error("main address should not be removable")
+		}
+	})
+
+	t.Run("plaintext user", func(t *testing.T) {
+		plainID, err := testDBService.AddUser(ctx, testInstanceID, models.User{
+			Account: models.Account{Type: models.ACCOUNT_TYPE_EMAIL, AccountID: "plaintext@test.com"},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if _, err := encDBService.GetUserByAccountID(ctx, testInstanceID, "plaintext@test.com"); err != nil {
+			t.Errorf("plaintext user should be found: %s", err.Error())
+		}
+
+		count, err := encDBService.ReencryptUsers(ctx, testInstanceID, true)
+		if err != nil || count < 1 {
+			t.Errorf("unexpected dry-run result: %d, %v", count, err)
+			return
+		}
+		if fieldcrypt.IsEncrypted(findStoredUser(t, plainID).Account.AccountID) {
+			t.Error("dry-run should not modify the user")
+		}
+
+		if _, err := encDBService.ReencryptUsers(ctx, testInstanceID, false); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if doc := findStoredUser(t, plainID); !fieldcrypt.IsEncrypted(doc.Account.AccountID) || doc.AccountIDIndex == "" {
+			t.Errorf("user should be encrypted: %v", doc)
+		}
+		count, err = encDBService.ReencryptUsers(ctx, testInstanceID, true)
+		if err != nil || count != 0 {
+			t.Errorf("all users should be encrypted: %d, %v", count, err)
+		}
+	})
+}
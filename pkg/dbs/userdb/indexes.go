@@ -0,0 +1,38 @@
+package userdb
+
+import (
+	"context"
+
+	"github.com/influenzanet/user-management-service/pkg/dbs/indexes"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var userIndexes = []indexes.Index{
+	{
+		Keys:   bson.D{{Key: "account.accountID", Value: 1}},
+		Unique: true,
+	},
+	// blind index of the encrypted account ID, only set if field encryption is enabled
+	{
+		Keys:   bson.D{{Key: "accountIDIndex", Value: 1}},
+		Unique: true,
+		Sparse: true,
+	},
+	// used for token introspection
+	{
+		Keys:   bson.D{{Key: "account.refreshTokenFamilies.token", Value: 1}},
+		Sparse: true,
+	},
+	// used by the clean-up and reminder of unverified accounts
+	{
+		Keys: bson.D{{Key: "account.accountConfirmedAt", Value: 1}, {Key: "timestamps.createdAt", Value: 1}},
+	},
+}
+
+// EnsureIndexes creates the missing indexes of the instance's user collection. With checkOnly, drift is only reported.
+func (dbService *UserDBService) EnsureIndexes(ctx context.Context, instanceID string, checkOnly bool) (indexes.Report, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	return indexes.Ensure(ctx, dbService.collectionRefUsers(instanceID), userIndexes, checkOnly)
+}
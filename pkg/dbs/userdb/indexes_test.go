@@ -0,0 +1,21 @@
+package userdb
+
+import (
+	"context"
+
+	"testing"
+)
+
+func TestEnsureIndexes(t *testing.T) {
+	if _, err := testDBService.EnsureIndexes(context.Background(), testInstanceID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := testDBService.EnsureIndexes(context.Background(), testInstanceID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HasDrift() {
+		t.Errorf("unexpected drift after creating indexes: %v", r)
+	}
+}
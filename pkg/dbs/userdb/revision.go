@@ -0,0 +1,63 @@
+package userdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxUpdateRetries limits how often UpdateUserWithRetry reloads the user after a conflict
+const maxUpdateRetries = 5
+
+// RevisionConflictError is returned by UpdateUser if the stored user was modified after the given revision was read
+type RevisionConflictError struct {
+	UserID   string
+	Revision int64
+}
+
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("user %s was modified concurrently (revision %d is outdated)", e.UserID, e.Revision)
+}
+
+// IsRevisionConflict checks if the error (or one it wraps) is a RevisionConflictError
+func IsRevisionConflict(err error) bool {
+	var conflict *RevisionConflictError
+	return errors.As(err, &conflict)
+}
+
+// revisionFilter matches the given revision. Documents stored before revisions were introduced have no
+// revision field and count as revision 0.
+func revisionFilter(revision int64) interface{} {
+	if revision == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return revision
+}
+
+// UpdateUserWithRetry applies update to the user and saves it. If the user was modified in the meantime, it is reloaded
+// and update is applied again on the fresh copy, so update must only depend on the user it receives. Errors of update
+// are returned unchanged and nothing is saved in that case. On any error the last loaded copy of the user is returned
+// (with the unsaved changes of update), so callers can still refer to the user.
+func UpdateUserWithRetry(ctx context.Context, dbService UserStore, instanceID string, user models.User, update func(user *models.User) error) (models.User, error) {
+	for attempt := 0; ; attempt++ {
+		if err := update(&user); err != nil {
+			return user, err
+		}
+		updated, err := dbService.UpdateUser(ctx, instanceID, user)
+		if err == nil {
+			return updated, nil
+		}
+		if !IsRevisionConflict(err) || attempt >= maxUpdateRetries {
+			return user, err
+		}
+
+		reloaded, err := dbService.GetUserByID(ctx, instanceID, user.ID.Hex())
+		if err != nil {
+			return user, err
+		}
+		user = reloaded
+	}
+}
@@ -0,0 +1,45 @@
+package userdb
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const userSchemaInfoID = "users"
+
+type schemaInfo struct {
+	ID      string `bson:"_id"`
+	Version int    `bson:"version"`
+}
+
+func (dbService *UserDBService) collectionRefSchemaInfos(instanceID string) *mongo.Collection {
+	return dbService.DBClient.Database(dbService.DBNamePrefix + instanceID + "_users").Collection("schema-infos")
+}
+
+// GetSchemaVersion returns the version of the user documents of the instance, 0 if no migration was applied yet
+func (dbService *UserDBService) GetSchemaVersion(ctx context.Context, instanceID string) (int, error) {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	info := schemaInfo{}
+	err := dbService.collectionRefSchemaInfos(instanceID).FindOne(ctx, bson.M{"_id": userSchemaInfoID}).Decode(&info)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return info.Version, err
+}
+
+func (dbService *UserDBService) SetSchemaVersion(ctx context.Context, instanceID string, version int) error {
+	ctx, cancel := dbService.getContext(ctx)
+	defer cancel()
+
+	upsert := true
+	_, err := dbService.collectionRefSchemaInfos(instanceID).UpdateOne(ctx,
+		bson.M{"_id": userSchemaInfoID},
+		bson.M{"$set": bson.M{"version": version}},
+		&options.UpdateOptions{Upsert: &upsert},
+	)
+	return err
+}
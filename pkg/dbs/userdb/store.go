@@ -0,0 +1,61 @@
+package userdb
+
+import (
+	"context"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// UserStore describes the storage layer for user documents. UserDBService implements it on top of MongoDB,
+// other implementations (e.g. the in-memory store in pkg/dbs/memdb) must follow the same semantics.
+type UserStore interface {
+	AddUser(ctx context.Context, instanceID string, user models.User) (id string, err error)
+	// UpdateUser replaces the stored user. Returns a *RevisionConflictError if the stored revision differs from updatedUser.Revision.
+	UpdateUser(ctx context.Context, instanceID string, updatedUser models.User) (models.User, error)
+	GetUserByID(ctx context.Context, instanceID string, id string) (models.User, error)
+	GetUserByAccountID(ctx context.Context, instanceID string, username string) (models.User, error)
+	// GetUserByRefreshToken finds the user by the current (hashed) token of a refresh token family. Legacy refresh
+	// tokens are not looked up.
+	GetUserByRefreshToken(ctx context.Context, instanceID string, token string) (models.User, error)
+	UpdateUserPassword(ctx context.Context, instanceID string, userID string, newPassword string) error
+	SaveFailedLoginAttempt(ctx context.Context, instanceID string, userID string) error
+	SavePasswordResetTrigger(ctx context.Context, instanceID string, userID string) error
+	UpdateAccountPreferredLang(ctx context.Context, instanceID string, userID string, lang string) (models.User, error)
+	UpdateContactPreferences(ctx context.Context, instanceID string, userID string, prefs models.ContactPreferences) (models.User, error)
+	AddRefreshTokenFamily(ctx context.Context, instanceID string, userID string, family models.RefreshTokenFamily, maxFamilies int) (models.User, error)
+	RenewRefreshToken(ctx context.Context, instanceID string, userID string, oldToken string, rotatedToken string, newToken string, session models.SessionInfo, maxFamilies int) (models.User, error)
+	RemoveAllRefreshTokens(ctx context.Context, instanceID string, userID string) error
+	RemoveLegacyRefreshTokens(ctx context.Context, instanceID string, userID string) error
+	RemoveRefreshTokenFamily(ctx context.Context, instanceID string, userID string, familyID string) error
+	AddRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error)
+	RemoveRole(ctx context.Context, instanceID string, userID string, role string) (models.User, error)
+	AddContactInfo(ctx context.Context, instanceID string, userID string, contactInfo models.ContactInfo) (models.User, error)
+	RemoveContactInfo(ctx context.Context, instanceID string, userID string, contactInfoID string) (models.User, error)
+	SetContactInfoVerificationSent(ctx context.Context, instanceID string, userID string, t string, addr string) error
+	UpdateLoginTime(ctx context.Context, instanceID string, id string) error
+	UpdateReminderToConfirmSentAtTime(ctx context.Context, instanceID string, id string) error
+	CountRecentlyCreatedUsers(ctx context.Context, instanceID string, interval int64) (count int64, err error)
+	DeleteUser(ctx context.Context, instanceID string, id string) error
+	DeleteUnverfiedUsers(ctx context.Context, instanceID string, createdBefore int64) (int64, error)
+	FindNonParticipantUsers(ctx context.Context, instanceID string) (users []models.User, err error)
+	PerfomActionForUsers(
+		ctx context.Context,
+		instanceID string,
+		filters UserFilter,
+		cbk func(instanceID string, user models.User, args ...interface{}) error,
+		args ...interface{},
+	) (err error)
+	SendReminderToConfirmAccountLoop(
+		ctx context.Context,
+		instanceID string,
+		createdBefore int64,
+		cbk func(instanceID string, user models.User, args ...interface{}) error,
+		args ...interface{},
+	) (err error)
+
+	// Schema version of the user documents, used by the migrations
+	GetSchemaVersion(ctx context.Context, instanceID string) (int, error)
+	SetSchemaVersion(ctx context.Context, instanceID string, version int) error
+}
+
+var _ UserStore = &UserDBService{}
@@ -0,0 +1,196 @@
+// Package fieldcrypt encrypts single document fields (e.g. email addresses) before they are stored in the DB.
+// Values are encrypted with AES-256-GCM and tagged with the ID of the key used, so that several keys can be
+// configured during a key rotation. Since encrypted values cannot be queried, a keyed HMAC of the plaintext
+// (blind index) can be stored next to them and used for lookups.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	keyLength         = 32
+	minIndexKeyLength = 32
+	// encrypted values are stored as <prefix><keyID>:<base64(nonce|ciphertext)>
+	prefix = "enc:v1:"
+)
+
+var (
+	// ErrUnknownKey when a value was encrypted with a key that is not configured (anymore)
+	ErrUnknownKey = errors.New("value was encrypted with an unknown key")
+	// ErrInvalidValue when an encrypted value is not in the correct format
+	ErrInvalidValue = errors.New("the encrypted value is not in the correct format")
+)
+
+// Keyring holds the keys for encryption and the blind index. A nil *Keyring means encryption is disabled: values
+// are stored as they are and no blind index is computed.
+type Keyring struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+	indexKey     []byte
+}
+
+// NewKeyring creates a keyring from 32 byte AES keys. New values are encrypted with the key currentKeyID, the other
+// keys are only used to decrypt existing values.
+func NewKeyring(keys map[string][]byte, currentKeyID string, indexKey []byte) (*Keyring, error) {
+	if len(indexKey) < minIndexKeyLength {
+		return nil, fmt.Errorf("index key must have at least %d bytes", minIndexKeyLength)
+	}
+	k, err := newKeyring(keys, currentKeyID)
+	if err != nil {
+		return nil, err
+	}
+	k.indexKey = indexKey
+	return k, nil
+}
+
+func newKeyring(keys map[string][]byte, currentKeyID string) (*Keyring, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("current key %q is not configured", currentKeyID)
+	}
+
+	k := &Keyring{
+		currentKeyID: currentKeyID,
+		keys:         map[string]cipher.AEAD{},
+	}
+	for id, key := range keys {
+		if id == "" || strings.ContainsAny(id, ":,") {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+		if len(key) != keyLength {
+			return nil, fmt.Errorf("key %q must have %d bytes", id, keyLength)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyring creates a keyring from the configuration format: keys is a comma separated list of <id>:<base64 key>,
+// indexKey is base64 encoded. If keys is empty, encryption is disabled and nil is returned.
+func ParseKeyring(keys string, currentKeyID string, indexKey string) (*Keyring, error) {
+	if strings.TrimSpace(keys) == "" {
+		return nil, nil
+	}
+	parsed, err := parseKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	ik, err := base64.StdEncoding.DecodeString(indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("index key: %v", err)
+	}
+	return NewKeyring(parsed, currentKeyID, ik)
+}
+
+// ParseSecretKeyring creates a keyring for secrets that are only decrypted and never looked up, so no index key is
+// needed and BlindIndex must not be used. The keys have the same format as for ParseKeyring, nil is returned if keys
+// is empty.
+func ParseSecretKeyring(keys string, currentKeyID string) (*Keyring, error) {
+	if strings.TrimSpace(keys) == "" {
+		return nil, nil
+	}
+	parsed, err := parseKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	return newKeyring(parsed, currentKeyID)
+}
+
+func parseKeys(keys string) (map[string][]byte, error) {
+	parsed := map[string][]byte{}
+	for _, entry := range strings.Split(keys, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("key entry must have the format <id>:<base64 key>")
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", parts[0], err)
+		}
+		parsed[parts[0]] = key
+	}
+	return parsed, nil
+}
+
+// IsEncrypted checks if the value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt the value with the current key. Empty values stay empty, so that optional fields remain optional.
+func (k *Keyring) Encrypt(value string) (string, error) {
+	if k == nil || value == "" {
+		return value, nil
+	}
+	aead := k.keys[k.currentKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return prefix + k.currentKeyID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt a value produced by Encrypt. Values that are not encrypted (stored before encryption was enabled) are
+// returned unchanged.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if k == nil {
+		return "", errors.New("value is encrypted, but no keys are configured")
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 2)
+	if len(parts) != 2 {
+		return "", ErrInvalidValue
+	}
+	aead, ok := k.keys[parts[0]]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidValue
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// NeedsReencryption is true for values stored in plaintext or encrypted with another than the current key
+func (k *Keyring) NeedsReencryption(value string) bool {
+	if k == nil || value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, prefix+k.currentKeyID+":")
+}
+
+// BlindIndex returns the keyed hash of the value used to look it up. Equal values have equal indexes, so it must only
+// be used for values that need to be unique or searchable. Returns an empty string if encryption is disabled.
+func (k *Keyring) BlindIndex(value string) string {
+	if k == nil || len(k.indexKey) == 0 || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(value))
+	return base64.RawStdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,179 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, keyLength)
+}
+
+func TestKeyring(t *testing.T) {
+	k1, err := NewKeyring(map[string][]byte{"k1": testKey(1)}, "k1", testKey(9))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	k2, err := NewKeyring(map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, "k2", testKey(9))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		enc, err := k1.Encrypt("test@test.com")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if !IsEncrypted(enc) || enc == "test@test.com" {
+			t.Errorf("value not encrypted: %s", enc)
+		}
+		other, _ := k1.Encrypt("test@test.com")
+		if other == enc {
+			t.Error("encrypting twice should use different nonces")
+		}
+		dec, err := k1.Decrypt(enc)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if dec != "test@test.com" {
+			t.Errorf("unexpected value: %s", dec)
+		}
+	})
+
+	t.Run("empty and plaintext values", func(t *testing.T) {
+		enc, _ := k1.Encrypt("")
+		if enc != "" {
+			t.Errorf("empty value should stay empty: %s", enc)
+		}
+		dec, err := k1.Decrypt("plain@test.com")
+		if err != nil || dec != "plain@test.com" {
+			t.Errorf("plaintext should be returned unchanged: %s, %v", dec, err)
+		}
+		if !k1.NeedsReencryption("plain@test.com") {
+			t.Error("plaintext should be reencrypted")
+		}
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		old, _ := k1.Encrypt("test@test.com")
+		if !k2.NeedsReencryption(old) {
+			t.Error("value encrypted with old key should be reencrypted")
+		}
+		dec, err := k2.Decrypt(old)
+		if err != nil || dec != "test@test.com" {
+			t.Errorf("old key should still decrypt: %s, %v", dec, err)
+		}
+		enc, _ := k2.Encrypt(dec)
+		if k2.NeedsReencryption(enc) {
+			t.Error("value encrypted with current key should not be reencrypted")
+		}
+		if _, err := k1.Decrypt(enc); err != ErrUnknownKey {
+			t.Errorf("expected unknown key error, got: %v", err)
+		}
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		enc, _ := k1.Encrypt("test@test.com")
+		tampered := enc[:len(enc)-2] + "AA"
+		if _, err := k1.Decrypt(tampered); err == nil {
+			t.Error("tampered value should not decrypt")
+		}
+		if _, err := k1.Decrypt(prefix + "k1"); err != ErrInvalidValue {
+			t.Errorf("expected invalid value error, got: %v", err)
+		}
+	})
+
+	t.Run("blind index", func(t *testing.T) {
+		a := k1.BlindIndex("test@test.com")
+		if a == "" || a != k2.BlindIndex("test@test.com") {
+			t.Error("blind index should only depend on the index key")
+		}
+		if a == k1.BlindIndex("test2@test.com") {
+			t.Error("different values should have different indexes")
+		}
+		k3, _ := NewKeyring(map[string][]byte{"k1": testKey(1)}, "k1", testKey(8))
+		if a == k3.BlindIndex("test@test.com") {
+			t.Error("index should depend on the index key")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var k *Keyring
+		enc, err := k.Encrypt("test@test.com")
+		if err != nil || enc != "test@test.com" {
+			t.Errorf("value should be unchanged: %s, %v", enc, err)
+		}
+		if k.BlindIndex("test@test.com") != "" || k.NeedsReencryption("test@test.com") {
+			t.Error("disabled keyring should not compute indexes or request reencryption")
+		}
+		encrypted, _ := k1.Encrypt("test@test.com")
+		if _, err := k.Decrypt(encrypted); err == nil {
+			t.Error("encrypted value should not be readable without keys")
+		}
+	})
+}
+
+func TestParseKeyring(t *testing.T) {
+	b64 := func(b []byte) string { return base64.StdEncoding.EncodeToString(b) }
+
+	t.Run("empty config", func(t *testing.T) {
+		k, err := ParseKeyring("", "", "")
+		if err != nil || k != nil {
+			t.Errorf("expected disabled keyring: %v, %v", k, err)
+		}
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		k, err := ParseKeyring("k1:"+b64(testKey(1))+", k2:"+b64(testKey(2)), "k2", b64(testKey(9)))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if len(k.keys) != 2 || k.currentKeyID != "k2" {
+			t.Errorf("unexpected keyring: %v", k)
+		}
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		for _, c := range []struct{ keys, current, index string }{
+			{"k1:" + b64(testKey(1)), "k2", b64(testKey(9))},
+			{"k1:" + b64(testKey(1)[:16]), "k1", b64(testKey(9))},
+			{"k1:" + b64(testKey(1)), "k1", b64(testKey(9)[:8])},
+			{"k1", "k1", b64(testKey(9))},
+			{"k1:notbase64!", "k1", b64(testKey(9))},
+		} {
+			if _, err := ParseKeyring(c.keys, c.current, c.index); err == nil {
+				t.Errorf("should fail for %v", c)
+			}
+		}
+	})
+}
+
+func TestParseSecretKeyring(t *testing.T) {
+	b64 := func(b []byte) string { return base64.StdEncoding.EncodeToString(b) }
+
+	k, err := ParseSecretKeyring("k1:"+b64(testKey(1)), "k1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	enc, err := k.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if dec, err := k.Decrypt(enc); err != nil || dec != "secret" {
+		t.Errorf("unexpected result: %s, %v", dec, err)
+	}
+	if k.BlindIndex("secret") != "" {
+		t.Error("keyring has no index key")
+	}
+
+	if k, err := ParseSecretKeyring("", ""); err != nil || k != nil {
+		t.Errorf("expected disabled keyring: %v, %v", k, err)
+	}
+	if _, err := ParseSecretKeyring("k1:"+b64(testKey(1)), "k2"); err == nil {
+		t.Error("unknown current key should fail")
+	}
+}
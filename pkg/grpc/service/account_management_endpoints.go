@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
 	"strconv"
 	"time"
@@ -10,6 +11,7 @@ import (
 	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
 	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
@@ -32,7 +34,7 @@ func (s *userManagementServer) GetUser(ctx context.Context, req *api.UserReferen
 		return nil, status.Error(codes.PermissionDenied, "not authorized")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.UserId)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "not found")
 	}
@@ -48,7 +50,7 @@ func (s *userManagementServer) ChangePassword(ctx context.Context, req *api.Pass
 		return nil, status.Error(codes.InvalidArgument, "new password too weak")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid user and/or password")
 	}
@@ -64,12 +66,17 @@ func (s *userManagementServer) ChangePassword(ctx context.Context, req *api.Pass
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	err = s.userDBservice.UpdateUserPassword(req.Token.InstanceId, req.Token.Id, newHashedPw)
+	err = s.userDBservice.UpdateUserPassword(ctx, req.Token.InstanceId, req.Token.Id, newHashedPw)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	log.Printf("user %s initiated password change", req.Token.Id)
 
+	if err := s.endAllSessionsOfUser(ctx, req.Token.InstanceId, req.Token.Id); err != nil {
+		log.Printf("ChangePassword: %s", err.Error())
+		return nil, status.Error(codes.Internal, "sessions could not be ended")
+	}
+
 	// Trigger message sending
 	_, err = s.clients.MessagingService.SendInstantEmail(ctx, &messageAPI.SendEmailReq{
 		InstanceId:        req.Token.InstanceId,
@@ -84,7 +91,7 @@ func (s *userManagementServer) ChangePassword(ctx context.Context, req *api.Pass
 	// ---
 
 	// remove all temptokens for password reset:
-	if err := s.globalDBService.DeleteAllTempTokenForUser(req.Token.InstanceId, req.Token.Id, constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, req.Token.InstanceId, req.Token.Id, constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
 		log.Printf("ChangePassword: %s", err.Error())
 	}
 
@@ -105,7 +112,7 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 	if !utils.CheckEmailFormat(req.NewEmail) {
 		return nil, status.Error(codes.InvalidArgument, "email not valid")
 	}
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "user not found")
 	}
@@ -117,7 +124,7 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 	}
 
 	// is email address still free to use?
-	_, err = s.userDBservice.GetUserByAccountID(req.Token.InstanceId, req.NewEmail)
+	_, err = s.userDBservice.GetUserByAccountID(ctx, req.Token.InstanceId, req.NewEmail)
 	if err == nil {
 		return nil, status.Error(codes.Internal, "action failed")
 	}
@@ -125,8 +132,7 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 	if user.Account.Type != models.ACCOUNT_TYPE_EMAIL {
 		return nil, status.Error(codes.Internal, "account is not email type")
 	}
-	oldCI, oldFound := user.FindContactInfoByTypeAndAddr("email", user.Account.AccountID)
-	if !oldFound {
+	if _, oldFound := user.FindContactInfoByTypeAndAddr("email", user.Account.AccountID); !oldFound {
 		return nil, status.Error(codes.Internal, "old contact info not found - unexpected error")
 	}
 
@@ -142,9 +148,9 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 				"oldEmail": user.Account.AccountID,
 				"newEmail": req.NewEmail,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 7),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*7, s.clock.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -167,32 +173,48 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 		}
 		// <---
 	}
-	// if old AccountID was not confirmed probably wrong address used in the first place
-	if user.Profiles[0].Alias == user.Account.AccountID {
-		user.Profiles[0].Alias = req.NewEmail
-	}
-	user.Account.AccountID = req.NewEmail
-	user.Account.AccountConfirmedAt = -1
+	oldAccountID := user.Account.AccountID
+	changeAccountID := func(user *models.User) error {
+		oldCI, oldFound := user.FindContactInfoByTypeAndAddr("email", oldAccountID)
+		if !oldFound {
+			return errors.New("old contact info not found - unexpected error")
+		}
 
-	// Add new address to contact list if necessary:
-	ci, found := user.FindContactInfoByTypeAndAddr("email", req.NewEmail)
-	if found {
-		// new email already confirmed
-		if ci.ConfirmedAt > 0 {
-			user.Account.AccountConfirmedAt = ci.ConfirmedAt
+		// if old AccountID was not confirmed probably wrong address used in the first place
+		if user.Profiles[0].Alias == user.Account.AccountID {
+			user.Profiles[0].Alias = req.NewEmail
+		}
+		user.Account.AccountID = req.NewEmail
+		user.Account.AccountConfirmedAt = -1
+
+		// Add new address to contact list if necessary:
+		ci, found := user.FindContactInfoByTypeAndAddr("email", req.NewEmail)
+		if found {
+			// new email already confirmed
+			if ci.ConfirmedAt > 0 {
+				user.Account.AccountConfirmedAt = ci.ConfirmedAt
+			}
+		} else {
+			user.AddNewEmail(req.NewEmail, false, s.clock.Now().Unix())
 		}
-	} else {
-		user.AddNewEmail(req.NewEmail, false)
-	}
 
-	newCI, newFound := user.FindContactInfoByTypeAndAddr("email", req.NewEmail)
-	if !newFound {
-		return nil, status.Error(codes.Internal, "new contact info not found - unexpected error")
+		newCI, newFound := user.FindContactInfoByTypeAndAddr("email", req.NewEmail)
+		if !newFound {
+			return errors.New("new contact info not found - unexpected error")
+		}
+		user.ReplaceContactInfoInContactPreferences(oldCI.ID.Hex(), newCI.ID.Hex())
+
+		if !req.KeepOldEmail {
+			err := user.RemoveContactInfo(oldCI.ID.Hex())
+			if err != nil {
+				log.Println(err.Error())
+			}
+		}
+		return nil
 	}
-	user.ReplaceContactInfoInContactPreferences(oldCI.ID.Hex(), newCI.ID.Hex())
 
 	// start confirmation workflow of necessary:
-	if user.Account.AccountConfirmedAt <= 0 {
+	if ci, found := user.FindContactInfoByTypeAndAddr("email", req.NewEmail); !found || ci.ConfirmedAt <= 0 {
 		// TempToken for contact verification:
 		tempTokenInfos := models.TempToken{
 			UserID:     user.ID.Hex(),
@@ -200,11 +222,11 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 			Purpose:    constants.TOKEN_PURPOSE_CONTACT_VERIFICATION,
 			Info: map[string]string{
 				"type":  "email",
-				"email": user.Account.AccountID,
+				"email": req.NewEmail,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*30, s.clock.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -212,7 +234,7 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 		// ---> Trigger message sending
 		_, err = s.clients.MessagingService.SendInstantEmail(ctx, &messageAPI.SendEmailReq{
 			InstanceId:        req.Token.InstanceId,
-			To:                []string{user.Account.AccountID},
+			To:                []string{req.NewEmail},
 			MessageType:       constants.EMAIL_TYPE_VERIFY_EMAIL,
 			PreferredLanguage: user.Account.PreferredLanguage,
 			ContentInfos: map[string]string{
@@ -225,15 +247,8 @@ func (s *userManagementServer) ChangeAccountIDEmail(ctx context.Context, req *ap
 		// <---
 	}
 
-	if !req.KeepOldEmail {
-		err := user.RemoveContactInfo(oldCI.ID.Hex())
-		if err != nil {
-			log.Println(err.Error())
-		}
-	}
-
 	// Save user:
-	updUser, err := s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	updUser, err := userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, changeAccountID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -255,7 +270,7 @@ func (s *userManagementServer) DeleteAccount(ctx context.Context, req *api.UserR
 	}
 	log.Printf("user %s initiated account removal for user id %s", req.Token.Id, req.UserId)
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.UserId)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -273,12 +288,16 @@ func (s *userManagementServer) DeleteAccount(ctx context.Context, req *api.UserR
 	}
 	// <---
 
-	if err := s.userDBservice.DeleteUser(req.Token.InstanceId, req.UserId); err != nil {
+	if err := s.userDBservice.DeleteUser(ctx, req.Token.InstanceId, req.UserId); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if err := s.revokeAccessTokensOfUser(ctx, req.Token.InstanceId, req.UserId); err != nil {
+		log.Printf("error, when trying to revoke access tokens: %s", err.Error())
+	}
+
 	// remove all TempTokens for the given user ID using auth-service
-	if err := s.globalDBService.DeleteAllTempTokenForUser(req.Token.InstanceId, req.Token.Id, ""); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, req.Token.InstanceId, req.Token.Id, ""); err != nil {
 		log.Printf("error, when trying to remove temp-tokens: %s", err.Error())
 	}
 
@@ -295,7 +314,7 @@ func (s *userManagementServer) ChangePreferredLanguage(ctx context.Context, req
 	if req == nil || utils.IsTokenEmpty(req.Token) || req.LanguageCode == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
-	user, err := s.userDBservice.UpdateAccountPreferredLang(req.Token.InstanceId, req.Token.Id, req.LanguageCode)
+	user, err := s.userDBservice.UpdateAccountPreferredLang(ctx, req.Token.InstanceId, req.Token.Id, req.LanguageCode)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -307,25 +326,29 @@ func (s *userManagementServer) SaveProfile(ctx context.Context, req *api.Profile
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "user not found")
 	}
 
-	if req.Profile.Id == "" {
-		if len(user.Profiles) > maximumProfilesAllowed {
-			s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_PROFILE_SAVED, "too many profiles added"+req.Profile.Alias)
-			return nil, status.Error(codes.Internal, "reached profile limit")
-		}
-		user.AddProfile(models.ProfileFromAPI(req.Profile))
-	} else {
-		err := user.UpdateProfile(models.ProfileFromAPI(req.Profile))
-		if err != nil {
-			return nil, status.Error(codes.Internal, "profile not found")
-		}
+	if req.Profile.Id == "" && len(user.Profiles) > maximumProfilesAllowed {
+		s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_PROFILE_SAVED, "too many profiles added"+req.Profile.Alias)
+		return nil, status.Error(codes.Internal, "reached profile limit")
 	}
 
-	updUser, err := s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	updUser, err := userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		if req.Profile.Id == "" {
+			if len(user.Profiles) > maximumProfilesAllowed {
+				return errors.New("reached profile limit")
+			}
+			user.AddProfile(models.ProfileFromAPI(req.Profile), s.clock.Now().Unix())
+			return nil
+		}
+		if err := user.UpdateProfile(models.ProfileFromAPI(req.Profile)); err != nil {
+			return errors.New("profile not found")
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -340,19 +363,17 @@ func (s *userManagementServer) RemoveProfile(ctx context.Context, req *api.Profi
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "user not found")
 	}
 
-	if len(user.Profiles) == 1 {
-		return nil, status.Error(codes.Internal, "can't delete last profile")
-	}
-
-	if err := user.RemoveProfile(req.Profile.Id); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	updUser, err := s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	updUser, err := userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		if len(user.Profiles) == 1 {
+			return errors.New("can't delete last profile")
+		}
+		return user.RemoveProfile(req.Profile.Id)
+	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -366,7 +387,7 @@ func (s *userManagementServer) UpdateContactPreferences(ctx context.Context, req
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	user, err := s.userDBservice.UpdateContactPreferences(req.Token.InstanceId, req.Token.Id, models.ContactPreferencesFromAPI(req.ContactPreferences))
+	user, err := s.userDBservice.UpdateContactPreferences(ctx, req.Token.InstanceId, req.Token.Id, models.ContactPreferencesFromAPI(req.ContactPreferences))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -377,13 +398,13 @@ func (s *userManagementServer) UseUnsubscribeToken(ctx context.Context, req *api
 	if req == nil || req.Token == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
-	tokenInfos, err := s.ValidateTempToken(req.Token, []string{constants.TOKEN_PURPOSE_UNSUBSCRIBE_NEWSLETTER})
+	tokenInfos, err := s.ValidateTempToken(ctx, req.Token, []string{constants.TOKEN_PURPOSE_UNSUBSCRIBE_NEWSLETTER})
 	if err != nil {
 		log.Printf("UseUnsubscribeToken: %s", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	user, err := s.userDBservice.GetUserByID(tokenInfos.InstanceID, tokenInfos.UserID)
+	user, err := s.userDBservice.GetUserByID(ctx, tokenInfos.InstanceID, tokenInfos.UserID)
 	if err != nil {
 		log.Printf("UseUnsubscribeToken: %s", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -391,7 +412,7 @@ func (s *userManagementServer) UseUnsubscribeToken(ctx context.Context, req *api
 
 	user.ContactPreferences.SubscribedToNewsletter = false
 
-	_, err = s.userDBservice.UpdateContactPreferences(tokenInfos.InstanceID, user.ID.Hex(), user.ContactPreferences)
+	_, err = s.userDBservice.UpdateContactPreferences(ctx, tokenInfos.InstanceID, user.ID.Hex(), user.ContactPreferences)
 	if err != nil {
 		log.Printf("UseUnsubscribeToken: %s", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -416,13 +437,11 @@ func (s *userManagementServer) AddEmail(ctx context.Context, req *api.ContactInf
 		return nil, status.Error(codes.InvalidArgument, "email not valid")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "user not found")
 	}
 
-	user.AddNewEmail(email, false)
-
 	// TempToken for contact verification:
 	tempTokenInfos := models.TempToken{
 		UserID:     user.ID.Hex(),
@@ -433,9 +452,9 @@ func (s *userManagementServer) AddEmail(ctx context.Context, req *api.ContactInf
 			"email": email,
 		},
 
-		Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+		Expiration: tokens.GetExpirationTime(time.Hour*24*30, s.clock.Now()),
 	}
-	tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+	tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -455,7 +474,7 @@ func (s *userManagementServer) AddEmail(ctx context.Context, req *api.ContactInf
 	}
 	// <---
 
-	updUser, err := s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	updUser, err := s.userDBservice.AddContactInfo(ctx, req.Token.InstanceId, user.ID.Hex(), models.NewEmailContactInfo(email, false, s.clock.Now().Unix()))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -467,16 +486,7 @@ func (s *userManagementServer) RemoveEmail(ctx context.Context, req *api.Contact
 	if req == nil || utils.IsTokenEmpty(req.Token) || req.ContactInfo == nil {
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "user not found")
-	}
-
-	err = user.RemoveContactInfo(req.ContactInfo.Id)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	updUser, err := s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	updUser, err := s.userDBservice.RemoveContactInfo(ctx, req.Token.InstanceId, req.Token.Id, req.ContactInfo.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
@@ -20,6 +21,7 @@ func TestGetUserEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -135,6 +137,7 @@ func TestChangePasswordEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -153,9 +156,13 @@ func TestChangePasswordEndpoint(t *testing.T) {
 	// Create Test User
 	testUser := models.User{
 		Account: models.Account{
-			Type:      "email",
-			AccountID: "test-password-change@test.com",
-			Password:  hashedOldPassword,
+			Type:          "email",
+			AccountID:     "test-password-change@test.com",
+			Password:      hashedOldPassword,
+			RefreshTokens: []string{"legacy-refresh-token"},
+			RefreshTokenFamilies: []models.RefreshTokenFamily{
+				models.NewRefreshTokenFamily("refresh-token", models.SessionInfo{}, time.Now().Unix()),
+			},
 		},
 		Roles: []string{"PARTICIPANT"},
 		Profiles: []models.Profile{
@@ -163,7 +170,7 @@ func TestChangePasswordEndpoint(t *testing.T) {
 		},
 	}
 
-	id, err := testUserDBService.AddUser(testInstanceID, testUser)
+	id, err := testUserDBService.AddUser(context.Background(), testInstanceID, testUser)
 	if err != nil {
 		t.Errorf("error creating users for testing pw change")
 		return
@@ -279,6 +286,15 @@ func TestChangePasswordEndpoint(t *testing.T) {
 			t.Errorf("or missing response: %s", resp)
 		}
 
+		u, err := testUserDBService.GetUserByID(context.Background(), testInstanceID, id)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if len(u.Account.RefreshTokens) > 0 || len(u.Account.RefreshTokenFamilies) > 0 {
+			t.Errorf("sessions should be ended: %v, %v", u.Account.RefreshTokens, u.Account.RefreshTokenFamilies)
+		}
+
 		// Check login with new credentials:
 		req2 := &api.LoginWithEmailMsg{
 			Email:      testUser.Account.AccountID,
@@ -308,6 +324,7 @@ func TestChangeAccountIDEmailEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -573,6 +590,7 @@ func TestDeleteAccountEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -667,7 +685,7 @@ func TestDeleteAccountEndpoint(t *testing.T) {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
 		}
-		_, err = testUserDBService.GetUserByID(testInstanceID, testUsers[0].ID.Hex())
+		_, err = testUserDBService.GetUserByID(context.Background(), testInstanceID, testUsers[0].ID.Hex())
 		if err == nil {
 			t.Error("user should not exist")
 		}
@@ -678,6 +696,7 @@ func TestChangePreferredLanguageEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -743,6 +762,7 @@ func TestSaveProfileEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -837,6 +857,7 @@ func TestRemoveProfileEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -944,6 +965,7 @@ func TestUpdateContactPreferencesEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -1011,6 +1033,7 @@ func TestUseUnsubscribeTokenEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -1046,7 +1069,7 @@ func TestUseUnsubscribeTokenEndpoint(t *testing.T) {
 		Purpose:    "unsubscribe-newsletter",
 		Expiration: time.Now().Unix() + 5000000,
 	}
-	unsubscribeToken, err := s.globalDBService.AddTempToken(unsubscribeTokenInfos)
+	unsubscribeToken, err := s.globalDBService.AddTempToken(context.Background(), unsubscribeTokenInfos)
 	if err != nil {
 		t.Errorf("failed to create test token: %s", err.Error())
 		return
@@ -1090,7 +1113,7 @@ func TestUseUnsubscribeTokenEndpoint(t *testing.T) {
 			return
 		}
 
-		user, err := s.userDBservice.GetUserByID(testInstanceID, testUsers[0].ID.Hex())
+		user, err := s.userDBservice.GetUserByID(context.Background(), testInstanceID, testUsers[0].ID.Hex())
 		if err != nil {
 			t.Errorf("unexpected token: %v", err)
 			return
@@ -1109,6 +1132,7 @@ func TestAddEmailEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -1204,6 +1228,7 @@ func TestRemoveEmailEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -14,4 +14,53 @@ const (
 	userCreationTimestampOffset = 7 * 24 * 3600 // consider user deletion only after this time, when created by admin
 
 	maximumProfilesAllowed = 6
+	maximumPasskeysAllowed = 20
+
+	recoveryCodeCount = 10 // number of codes generated at once
+)
+
+const (
+	tokenPurposePasskeyRegistration = "passkey_registration"
+	tokenPurposePasskeyLogin        = "passkey_login"
+	passkeyChallengeLifetime        = 5 * 60 // seconds
+
+	tokenPurposePasswordlessLink = "passwordless_login"
+	tokenPurposePasswordlessCode = "passwordless_login_code"
+	passwordlessLinkLifetime     = 15 * 60 // seconds
+	passwordlessLoginCooldown    = 60      // Minimum delay between 2 login emails, in seconds
+)
+
+// Metadata the gateway sets to describe the client of a session
+const (
+	metadataKeyUserAgent    = "x-user-agent"
+	metadataKeyClientIP     = "x-forwarded-for" // the first address is used
+	metadataKeySessionLabel = "x-session-label"
+)
+
+// Log events that have no constant in go-utils
+const (
+	logEventTOTPEnabled    = "TOTP ENABLED"
+	logEventTOTPDisabled   = "TOTP DISABLED"
+	logEventPasskeyAdded   = "PASSKEY ADDED"
+	logEventPasskeyRemoved = "PASSKEY REMOVED"
+
+	logEventRecoveryCodesGenerated = "RECOVERY CODES GENERATED"
+	logEventRecoveryCodeUsed       = "RECOVERY CODE USED"
+
+	logEventPasswordlessLoginRequested = "PASSWORDLESS LOGIN REQUESTED"
+	logEventSessionRevoked             = "SESSION REVOKED"
+	logEventSessionExpired             = "SESSION EXPIRED"
+)
+
+// Email types that have no constant in go-utils
+const (
+	emailTypeRecoveryCodeUsed   = "recovery-code-used"
+	emailTypeLoginLink          = "login-link"
+	emailTypeRefreshTokenReused = "refresh-token-reused"
+)
+
+// Token types of the introspection (RFC 7662), also accepted as token_type_hint
+const (
+	tokenTypeAccessToken  = "access_token"
+	tokenTypeRefreshToken = "refresh_token"
 )
@@ -12,11 +12,12 @@ func (s *userManagementServer) ValidateAppToken(ctx context.Context, req *api.Ap
 	if req == nil || req.Token == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid app token")
 	}
-	tokenInfos, err := s.globalDBService.FindAppToken(req.Token)
+	tokenInfos, err := s.globalDBService.FindAppToken(ctx, req.Token)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid app token")
 	}
 	return &api.AppTokenValidation{
 		Instances: tokenInfos.Instances,
+		Audiences: tokenInfos.Audiences,
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"google.golang.org/grpc/status"
 )
@@ -14,6 +15,7 @@ func TestValidateAppTokenEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -25,7 +27,7 @@ func TestValidateAppTokenEndpoint(t *testing.T) {
 		Instances: []string{testInstanceID},
 		Tokens:    []string{"test1", "test2"},
 	}
-	err := testGlobalDBService.AddAppToken(appToken)
+	err := testGlobalDBService.AddAppToken(context.Background(), appToken)
 	if err != nil {
 		t.Errorf("unexpected error when creating app token: %s", err.Error())
 		return
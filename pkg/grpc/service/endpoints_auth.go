@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -16,9 +17,11 @@ import (
 	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
 	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/totp"
 	"github.com/influenzanet/user-management-service/pkg/utils"
 
 	constants "github.com/influenzanet/go-utils/pkg/constants"
@@ -42,20 +45,20 @@ func (s *userManagementServer) SendVerificationCode(ctx context.Context, req *ap
 	}
 
 	req.Email = utils.SanitizeEmail(req.Email)
-	user, err := s.userDBservice.GetUserByAccountID(req.InstanceId, req.Email)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, req.Email)
 	if err != nil {
 		log.Printf("SECURITY WARNING: login step 1 attempt with wrong email address for %s", req.Email)
 		return nil, status.Error(codes.InvalidArgument, "invalid username and/or password")
 	}
 
-	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow) {
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
 		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "send verification code endpoint")
 		log.Printf("SECURITY WARNING: login attempt blocked for email address for %s - too many wrong tries recently", user.ID.Hex())
 		time.Sleep(time.Duration(rand.Intn(10)) * time.Second)
 		return nil, status.Error(codes.InvalidArgument, "invalid username and/or password")
 	}
 
-	if user.Account.VerificationCode.CreatedAt > time.Now().Unix()-loginVerificationCodeCooldown {
+	if user.Account.VerificationCode.CreatedAt > s.clock.Now().Unix()-loginVerificationCodeCooldown {
 		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "try resending verification code too often")
 		log.Printf("SECURITY WARNING: resend verification code %s - too many wrong tries recently", req.Email)
 		return nil, status.Error(codes.InvalidArgument, "cannot generate verification code so often")
@@ -64,14 +67,14 @@ func (s *userManagementServer) SendVerificationCode(ctx context.Context, req *ap
 	match, err := pwhash.ComparePasswordWithHash(user.Account.Password, req.Password)
 	if err != nil || !match {
 		log.Printf("SECURITY WARNING: login step 1 attempt with wrong password for %s", user.ID.Hex())
-		if err2 := s.userDBservice.SaveFailedLoginAttempt(req.InstanceId, user.ID.Hex()); err != nil {
+		if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err != nil {
 			log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
 		}
 		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_PASSWORD, "send verification code endpoint")
 		return nil, status.Error(codes.InvalidArgument, "invalid username and/or password")
 	}
 
-	err = s.generateAndSendVerificationCode(req.InstanceId, user)
+	err = s.generateAndSendVerificationCode(ctx, req.InstanceId, user)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +91,7 @@ func (s *userManagementServer) AutoValidateTempToken(ctx context.Context, req *a
 		return nil, status.Error(codes.InvalidArgument, "invalid token")
 	}
 
-	tokenInfos, err := s.ValidateTempToken(req.TempToken,
+	tokenInfos, err := s.ValidateTempToken(ctx, req.TempToken,
 		[]string{
 			constants.TOKEN_PURPOSE_INVITATION,
 			constants.TOKEN_PURPOSE_SURVEY_LOGIN,
@@ -108,14 +111,14 @@ func (s *userManagementServer) AutoValidateTempToken(ctx context.Context, req *a
 		return nil, status.Error(codes.InvalidArgument, "invalid token")
 	}
 
-	user, err := s.userDBservice.GetUserByID(tokenInfos.InstanceID, tokenInfos.UserID)
+	user, err := s.userDBservice.GetUserByID(ctx, tokenInfos.InstanceID, tokenInfos.UserID)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "user not found")
 	}
 
 	sameUser := false
 	if len(req.AccessToken) > 0 {
-		validatedToken, _, err := tokens.ValidateToken(req.AccessToken)
+		validatedToken, _, err := tokens.ValidateToken(s.accessTokens, req.AccessToken, s.clock.Now())
 		if err != nil && !strings.Contains(err.Error(), "token is expired by") {
 			log.Printf("AutoValidateTempToken: unexpected error when parsing token -> %v", err)
 		}
@@ -124,7 +127,7 @@ func (s *userManagementServer) AutoValidateTempToken(ctx context.Context, req *a
 		}
 	}
 
-	if user.Account.VerificationCode.ExpiresAt > time.Now().Unix()+loginVerificationCodeCooldown {
+	if user.Account.VerificationCode.ExpiresAt > s.clock.Now().Unix()+loginVerificationCodeCooldown {
 		log.Printf("AutoValidateTempToken: verification code re-used for %s", user.ID.Hex())
 		return &api.AutoValidateResponse{AccountId: user.Account.AccountID, IsSameUser: sameUser, VerificationCode: user.Account.VerificationCode.Code, InstanceId: tokenInfos.InstanceID}, nil
 	}
@@ -135,20 +138,22 @@ func (s *userManagementServer) AutoValidateTempToken(ctx context.Context, req *a
 		return nil, status.Error(codes.Internal, "error while generating verification code")
 	}
 
-	user.Account.VerificationCode = models.VerificationCode{
-		Code:      vc,
-		ExpiresAt: time.Now().Unix() + s.Intervals.VerificationCodeLifetime,
-	}
-	user, err = s.userDBservice.UpdateUser(tokenInfos.InstanceID, user)
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, tokenInfos.InstanceID, user, func(user *models.User) error {
+		user.Account.VerificationCode = models.VerificationCode{
+			Code:      vc,
+			ExpiresAt: s.clock.Now().Unix() + s.Intervals.VerificationCodeLifetime,
+		}
+		return nil
+	})
 	if err != nil {
 		log.Printf("AutoValidateTempToken: unexpected error when saving user -> %v", err)
 		return nil, status.Error(codes.Internal, "user couldn't be updated")
 	}
 
-	if err := s.globalDBService.DeleteAllTempTokenForUser(tokenInfos.InstanceID, user.ID.Hex(), constants.TOKEN_PURPOSE_INVITATION); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, tokenInfos.InstanceID, user.ID.Hex(), constants.TOKEN_PURPOSE_INVITATION); err != nil {
 		log.Printf("AutoValidateTempToken: %s", err.Error())
 	}
-	if err := s.globalDBService.DeleteAllTempTokenForUser(tokenInfos.InstanceID, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, tokenInfos.InstanceID, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
 		log.Printf("AutoValidateTempToken: %s", err.Error())
 	}
 
@@ -165,18 +170,18 @@ func (s *userManagementServer) LoginWithEmail(ctx context.Context, req *api.Logi
 	}
 
 	req.Email = utils.SanitizeEmail(req.Email)
-	user, err := s.userDBservice.GetUserByAccountID(req.InstanceId, req.Email)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, req.Email)
 	if err != nil {
 		log.Printf("SECURITY WARNING: login attempt with wrong email address for %s", req.Email)
 		s.SaveLogEvent(req.InstanceId, "", loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, req.Email)
 		return nil, status.Error(codes.InvalidArgument, "invalid username and/or password")
 	}
 
-	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow) {
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
 		log.Printf("SECURITY WARNING: login attempt blocked for email address for %s - too many wrong tries recently", req.Email)
 
 		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "")
-		if err2 := s.userDBservice.SaveFailedLoginAttempt(req.InstanceId, user.ID.Hex()); err != nil {
+		if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err != nil {
 			log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
 		}
 		time.Sleep(time.Duration(rand.Intn(10)) * time.Second)
@@ -193,22 +198,23 @@ func (s *userManagementServer) LoginWithEmail(ctx context.Context, req *api.Logi
 	if err != nil || !match {
 		log.Printf("SECURITY WARNING: login attempt with wrong password for %s", user.ID.Hex())
 		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_PASSWORD, "")
-		if err2 := s.userDBservice.SaveFailedLoginAttempt(req.InstanceId, user.ID.Hex()); err != nil {
+		if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err != nil {
 			log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
 		}
 		return nil, status.Error(codes.InvalidArgument, "invalid username and/or password")
 	}
 
-	if user.Account.AuthType == "2FA" {
-		if req.VerificationCode == "" {
+	totpEnabled := user.Account.TOTP.IsEnabled()
+	if user.Account.AuthType == "2FA" || totpEnabled {
+		if req.VerificationCode == "" && req.PasskeyCredential == "" && req.RecoveryCode == "" {
 			// user tries first step
-			if user.Account.VerificationCode.Code == "" || user.Account.VerificationCode.CreatedAt == 0 || user.Account.VerificationCode.ExpiresAt < time.Now().Unix() {
-				if user.Account.VerificationCode.CreatedAt > time.Now().Unix()-loginVerificationCodeCooldown {
+			if user.Account.AuthType == "2FA" && (user.Account.VerificationCode.Code == "" || user.Account.VerificationCode.CreatedAt == 0 || user.Account.VerificationCode.ExpiresAt < s.clock.Now().Unix()) {
+				if user.Account.VerificationCode.CreatedAt > s.clock.Now().Unix()-loginVerificationCodeCooldown {
 					s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "try resending verification code too often")
 					log.Printf("SECURITY WARNING: resend verification code %s - too many wrong tries recently", user.ID.Hex())
 					return nil, status.Error(codes.InvalidArgument, "cannot generate verification code so often")
 				}
-				err = s.generateAndSendVerificationCode(req.InstanceId, user)
+				err = s.generateAndSendVerificationCode(ctx, req.InstanceId, user)
 				if err != nil {
 					log.Printf("login: unexpected error %v", err)
 					return nil, status.Error(codes.InvalidArgument, "code generation error")
@@ -219,33 +225,90 @@ func (s *userManagementServer) LoginWithEmail(ctx context.Context, req *api.Logi
 					Account: &api.User_Account{
 						AccountConfirmedAt: user.Account.AccountConfirmedAt,
 						AccountId:          user.Account.AccountID,
+						TotpEnabled:        totpEnabled,
 					},
 				},
 				SecondFactorNeeded: true,
 			}, nil
 		} else {
-			// user tries second step
-			if user.Account.VerificationCode.ExpiresAt < time.Now().Unix() || user.Account.VerificationCode.Code != req.VerificationCode {
+			// user tries second step, with a passkey, a recovery code, the code from the authenticator app or the one sent by email
+			if req.RecoveryCode != "" {
+				user, err = s.useRecoveryCode(ctx, req.InstanceId, user, req.RecoveryCode)
+				if err != nil {
+					if err != errWrongRecoveryCode {
+						log.Printf("LoginWithEmail: unexpected error when using recovery code -> %v", err)
+					}
+					log.Printf("SECURITY WARNING: login attempt with wrong recovery code for %s", user.ID.Hex())
+					s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "recovery code")
+					if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err2 != nil {
+						log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
+					}
+					return nil, status.Error(codes.InvalidArgument, "wrong verfication code")
+				}
+				s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, logEventRecoveryCodeUsed, fmt.Sprintf("%d codes left", len(user.Account.RecoveryCodes)))
+				go s.sendRecoveryCodeUsedEmail(req.InstanceId, user)
+				return s.loginUser(ctx, req.InstanceId, user, req.AsParticipant)
+			}
+			if req.PasskeyCredential != "" {
+				userID, challenge, resp, err := s.passkeyAssertion(ctx, req.InstanceId, req.PasskeyChallengeId, req.PasskeyCredential)
+				if err == nil && userID != "" && userID != user.ID.Hex() {
+					err = errors.New("passkey belongs to another user")
+				}
+				if err == nil {
+					user, err = s.verifyPasskey(ctx, req.InstanceId, user, challenge, resp, false)
+				}
+				if err != nil {
+					log.Printf("SECURITY WARNING: login attempt with wrong passkey for %s: %v", user.ID.Hex(), err)
+					s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "passkey")
+					if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err2 != nil {
+						log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
+					}
+					return nil, status.Error(codes.InvalidArgument, "wrong verfication code")
+				}
+				return s.loginUser(ctx, req.InstanceId, user, req.AsParticipant)
+			}
+
+			totpAccepted := false
+			if totpEnabled {
+				updated, err := s.useTOTPCode(ctx, req.InstanceId, user, req.VerificationCode, nil)
+				if err == nil {
+					user = updated
+					totpAccepted = true
+				} else if err != totp.ErrInvalidCode {
+					log.Printf("LoginWithEmail: unexpected error when checking TOTP code -> %v", err)
+				}
+			}
+			if !totpAccepted && user.Account.AuthType != "2FA" {
+				log.Printf("SECURITY WARNING: login attempt with wrong TOTP code for %s", user.ID.Hex())
+				s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "TOTP")
+				if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err2 != nil {
+					log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
+				}
+				return nil, status.Error(codes.InvalidArgument, "wrong verfication code")
+			}
+			if !totpAccepted && (user.Account.VerificationCode.ExpiresAt < s.clock.Now().Unix() || user.Account.VerificationCode.Code != req.VerificationCode) {
 				log.Printf("SECURITY WARNING: login attempt with wrong or expired verification code for %s", user.ID.Hex())
 				s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "")
-				if err2 := s.userDBservice.SaveFailedLoginAttempt(req.InstanceId, user.ID.Hex()); err != nil {
+				if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err != nil {
 					log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
 				}
 
 				if user.Account.VerificationCode.Attempts <= allowedVerificationCodeAttempts {
-					user.Account.VerificationCode.Attempts += 1
-					user, err = s.userDBservice.UpdateUser(req.InstanceId, user)
+					user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.InstanceId, user, func(user *models.User) error {
+						user.Account.VerificationCode.Attempts += 1
+						return nil
+					})
 					if err != nil {
 						log.Printf("LoginWithEmail: unexpected error when saving user -> %v", err)
 					}
 					return nil, status.Error(codes.InvalidArgument, "wrong verfication code")
 				} else {
-					if user.Account.VerificationCode.CreatedAt > time.Now().Unix()-loginVerificationCodeCooldown {
+					if user.Account.VerificationCode.CreatedAt > s.clock.Now().Unix()-loginVerificationCodeCooldown {
 						s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "try resending verification code too often")
 						log.Printf("SECURITY WARNING: resend verification code %s - too many wrong tries recently", user.ID.Hex())
 						return nil, status.Error(codes.InvalidArgument, "cannot generate verification code so often")
 					}
-					err = s.generateAndSendVerificationCode(req.InstanceId, user)
+					err = s.generateAndSendVerificationCode(ctx, req.InstanceId, user)
 					if err != nil {
 						log.Printf("login: unexpected error %v", err)
 						return nil, status.Error(codes.InvalidArgument, "code generation error")
@@ -256,85 +319,21 @@ func (s *userManagementServer) LoginWithEmail(ctx context.Context, req *api.Logi
 		}
 	}
 
-	var username string
-	currentRoles := user.Roles
-	if req.AsParticipant {
-		currentRoles = []string{constants.USER_ROLE_PARTICIPANT}
-	} else {
-		if len(user.Roles) > 1 || len(user.Roles) == 1 && user.Roles[0] != constants.USER_ROLE_PARTICIPANT {
-			username = user.Account.AccountID
-		}
-	}
-
-	apiUser := user.ToAPI()
-
-	mainProfileID, otherProfileIDs := utils.GetMainAndOtherProfiles(user)
-
-	// Access Token
-	token, err := tokens.GenerateNewToken(
-		apiUser.Id,
-		apiUser.Account.AccountConfirmedAt > 0,
-		mainProfileID,
-		currentRoles,
-		req.InstanceId,
-		s.Intervals.TokenExpiryInterval,
-		username,
-		nil,
-		otherProfileIDs,
-	)
-	if err != nil {
-		log.Printf("LoginWithEmail: unexpected error during token generation -> %v", err)
-		return nil, status.Error(codes.Internal, "token generation error")
-	}
-
-	// Refresh Token
-	rt, err := tokens.GenerateUniqueTokenString()
-	if err != nil {
-		log.Printf("LoginWithEmail: unexpected error during refresh token generation -> %v", err)
-		return nil, status.Error(codes.Internal, "token generation error")
-	}
-	user.AddRefreshToken(rt)
-	user.Timestamps.LastLogin = time.Now().Unix()
-	user.Account.VerificationCode = models.VerificationCode{}
-	user.Account.FailedLoginAttempts = utils.RemoveAttemptsOlderThan(user.Account.FailedLoginAttempts, 3600)
-	user.Account.PasswordResetTriggers = utils.RemoveAttemptsOlderThan(user.Account.PasswordResetTriggers, 7200)
-
-	user, err = s.userDBservice.UpdateUser(req.InstanceId, user)
-	if err != nil {
-		log.Printf("LoginWithEmail: unexpected error when saving user -> %v", err)
-		return nil, status.Error(codes.Internal, "user couldn't be updated")
-	}
-
-	// remove all temptokens for password reset:
-	if err := s.globalDBService.DeleteAllTempTokenForUser(req.InstanceId, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
-		log.Printf("LoginWithEmail: %s", err.Error())
-	}
-
-	s.SaveLogEvent(req.InstanceId, apiUser.Id, loggingAPI.LogEventType_LOG, constants.LOG_EVENT_LOGIN_SUCCESS, "")
-
-	response := &api.LoginResponse{
-		Token: &api.TokenResponse{
-			AccessToken:       token,
-			RefreshToken:      rt,
-			ExpiresIn:         int32(s.Intervals.TokenExpiryInterval / time.Minute),
-			Profiles:          apiUser.Profiles,
-			SelectedProfileId: mainProfileID,
-			PreferredLanguage: apiUser.Account.PreferredLanguage,
-		},
-		User: user.ToAPI(),
-	}
-	return response, nil
-
+	return s.loginUser(ctx, req.InstanceId, user, req.AsParticipant)
 }
 
 func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *api.LoginWithExternalIDPMsg) (*api.LoginResponse, error) {
-	if req == nil || req.Email == "" || req.InstanceId == "" {
+	if req == nil || req.InstanceId == "" {
 		log.Printf("[ERROR] LoginWithExternalIDP: invalid request - %v", req)
 		return nil, status.Error(codes.InvalidArgument, "invalid request")
 	}
+	ext, err := s.externalLoginFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
 
-	req.Email = utils.SanitizeEmail(req.Email)
-	user, err := s.userDBservice.GetUserByAccountID(req.InstanceId, req.Email)
+	ext.email = utils.SanitizeEmail(ext.email)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, ext.email)
 	if err != nil {
 		// user does not exists - create user
 		randomPW, err := tokens.GenerateUniqueTokenString()
@@ -345,30 +344,30 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 		user = models.User{
 			Account: models.Account{
 				Type:                  models.ACCOUNT_TYPE_EXTERNAL,
-				AccountID:             req.Email,
-				AccountConfirmedAt:    time.Now().Unix(),
+				AccountID:             ext.email,
+				AccountConfirmedAt:    s.clock.Now().Unix(),
 				Password:              randomPW, // not used, just to not leave it empty
 				PreferredLanguage:     "",
 				FailedLoginAttempts:   []int64{},
 				PasswordResetTriggers: []int64{},
 			},
-			Roles: []string{req.Role},
+			Roles: ext.roles,
 			Profiles: []models.Profile{
 				{
 					ID:                 primitive.NewObjectID(),
-					Alias:              utils.BlurEmailAddress(req.Email),
-					ConsentConfirmedAt: time.Now().Unix(),
+					Alias:              utils.BlurEmailAddress(ext.email),
+					ConsentConfirmedAt: s.clock.Now().Unix(),
 					AvatarID:           "default",
 					MainProfile:        true,
 				},
 			},
 			Timestamps: models.Timestamps{
-				CreatedAt: time.Now().Unix(),
+				CreatedAt: s.clock.Now().Unix(),
 			},
 		}
-		user.AddNewEmail(req.Email, false)
+		user.AddNewEmail(ext.email, false, s.clock.Now().Unix())
 
-		user.Account.AuthType = req.Customer
+		user.Account.AuthType = ext.customer
 		user.ContactPreferences.SubscribedToNewsletter = false
 		user.ContactPreferences.SendNewsletterTo = []string{user.ContactInfos[0].ID.Hex()}
 
@@ -376,7 +375,7 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 		user.ContactPreferences.SubscribedToWeekly = false
 		user.ContactPreferences.ReceiveWeeklyMessageDayOfWeek = int32(rand.Intn(7))
 
-		id, err := s.userDBservice.AddUser(req.InstanceId, user)
+		id, err := s.userDBservice.AddUser(ctx, req.InstanceId, user)
 		if err != nil {
 			log.Printf("ERROR: when creating new user: %s", err.Error())
 			return nil, status.Error(codes.Internal, "user creation failed")
@@ -385,18 +384,14 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 
 	} else {
 		if user.Account.Type != models.ACCOUNT_TYPE_EXTERNAL {
-			log.Printf("[ERROR] LoginWithExternalIDP: wrong account type '%s' for %v", user.Account.Type, req)
+			log.Printf("[ERROR] LoginWithExternalIDP: wrong account type '%s' for %s", user.Account.Type, ext.email)
 			s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_ERROR, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, "wrong account type for external login: "+user.Account.Type)
 			return nil, status.Error(codes.PermissionDenied, "wrong account type")
 		}
-
-		if !user.HasRole(req.Role) {
-			user.Roles = append(user.Roles, req.Role)
-		}
 	}
 
 	username := user.Account.AccountID
-	currentRoles := []string{req.Role}
+	currentRoles := ext.roles
 
 	apiUser := user.ToAPI()
 
@@ -404,6 +399,7 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 
 	// Access Token
 	token, err := tokens.GenerateNewToken(
+		s.accessTokens,
 		apiUser.Id,
 		apiUser.Account.AccountConfirmedAt > 0,
 		mainProfileID,
@@ -413,6 +409,7 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 		username,
 		nil,
 		otherProfileIDs,
+		s.clock.Now(),
 	)
 	if err != nil {
 		log.Printf("[ERROR] LoginWithExternalIDP: unexpected error during token generation -> %v", err)
@@ -425,24 +422,18 @@ func (s *userManagementServer) LoginWithExternalIDP(ctx context.Context, req *ap
 		log.Printf("[ERROR] LoginWithExternalIDP: unexpected error during refresh token generation -> %v", err)
 		return nil, status.Error(codes.Internal, "token generation error")
 	}
-	user.AddRefreshToken(rt)
-	user.Timestamps.LastLogin = time.Now().Unix()
-	user.Account.VerificationCode = models.VerificationCode{}
-	user.Account.FailedLoginAttempts = utils.RemoveAttemptsOlderThan(user.Account.FailedLoginAttempts, 3600)
-	user.Account.PasswordResetTriggers = utils.RemoveAttemptsOlderThan(user.Account.PasswordResetTriggers, 7200)
-
-	user, err = s.userDBservice.UpdateUser(req.InstanceId, user)
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.InstanceId, user, s.updateUserAfterLogin(ctx, rt, ext.roles...))
 	if err != nil {
 		log.Printf("[ERROR] LoginWithExternalIDP: unexpected error when saving user -> %v", err)
 		return nil, status.Error(codes.Internal, "user couldn't be updated")
 	}
 
 	// remove all temptokens for password reset:
-	if err := s.globalDBService.DeleteAllTempTokenForUser(req.InstanceId, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, req.InstanceId, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
 		log.Printf("[ERROR] LoginWithExternalIDP: %s", err.Error())
 	}
 
-	msg := fmt.Sprintf("User: %s\nIDP: %s\nGroup info: %s", req.Idp, req.GroupInfo, user.Account.AccountID)
+	msg := fmt.Sprintf("User: %s\nIDP: %s\nGroup info: %s", user.Account.AccountID, ext.idp, ext.groupInfo)
 	s.SaveLogEvent(req.InstanceId, apiUser.Id, loggingAPI.LogEventType_LOG, constants.LOG_EVENT_LOGIN_SUCCESS, msg)
 
 	response := &api.LoginResponse{
@@ -479,7 +470,7 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 		req.InstanceId = "default"
 	}
 
-	newUserCount, err := s.userDBservice.CountRecentlyCreatedUsers(req.InstanceId, signupRateLimitWindow)
+	newUserCount, err := s.userDBservice.CountRecentlyCreatedUsers(ctx, req.InstanceId, signupRateLimitWindow)
 	if err != nil {
 		log.Printf("ERROR: signup - unexpected error when counting: %v", err)
 	} else {
@@ -510,16 +501,16 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 			{
 				ID:                 primitive.NewObjectID(),
 				Alias:              utils.BlurEmailAddress(req.Email),
-				ConsentConfirmedAt: time.Now().Unix(),
+				ConsentConfirmedAt: s.clock.Now().Unix(),
 				AvatarID:           "default",
 				MainProfile:        true,
 			},
 		},
 		Timestamps: models.Timestamps{
-			CreatedAt: time.Now().Unix(),
+			CreatedAt: s.clock.Now().Unix(),
 		},
 	}
-	newUser.AddNewEmail(req.Email, false)
+	newUser.AddNewEmail(req.Email, false, s.clock.Now().Unix())
 	if req.Use_2Fa {
 		newUser.Account.AuthType = "2FA"
 	}
@@ -532,7 +523,7 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 	newUser.ContactPreferences.SubscribedToWeekly = true
 	newUser.ContactPreferences.ReceiveWeeklyMessageDayOfWeek = int32(rand.Intn(7))
 
-	id, err := s.userDBservice.AddUser(req.InstanceId, newUser)
+	id, err := s.userDBservice.AddUser(ctx, req.InstanceId, newUser)
 	if err != nil {
 		log.Printf("ERROR: when creating new user: %s", err.Error())
 		return nil, status.Error(codes.Internal, "user creation failed")
@@ -548,9 +539,9 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 			"type":  models.ACCOUNT_TYPE_EMAIL,
 			"email": newUser.Account.AccountID,
 		},
-		Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+		Expiration: tokens.GetExpirationTime(time.Hour*24*30, s.clock.Now()),
 	}
-	tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+	tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 	if err != nil {
 		log.Printf("ERROR: signup method failed to create verification token: %s", err.Error())
 		return nil, status.Error(codes.Internal, "failed to create verification token")
@@ -581,6 +572,7 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 
 	// Access Token
 	token, err := tokens.GenerateNewToken(
+		s.accessTokens,
 		apiUser.Id,
 		apiUser.Account.AccountConfirmedAt > 0,
 		apiUser.Profiles[0].Id,
@@ -590,6 +582,7 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 		username,
 		nil,
 		[]string{},
+		s.clock.Now(),
 	)
 	if err != nil {
 		log.Printf("ERROR: signup method failed to generate jwt: %s", err.Error())
@@ -602,10 +595,11 @@ func (s *userManagementServer) SignupWithEmail(ctx context.Context, req *api.Sig
 		log.Printf("ERROR: signup method failed to generate refresh token: %s", err.Error())
 		return nil, status.Error(codes.Internal, "token creation failed")
 	}
-	newUser.AddRefreshToken(rt)
-	newUser.Timestamps.LastLogin = time.Now().Unix()
-
-	newUser, err = s.userDBservice.UpdateUser(req.InstanceId, newUser)
+	newUser, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.InstanceId, newUser, func(user *models.User) error {
+		user.AddRefreshTokenFamily(models.NewRefreshTokenFamily(s.hashRefreshToken(rt), sessionInfoFromContext(ctx), s.clock.Now().Unix()), s.refreshTokens.MaxSessionsPerUser())
+		user.Timestamps.LastLogin = s.clock.Now().Unix()
+		return nil
+	})
 	if err != nil {
 		log.Printf("ERROR: signup method failed to save refresh token: %s", err.Error())
 		return nil, status.Error(codes.Internal, "user created, but token could not be saved")
@@ -629,7 +623,7 @@ func (s *userManagementServer) VerifyContact(ctx context.Context, req *api.TempT
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	tokenInfos, err := s.ValidateTempToken(req.Token, []string{
+	tokenInfos, err := s.ValidateTempToken(ctx, req.Token, []string{
 		constants.TOKEN_PURPOSE_CONTACT_VERIFICATION,
 		constants.TOKEN_PURPOSE_INVITATION,
 	})
@@ -638,7 +632,7 @@ func (s *userManagementServer) VerifyContact(ctx context.Context, req *api.TempT
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	user, err := s.userDBservice.GetUserByID(tokenInfos.InstanceID, tokenInfos.UserID)
+	user, err := s.userDBservice.GetUserByID(ctx, tokenInfos.InstanceID, tokenInfos.UserID)
 	if err != nil {
 		log.Printf("VerifyContact: %s", err.Error())
 		return nil, status.Error(codes.InvalidArgument, "no user found")
@@ -650,15 +644,20 @@ func (s *userManagementServer) VerifyContact(ctx context.Context, req *api.TempT
 		return nil, status.Error(codes.InvalidArgument, "missing token info")
 	}
 
-	if err := user.ConfirmContactInfo(cType, email); err != nil {
-		log.Printf("VerifyContact: %s", err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	if _, found := user.FindContactInfoByTypeAndAddr(cType, email); !found {
+		log.Printf("VerifyContact: contact not found")
+		return nil, status.Error(codes.InvalidArgument, "contact not found")
 	}
 
-	if user.Account.Type == models.ACCOUNT_TYPE_EMAIL && user.Account.AccountID == email {
-		user.Account.AccountConfirmedAt = time.Now().Unix()
-	}
-	user, err = s.userDBservice.UpdateUser(tokenInfos.InstanceID, user)
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, tokenInfos.InstanceID, user, func(user *models.User) error {
+		if err := user.ConfirmContactInfo(cType, email, s.clock.Now().Unix()); err != nil {
+			return err
+		}
+		if user.Account.Type == models.ACCOUNT_TYPE_EMAIL && user.Account.AccountID == email {
+			user.Account.AccountConfirmedAt = s.clock.Now().Unix()
+		}
+		return nil
+	})
 
 	s.SaveLogEvent(tokenInfos.InstanceID, tokenInfos.UserID, loggingAPI.LogEventType_LOG, constants.LOG_EVENT_CONTACT_VERIFIED, email)
 	return user.ToAPI(), err
@@ -669,7 +668,7 @@ func (s *userManagementServer) ResendContactVerification(ctx context.Context, re
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		log.Printf("ResendContactVerification: %s", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -680,7 +679,7 @@ func (s *userManagementServer) ResendContactVerification(ctx context.Context, re
 		return nil, status.Error(codes.InvalidArgument, "address not found")
 	}
 
-	if ci.ConfirmationLinkSentAt > time.Now().Unix()-contactVerificationMessageCooldown {
+	if ci.ConfirmationLinkSentAt > s.clock.Now().Unix()-contactVerificationMessageCooldown {
 		return nil, status.Error(codes.InvalidArgument, "cannot send verification so often")
 	}
 
@@ -693,9 +692,9 @@ func (s *userManagementServer) ResendContactVerification(ctx context.Context, re
 			"type":  models.ACCOUNT_TYPE_EMAIL,
 			"email": ci.Email,
 		},
-		Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+		Expiration: tokens.GetExpirationTime(time.Hour*24*30, s.clock.Now()),
 	}
-	tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+	tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -716,8 +715,7 @@ func (s *userManagementServer) ResendContactVerification(ctx context.Context, re
 	// <---
 
 	// update last verification email sent time:
-	user.SetContactInfoVerificationSent("email", req.Address)
-	_, err = s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	err = s.userDBservice.SetContactInfoVerificationSent(ctx, req.Token.InstanceId, req.Token.Id, "email", req.Address)
 	if err != nil {
 		log.Printf("ResendContactVerification: %s", err.Error())
 	}
@@ -2,14 +2,25 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/mock/gomock"
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
 	"github.com/influenzanet/go-utils/pkg/constants"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/oidc"
 	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
@@ -27,6 +38,7 @@ func TestSendVerificationCode(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		clients: &models.APIClients{
 			MessagingService: mockMessagingClient,
 			LoggingService:   mockLoggingClient,
@@ -60,7 +72,7 @@ func TestSendVerificationCode(t *testing.T) {
 		},
 	}
 
-	_, err = testUserDBService.AddUser(testInstanceID, testUser)
+	_, err = testUserDBService.AddUser(context.Background(), testInstanceID, testUser)
 	if err != nil {
 		t.Errorf("unexpected error while creating user: %v", err)
 		return
@@ -117,6 +129,7 @@ func TestAutoValidateTempToken(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -145,7 +158,7 @@ func TestAutoValidateTempToken(t *testing.T) {
 		},
 	}
 
-	id, err := testUserDBService.AddUser(testInstanceID, testUser)
+	id, err := testUserDBService.AddUser(context.Background(), testInstanceID, testUser)
 	if err != nil {
 		t.Errorf("error creating user for testing login")
 		return
@@ -157,7 +170,7 @@ func TestAutoValidateTempToken(t *testing.T) {
 	}
 
 	// add temp token with correct purpose not expired
-	token1, err := s.globalDBService.AddTempToken(models.TempToken{
+	token1, err := s.globalDBService.AddTempToken(context.Background(), models.TempToken{
 		InstanceID: testInstanceID,
 		UserID:     testUser.ID.Hex(),
 		Expiration: time.Now().Unix() + 20,
@@ -169,7 +182,7 @@ func TestAutoValidateTempToken(t *testing.T) {
 	}
 
 	// add temp token with correct purpose expired
-	token2, err := s.globalDBService.AddTempToken(models.TempToken{
+	token2, err := s.globalDBService.AddTempToken(context.Background(), models.TempToken{
 		InstanceID: testInstanceID,
 		UserID:     testUser.ID.Hex(),
 		Expiration: time.Now().Unix() - 20,
@@ -181,7 +194,7 @@ func TestAutoValidateTempToken(t *testing.T) {
 	}
 
 	// add temp token with wrong purpose not expired
-	token3, err := s.globalDBService.AddTempToken(models.TempToken{
+	token3, err := s.globalDBService.AddTempToken(context.Background(), models.TempToken{
 		InstanceID: testInstanceID,
 		UserID:     testUser.ID.Hex(),
 		Expiration: time.Now().Unix() + 20,
@@ -259,7 +272,8 @@ func TestAutoValidateTempToken(t *testing.T) {
 
 	t.Run("correct temptoken with access token same user", func(t *testing.T) {
 		accessToken, err := tokens.GenerateNewToken(
-			testUser.ID.Hex(), true, "profid", []string{}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{},
+			s.accessTokens,
+			testUser.ID.Hex(), true, "profid", []string{}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now(),
 		)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
@@ -286,7 +300,8 @@ func TestAutoValidateTempToken(t *testing.T) {
 
 	t.Run("correct temptoken with access token different user", func(t *testing.T) {
 		accessToken, err := tokens.GenerateNewToken(
-			"different", true, "profid", []string{}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{},
+			s.accessTokens,
+			"different", true, "profid", []string{}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now(),
 		)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
@@ -320,6 +335,7 @@ func TestLogin(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -351,7 +367,7 @@ func TestLogin(t *testing.T) {
 		},
 	}
 
-	id, err := testUserDBService.AddUser(testInstanceID, testUser1)
+	id, err := testUserDBService.AddUser(context.Background(), testInstanceID, testUser1)
 	if err != nil {
 		t.Errorf("error creating user for testing login")
 		return
@@ -381,7 +397,7 @@ func TestLogin(t *testing.T) {
 		},
 	}
 
-	id, err = testUserDBService.AddUser(testInstanceID, testUser2)
+	id, err = testUserDBService.AddUser(context.Background(), testInstanceID, testUser2)
 	if err != nil {
 		t.Errorf("error creating user 2 for testing login")
 		return
@@ -510,7 +526,14 @@ func TestLogin(t *testing.T) {
 		}
 	})
 
-	_, err = testUserDBService.UpdateUser(testInstanceID, testUser2)
+	// failed attempts were saved in the meantime
+	current, err := testUserDBService.GetUserByID(context.Background(), testInstanceID, testUser2.ID.Hex())
+	if err != nil {
+		t.Errorf("error reading user 2 for testing login")
+		return
+	}
+	testUser2.Revision = current.Revision
+	_, err = testUserDBService.UpdateUser(context.Background(), testInstanceID, testUser2)
 	if err != nil {
 		t.Errorf("error updating user 2 for testing login")
 		return
@@ -585,6 +608,7 @@ func TestSignupWithEmail(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -718,6 +742,7 @@ func TestVerifyAccountEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -793,9 +818,9 @@ func TestVerifyAccountEndpoint(t *testing.T) {
 				"type":  "email",
 				"email": testUsers[0].Account.AccountID,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*30, time.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(context.Background(), tempTokenInfos)
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
@@ -825,9 +850,9 @@ func TestVerifyAccountEndpoint(t *testing.T) {
 				"type":  "email",
 				"email": testUsers[0].ContactInfos[1].Email,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*30, time.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(context.Background(), tempTokenInfos)
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
@@ -864,9 +889,9 @@ func TestVerifyAccountEndpoint(t *testing.T) {
 				"type":  "email",
 				"email": testUsers[0].Account.AccountID,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*30, time.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(context.Background(), tempTokenInfos)
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
@@ -898,6 +923,7 @@ func TestResendContactVerificationEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -986,3 +1012,165 @@ func TestResendContactVerificationEndpoint(t *testing.T) {
 		}
 	})
 }
+
+func TestLoginWithExternalIDP(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "external-idp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	jwks := fmt.Sprintf(`{"keys": [{"kty": "RSA", "kid": "k1", "n": "%s", "e": "%s"}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	)
+	if err := ioutil.WriteFile(jwksFile, []byte(jwks), 0600); err != nil {
+		t.Fatal(err)
+	}
+	providers, err := oidc.ParseProviders([]byte(fmt.Sprintf(`{"%s": [{
+		"name": "test-idp", "issuer": "https://idp.example.com", "audiences": ["user-management"], "jwksFile": "%s",
+		"groupRoles": {"admins": "ADMIN"}, "customer": "test-customer"
+	}]}`, testInstanceID, jwksFile)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Minute,
+			VerificationCodeLifetime: 60,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		externalIDPs: oidc.Config{Providers: providers},
+	}
+
+	idToken := func(email string, groups []string, nonce string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            "https://idp.example.com",
+			"aud":            "user-management",
+			"sub":            "ext-" + email,
+			"email":          email,
+			"email_verified": true,
+			"groups":         groups,
+			"nonce":          nonce,
+			"exp":            time.Now().Add(5 * time.Minute).Unix(),
+		})
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	t.Run("without ID token", func(t *testing.T) {
+		_, err := s.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			Email:      "external-trusted@test.com",
+			Role:       "ADMIN",
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid request"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("with valid ID token", func(t *testing.T) {
+		resp, err := s.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			IdToken:    idToken("External-Admin@test.com", []string{"staff", "admins"}, "n1"),
+			Nonce:      "n1",
+			Role:       "SHOULD-BE-IGNORED",
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		parsed, _, err := tokens.ValidateToken(s.accessTokens, resp.Token.AccessToken, time.Now())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if roles := tokens.GetRolesFromPayload(parsed.Payload); len(roles) != 1 || roles[0] != "ADMIN" {
+			t.Errorf("unexpected roles: %v", roles)
+		}
+		user, err := testUserDBService.GetUserByAccountID(context.Background(), testInstanceID, "external-admin@test.com")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if user.Account.Type != models.ACCOUNT_TYPE_EXTERNAL || user.Account.AuthType != "test-customer" || !user.HasRole("ADMIN") || user.HasRole("SHOULD-BE-IGNORED") {
+			t.Errorf("unexpected user: %v", user)
+		}
+	})
+
+	t.Run("with wrong nonce", func(t *testing.T) {
+		_, err := s.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			IdToken:    idToken("external-admin@test.com", []string{"admins"}, "n1"),
+			Nonce:      "n2",
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid ID token"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("with unknown IdP", func(t *testing.T) {
+		_, err := s.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			Idp:        "other-idp",
+			IdToken:    idToken("external-admin@test.com", []string{"admins"}, "n1"),
+			Nonce:      "n1",
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid ID token"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("without role for the groups", func(t *testing.T) {
+		_, err := s.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			IdToken:    idToken("external-staff@test.com", []string{"staff"}, "n1"),
+			Nonce:      "n1",
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "no role for external user"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("trusted caller", func(t *testing.T) {
+		trusted := s
+		trusted.externalIDPs.TrustCaller = true
+		resp, err := trusted.LoginWithExternalIDP(context.Background(), &api.LoginWithExternalIDPMsg{
+			InstanceId: testInstanceID,
+			Email:      "external-trusted@test.com",
+			Role:       "RESEARCHER",
+			Idp:        "legacy-idp",
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		parsed, _, err := tokens.ValidateToken(s.accessTokens, resp.Token.AccessToken, time.Now())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if roles := tokens.GetRolesFromPayload(parsed.Payload); len(roles) != 1 || roles[0] != "RESEARCHER" {
+			t.Errorf("unexpected roles: %v", roles)
+		}
+	})
+}
@@ -4,30 +4,38 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	constants "github.com/influenzanet/go-utils/pkg/constants"
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
 	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func (s *userManagementServer) generateAndSendVerificationCode(instanceID string, user models.User) error {
+func (s *userManagementServer) generateAndSendVerificationCode(ctx context.Context, instanceID string, user models.User) error {
 	vc, err := tokens.GenerateVerificationCode(6)
 	if err != nil {
 		log.Printf("unexpected error while generating verification code: %v", err)
 		return status.Error(codes.Internal, "error while generating verification code")
 	}
 
-	user.Account.VerificationCode = models.VerificationCode{
-		Code:      vc,
-		Attempts:  0,
-		CreatedAt: time.Now().Unix(),
-		ExpiresAt: time.Now().Unix() + s.Intervals.VerificationCodeLifetime,
-	}
-	user, err = s.userDBservice.UpdateUser(instanceID, user)
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, instanceID, user, func(user *models.User) error {
+		user.Account.VerificationCode = models.VerificationCode{
+			Code:      vc,
+			Attempts:  0,
+			CreatedAt: s.clock.Now().Unix(),
+			ExpiresAt: s.clock.Now().Unix() + s.Intervals.VerificationCodeLifetime,
+		}
+		return nil
+	})
 	if err != nil {
 		log.Printf("generateAndSendVerificationCode: unexpected error when saving user -> %v", err)
 		return status.Error(codes.Internal, "user couldn't be updated")
@@ -40,6 +48,114 @@ func (s *userManagementServer) generateAndSendVerificationCode(instanceID string
 	return nil
 }
 
+// updateUserAfterLogin returns the update for a successful login: a session with the hashed refresh token is started for
+// the client described in ctx, the verification code is consumed and old rate limiting entries are removed. Roles that
+// are not empty are added to the user if missing.
+func (s *userManagementServer) updateUserAfterLogin(ctx context.Context, refreshToken string, roles ...string) func(user *models.User) error {
+	session := models.NewRefreshTokenFamily(s.hashRefreshToken(refreshToken), sessionInfoFromContext(ctx), s.clock.Now().Unix())
+	return func(user *models.User) error {
+		for _, role := range roles {
+			if role != "" && !user.HasRole(role) {
+				user.Roles = append(user.Roles, role)
+			}
+		}
+		user.AddRefreshTokenFamily(session, s.refreshTokens.MaxSessionsPerUser())
+		user.Timestamps.LastLogin = s.clock.Now().Unix()
+		user.Account.VerificationCode = models.VerificationCode{}
+		user.Account.FailedLoginAttempts = utils.RemoveAttemptsOlderThan(user.Account.FailedLoginAttempts, 3600, s.clock.Now().Unix())
+		user.Account.PasswordResetTriggers = utils.RemoveAttemptsOlderThan(user.Account.PasswordResetTriggers, 7200, s.clock.Now().Unix())
+		return nil
+	}
+}
+
+// checkPasswordAgain asks the logged in user for the password before a security relevant change of the account. Wrong
+// passwords count as failed login attempts. The returned error can be passed on as response.
+func (s *userManagementServer) checkPasswordAgain(ctx context.Context, instanceID string, user models.User, password string, endpoint string) error {
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
+		s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, endpoint)
+		return status.Error(codes.InvalidArgument, "invalid user and/or password")
+	}
+
+	match, err := pwhash.ComparePasswordWithHash(user.Account.Password, password)
+	if err != nil || !match {
+		s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_PASSWORD, endpoint)
+		if err := s.userDBservice.SaveFailedLoginAttempt(ctx, instanceID, user.ID.Hex()); err != nil {
+			log.Printf("DB ERROR: unexpected error when updating user: %s ", err.Error())
+		}
+		return status.Error(codes.InvalidArgument, "invalid user and/or password")
+	}
+	return nil
+}
+
+// loginUser issues the access and refresh token for a user who was authenticated by LoginWithEmail or
+// LoginWithPasskey
+func (s *userManagementServer) loginUser(ctx context.Context, instanceID string, user models.User, asParticipant bool) (*api.LoginResponse, error) {
+	var username string
+	currentRoles := user.Roles
+	if asParticipant {
+		currentRoles = []string{constants.USER_ROLE_PARTICIPANT}
+	} else {
+		if len(user.Roles) > 1 || len(user.Roles) == 1 && user.Roles[0] != constants.USER_ROLE_PARTICIPANT {
+			username = user.Account.AccountID
+		}
+	}
+
+	apiUser := user.ToAPI()
+
+	mainProfileID, otherProfileIDs := utils.GetMainAndOtherProfiles(user)
+
+	// Access Token
+	token, err := tokens.GenerateNewToken(
+		s.accessTokens,
+		apiUser.Id,
+		apiUser.Account.AccountConfirmedAt > 0,
+		mainProfileID,
+		currentRoles,
+		instanceID,
+		s.Intervals.TokenExpiryInterval,
+		username,
+		nil,
+		otherProfileIDs,
+		s.clock.Now(),
+	)
+	if err != nil {
+		log.Printf("login: unexpected error during token generation -> %v", err)
+		return nil, status.Error(codes.Internal, "token generation error")
+	}
+
+	// Refresh Token
+	rt, err := tokens.GenerateUniqueTokenString()
+	if err != nil {
+		log.Printf("login: unexpected error during refresh token generation -> %v", err)
+		return nil, status.Error(codes.Internal, "token generation error")
+	}
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, instanceID, user, s.updateUserAfterLogin(ctx, rt, ""))
+	if err != nil {
+		log.Printf("login: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	// remove all temptokens for password reset:
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, instanceID, user.ID.Hex(), constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
+		log.Printf("login: %s", err.Error())
+	}
+
+	s.SaveLogEvent(instanceID, apiUser.Id, loggingAPI.LogEventType_LOG, constants.LOG_EVENT_LOGIN_SUCCESS, "")
+
+	response := &api.LoginResponse{
+		Token: &api.TokenResponse{
+			AccessToken:       token,
+			RefreshToken:      rt,
+			ExpiresIn:         int32(s.Intervals.TokenExpiryInterval / time.Minute),
+			Profiles:          apiUser.Profiles,
+			SelectedProfileId: mainProfileID,
+			PreferredLanguage: apiUser.Account.PreferredLanguage,
+		},
+		User: user.ToAPI(),
+	}
+	return response, nil
+}
+
 func (s *userManagementServer) sendVerificationEmail(instanceID string, accountID string, code string, preferredLang string) {
 	if s.clients.MessagingService == nil {
 		return
@@ -57,3 +173,53 @@ func (s *userManagementServer) sendVerificationEmail(instanceID string, accountI
 		log.Printf("SendVerificationCode: %s", err.Error())
 	}
 }
+
+// externalLogin is the user of a LoginWithExternalIDP request
+type externalLogin struct {
+	email     string
+	roles     []string
+	customer  string
+	idp       string
+	groupInfo string
+}
+
+// externalLoginFromRequest verifies the ID token of the request with the identity providers of the instance. Only if
+// the caller is trusted and sends no ID token, email, role and IdP are taken from the request as they are.
+func (s *userManagementServer) externalLoginFromRequest(req *api.LoginWithExternalIDPMsg) (externalLogin, error) {
+	if req.IdToken == "" {
+		if !s.externalIDPs.TrustCaller || req.Email == "" {
+			return externalLogin{}, status.Error(codes.InvalidArgument, "invalid request")
+		}
+		return externalLogin{
+			email:     req.Email,
+			roles:     []string{req.Role},
+			customer:  req.Customer,
+			idp:       req.Idp,
+			groupInfo: req.GroupInfo,
+		}, nil
+	}
+
+	provider, err := s.externalIDPs.Provider(req.InstanceId, req.Idp, req.IdToken)
+	if err != nil {
+		log.Printf("[ERROR] LoginWithExternalIDP: %v for instance %s and IdP '%s'", err, req.InstanceId, req.Idp)
+		return externalLogin{}, status.Error(codes.Unauthenticated, "invalid ID token")
+	}
+	identity, err := provider.Verify(req.IdToken, req.Nonce, s.clock.Now())
+	if err != nil {
+		log.Printf("[ERROR] LoginWithExternalIDP: %v (IdP %s)", err, provider.Name)
+		s.SaveLogEvent(req.InstanceId, "", loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, "invalid ID token of IdP "+provider.Name)
+		return externalLogin{}, status.Error(codes.Unauthenticated, "invalid ID token")
+	}
+	roles := provider.Roles(identity.Groups)
+	if len(roles) < 1 {
+		log.Printf("[ERROR] LoginWithExternalIDP: no role for groups %v of %s (IdP %s)", identity.Groups, identity.Subject, provider.Name)
+		return externalLogin{}, status.Error(codes.PermissionDenied, "no role for external user")
+	}
+	return externalLogin{
+		email:     identity.Email,
+		roles:     roles,
+		customer:  provider.Customer,
+		idp:       provider.Name,
+		groupInfo: strings.Join(identity.Groups, ","),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IntrospectToken tells a client whether an access or refresh token is active and whom it was issued to, following
+// RFC 7662. The client authenticates with its app token; tokens of instances the app has no access to are inactive.
+func (s *userManagementServer) IntrospectToken(ctx context.Context, req *api.IntrospectTokenMsg) (*api.TokenIntrospection, error) {
+	if req == nil || req.AppToken == "" || req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing arguments")
+	}
+	app, err := s.globalDBService.FindAppToken(ctx, req.AppToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid app token")
+	}
+
+	// the hint only decides which type is tried first
+	introspect := []func(context.Context, models.AppToken, string) *api.TokenIntrospection{
+		s.introspectAccessToken,
+		s.introspectRefreshToken,
+	}
+	if req.TokenTypeHint == tokenTypeRefreshToken {
+		introspect[0], introspect[1] = introspect[1], introspect[0]
+	}
+	for _, f := range introspect {
+		if resp := f(ctx, app, req.Token); resp != nil {
+			return resp, nil
+		}
+	}
+	return &api.TokenIntrospection{Active: false}, nil
+}
+
+// introspectAccessToken returns nil if the token is not an active access token for the app
+func (s *userManagementServer) introspectAccessToken(ctx context.Context, app models.AppToken, token string) *api.TokenIntrospection {
+	claims, ok, err := tokens.ValidateToken(s.accessTokens, token, s.clock.Now())
+	if err != nil || !ok {
+		return nil
+	}
+	if !app.HasInstance(claims.InstanceID) || !app.AcceptsAudience(claims.Audience) {
+		return nil
+	}
+	revoked, err := s.globalDBService.IsTokenRevoked(ctx, claims.InstanceID, claims.ID, claims.StandardClaims.Id, claims.IssuedAt)
+	if err != nil {
+		log.Printf("introspect token error: %v", err.Error())
+		return nil
+	}
+	if revoked {
+		return nil
+	}
+
+	return &api.TokenIntrospection{
+		Active:          true,
+		TokenType:       tokenTypeAccessToken,
+		Sub:             claims.ID,
+		InstanceId:      claims.InstanceID,
+		Roles:           tokens.GetRolesFromPayload(claims.Payload),
+		ProfileId:       claims.ProfileID,
+		OtherProfileIds: claims.OtherProfileIDs,
+		Exp:             claims.ExpiresAt,
+		Iat:             claims.IssuedAt,
+		Iss:             claims.Issuer,
+		Aud:             claims.Audience,
+		Jti:             claims.StandardClaims.Id,
+		Username:        tokens.GetUsernameFromPayload(claims.Payload),
+	}
+}
+
+// introspectRefreshToken looks the token up in the instances of the app, returns nil if it is not the current token
+// of an active session. Legacy refresh tokens from before sessions were introduced are reported inactive, they are
+// stored in plaintext and only accepted once by RenewJWT.
+func (s *userManagementServer) introspectRefreshToken(ctx context.Context, app models.AppToken, token string) *api.TokenIntrospection {
+	storedToken := s.hashRefreshToken(token)
+	for _, instanceID := range app.Instances {
+		user, err := s.userDBservice.GetUserByRefreshToken(ctx, instanceID, storedToken)
+		if err != nil {
+			continue
+		}
+		i := user.FindRefreshTokenFamilyByToken(storedToken)
+		if i < 0 {
+			continue
+		}
+		family := user.Account.RefreshTokenFamilies[i]
+		lifetime := s.refreshTokens.SessionLifetimeForRoles(user.Roles)
+		if family.IsExpired(lifetime, s.clock.Now().Unix()) {
+			return nil
+		}
+
+		resp := &api.TokenIntrospection{
+			Active:     true,
+			TokenType:  tokenTypeRefreshToken,
+			Sub:        user.ID.Hex(),
+			InstanceId: instanceID,
+			Roles:      user.Roles,
+			Iat:        family.LastUsedAt,
+			Exp:        family.ExpiresAt(lifetime),
+		}
+		if len(user.Profiles) > 0 {
+			resp.ProfileId, resp.OtherProfileIds = utils.GetMainAndOtherProfiles(user)
+		}
+		return resp
+	}
+	return nil
+}
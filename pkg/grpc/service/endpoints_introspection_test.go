@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/memdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestIntrospectToken(t *testing.T) {
+	fakeClock := clock.NewFake(time.Now())
+	s := &userManagementServer{
+		userDBservice:   memdb.NewUserDBService(fakeClock),
+		globalDBService: testGlobalDBService,
+		clock:           fakeClock,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Minute * 10,
+			VerificationCodeLifetime: 60,
+		},
+		refreshTokens: models.RefreshTokenConfig{
+			HashKey:  []byte("introspection-test-key"),
+			Lifetime: models.SessionLifetime{IdleTimeout: 3600},
+		},
+		accessTokens: models.AccessTokenConfig{Audience: "participants"},
+	}
+	for _, app := range []models.AppToken{
+		{AppName: "introspection-test-app", Instances: []string{testInstanceID}, Tokens: []string{"introspection-app-token"}},
+		{AppName: "introspection-management-app", Instances: []string{testInstanceID}, Tokens: []string{"introspection-management-app-token"}, Audiences: []string{"researchers"}},
+	} {
+		if err := testGlobalDBService.AddAppToken(context.Background(), app); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refreshToken := "introspection-refresh-token"
+	profileID := primitive.NewObjectID()
+	userID, err := s.userDBservice.AddUser(context.Background(), testInstanceID, models.User{
+		Account: models.Account{
+			Type:      models.ACCOUNT_TYPE_EMAIL,
+			AccountID: "introspection@test.com",
+			RefreshTokenFamilies: []models.RefreshTokenFamily{
+				models.NewRefreshTokenFamily(s.hashRefreshToken(refreshToken), models.SessionInfo{}, fakeClock.Now().Unix()),
+			},
+		},
+		Roles:    []string{"PARTICIPANT"},
+		Profiles: []models.Profile{{ID: profileID, MainProfile: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessToken, err := tokens.GenerateNewToken(s.accessTokens, userID, true, profileID.Hex(), []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, fakeClock.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	introspect := func(token string, hint string) (*api.TokenIntrospection, error) {
+		return s.IntrospectToken(context.Background(), &api.IntrospectTokenMsg{AppToken: "introspection-app-token", Token: token, TokenTypeHint: hint})
+	}
+
+	t.Run("missing or wrong app token", func(t *testing.T) {
+		_, err := s.IntrospectToken(context.Background(), &api.IntrospectTokenMsg{Token: accessToken})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "missing arguments"); !ok {
+			t.Error(msg)
+		}
+		_, err = s.IntrospectToken(context.Background(), &api.IntrospectTokenMsg{AppToken: "wrong", Token: accessToken})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid app token"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("access token", func(t *testing.T) {
+		resp, err := introspect(accessToken, "")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !resp.Active || resp.TokenType != tokenTypeAccessToken || resp.Sub != userID || resp.InstanceId != testInstanceID ||
+			resp.ProfileId != profileID.Hex() || len(resp.Roles) != 1 || resp.Jti == "" || resp.Exp != fakeClock.Now().Add(s.Intervals.TokenExpiryInterval).Unix() {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("access token for other audience", func(t *testing.T) {
+		resp, err := s.IntrospectToken(context.Background(), &api.IntrospectTokenMsg{AppToken: "introspection-management-app-token", Token: accessToken})
+		if err != nil || resp.Active {
+			t.Errorf("token should be inactive for the app: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("refresh token with hint", func(t *testing.T) {
+		resp, err := introspect(refreshToken, tokenTypeRefreshToken)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !resp.Active || resp.TokenType != tokenTypeRefreshToken || resp.Sub != userID || resp.ProfileId != profileID.Hex() ||
+			resp.Exp != fakeClock.Now().Unix()+3600 {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("refresh token with wrong hint", func(t *testing.T) {
+		resp, err := introspect(refreshToken, tokenTypeAccessToken)
+		if err != nil || !resp.Active || resp.TokenType != tokenTypeRefreshToken {
+			t.Errorf("unexpected response: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("legacy refresh token", func(t *testing.T) {
+		if _, err := s.userDBservice.AddUser(context.Background(), testInstanceID, models.User{
+			Account: models.Account{
+				Type:          models.ACCOUNT_TYPE_EMAIL,
+				AccountID:     "introspection-legacy@test.com",
+				RefreshTokens: []string{"introspection-legacy-token"},
+			},
+			Roles: []string{"PARTICIPANT"},
+		}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		resp, err := introspect("introspection-legacy-token", tokenTypeRefreshToken)
+		if err != nil || resp.Active {
+			t.Errorf("legacy refresh token should be inactive: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		resp, err := introspect("unknown", "")
+		if err != nil || resp.Active || resp.Sub != "" {
+			t.Errorf("unexpected response: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("over HTTP", func(t *testing.T) {
+		handler := IntrospectionHandler(s)
+		post := func(appToken string, token string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodPost, "/introspect", strings.NewReader(url.Values{"token": {token}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if appToken != "" {
+				r.Header.Set("Authorization", "Bearer "+appToken)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			return w
+		}
+
+		if w := post("", accessToken); w.Code != http.StatusUnauthorized {
+			t.Errorf("unexpected status without app token: %d", w.Code)
+		}
+		if w := post("wrong", accessToken); w.Code != http.StatusUnauthorized {
+			t.Errorf("unexpected status with wrong app token: %d", w.Code)
+		}
+		w := post("introspection-app-token", accessToken)
+		resp := map[string]interface{}{}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+			t.Errorf("unexpected response: %d %s", w.Code, w.Body.String())
+			return
+		}
+		if resp["active"] != true || resp["sub"] != userID || resp["token_type"] != tokenTypeAccessToken {
+			t.Errorf("unexpected response: %v", resp)
+		}
+		if w := post("introspection-app-token", "unknown"); strings.TrimSpace(w.Body.String()) != `{"active":false}` {
+			t.Errorf("unexpected response for inactive token: %s", w.Body.String())
+		}
+	})
+
+	t.Run("inactive after expiry and revocation", func(t *testing.T) {
+		if _, err := s.RevokeAccessToken(context.Background(), &api.JWTRequest{Token: accessToken}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp, err := introspect(accessToken, ""); err != nil || resp.Active {
+			t.Errorf("revoked token should be inactive: %v, %v", resp, err)
+		}
+		fakeClock.Advance(time.Hour * 2)
+		if resp, err := introspect(refreshToken, ""); err != nil || resp.Active {
+			t.Errorf("refresh token of expired session should be inactive: %v, %v", resp, err)
+		}
+	})
+}
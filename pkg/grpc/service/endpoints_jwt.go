@@ -6,10 +6,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	"github.com/influenzanet/user-management-service/pkg/utils"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
 
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
@@ -22,10 +26,28 @@ func (s *userManagementServer) ValidateJWT(ctx context.Context, req *api.JWTRequ
 		return nil, status.Error(codes.InvalidArgument, "missing arguments")
 	}
 	// Parse and validate token
-	parsedToken, ok, err := tokens.ValidateToken(req.Token)
+	parsedToken, ok, err := tokens.ValidateToken(s.accessTokens, req.Token, s.clock.Now())
 	if err != nil || !ok {
 		return nil, status.Error(codes.InvalidArgument, "invalid token")
 	}
+	revoked, err := s.globalDBService.IsTokenRevoked(ctx, parsedToken.InstanceID, parsedToken.ID, parsedToken.StandardClaims.Id, parsedToken.IssuedAt)
+	if err != nil {
+		log.Printf("validate token error: %v", err.Error())
+		return nil, status.Error(codes.Internal, "token revocation could not be checked")
+	}
+	if revoked {
+		return nil, status.Error(codes.InvalidArgument, "invalid token")
+	}
+	if req.AppToken != "" {
+		appToken, err := s.globalDBService.FindAppToken(ctx, req.AppToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid app token")
+		}
+		if !appToken.AcceptsAudience(parsedToken.Audience) {
+			log.Printf("SECURITY WARNING: token with audience %q presented to app %s", parsedToken.Audience, appToken.AppName)
+			return nil, status.Error(codes.InvalidArgument, "invalid token")
+		}
+	}
 
 	return &api_types.TokenInfos{
 		Id:               parsedToken.ID,
@@ -39,39 +61,75 @@ func (s *userManagementServer) ValidateJWT(ctx context.Context, req *api.JWTRequ
 	}, nil
 }
 
+// GetJWKS publishes the public keys of the access tokens, so that other services can verify them without calling
+// ValidateJWT
+func (s *userManagementServer) GetJWKS(ctx context.Context, _ *empty.Empty) (*api.JSONWebKeySet, error) {
+	keys := &api.JSONWebKeySet{Keys: []*api.JSONWebKey{}}
+	for _, k := range tokens.JWKS() {
+		keys.Keys = append(keys.Keys, k.ToAPI())
+	}
+	return keys, nil
+}
+
 func (s *userManagementServer) RenewJWT(ctx context.Context, req *api.RefreshJWTRequest) (*api.TokenResponse, error) {
 	if req == nil || req.AccessToken == "" || req.RefreshToken == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing arguments")
 	}
 
 	// Parse and validate token
-	parsedToken, _, err := tokens.ValidateToken(req.AccessToken)
+	parsedToken, _, err := tokens.ValidateToken(s.accessTokens, req.AccessToken, s.clock.Now())
 	if err != nil && !strings.Contains(err.Error(), "token is expired by") {
 		log.Printf("renew token error: %v", err.Error())
 		return nil, status.Error(codes.PermissionDenied, "wrong access token")
 	}
 
-	user, err := s.userDBservice.GetUserByID(parsedToken.InstanceID, parsedToken.ID)
+	user, err := s.userDBservice.GetUserByID(ctx, parsedToken.InstanceID, parsedToken.ID)
 	if err != nil {
 		log.Printf("renew token error: %v", err.Error())
 		return nil, status.Error(codes.Internal, "user not found")
 	}
 
-	err = user.RemoveRefreshToken(req.RefreshToken)
-	if err != nil {
-		log.Printf("renew token error: %v", err.Error())
+	storedToken := s.storedRefreshToken(user, req.RefreshToken)
+	if storedToken == "" {
+		if familyID, reused := s.findRotatedRefreshToken(user, req.RefreshToken); reused {
+			s.revokeReusedRefreshTokenFamily(ctx, parsedToken.InstanceID, user, familyID)
+			return nil, status.Error(codes.Internal, "wrong refresh token")
+		}
+		log.Printf("renew token error: refresh token not found for user %s", parsedToken.ID)
 		s.SaveLogEvent(parsedToken.InstanceID, parsedToken.ID, loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_TOKEN_REFRESH_FAILED, "wrong refresh token, cannot renew")
 		return nil, status.Error(codes.Internal, "wrong refresh token")
 	}
-	user.Timestamps.LastTokenRefresh = time.Now().Unix()
+	lifetime := s.refreshTokens.SessionLifetimeForRoles(user.Roles)
+	if i := user.FindRefreshTokenFamilyByToken(storedToken); i > -1 {
+		family := user.Account.RefreshTokenFamilies[i]
+		if family.IsExpired(lifetime, s.clock.Now().Unix()) {
+			if err := s.userDBservice.RemoveRefreshTokenFamily(ctx, parsedToken.InstanceID, parsedToken.ID, family.ID); err != nil {
+				log.Printf("renew token error: %v", err.Error())
+			}
+			s.SaveLogEvent(parsedToken.InstanceID, parsedToken.ID, loggingAPI.LogEventType_LOG, logEventSessionExpired, "")
+			return nil, status.Error(codes.Unauthenticated, "session expired")
+		}
+	} else if user.LegacyRefreshTokensExpired(lifetime, s.clock.Now().Unix()) {
+		if err := s.userDBservice.RemoveLegacyRefreshTokens(ctx, parsedToken.InstanceID, parsedToken.ID); err != nil {
+			log.Printf("renew token error: %v", err.Error())
+		}
+		s.SaveLogEvent(parsedToken.InstanceID, parsedToken.ID, loggingAPI.LogEventType_LOG, logEventSessionExpired, "")
+		return nil, status.Error(codes.Unauthenticated, "session expired")
+	}
 
-	roles := tokens.GetRolesFromPayload(parsedToken.Payload)
+	// roles removed since the last token was issued are not granted again
+	roles := []string{}
+	for _, role := range tokens.GetRolesFromPayload(parsedToken.Payload) {
+		if user.HasRole(role) {
+			roles = append(roles, role)
+		}
+	}
 	username := tokens.GetUsernameFromPayload(parsedToken.Payload)
 
 	mainProfileID, otherProfileIDs := utils.GetMainAndOtherProfiles(user)
 
 	// Generate new access token:
-	newToken, err := tokens.GenerateNewToken(parsedToken.ID, user.Account.AccountConfirmedAt > 0, mainProfileID, roles, parsedToken.InstanceID, s.Intervals.TokenExpiryInterval, username, nil, otherProfileIDs)
+	newToken, err := tokens.GenerateNewToken(s.accessTokens, parsedToken.ID, user.Account.AccountConfirmedAt > 0, mainProfileID, roles, parsedToken.InstanceID, s.Intervals.TokenExpiryInterval, username, nil, otherProfileIDs, s.clock.Now())
 	if err != nil {
 		log.Printf("renew token error: %v", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -81,9 +139,13 @@ func (s *userManagementServer) RenewJWT(ctx context.Context, req *api.RefreshJWT
 		log.Printf("renew token error: %v", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	user.AddRefreshToken(newRefreshToken)
 
-	user, err = s.userDBservice.UpdateUser(parsedToken.InstanceID, user)
+	user, err = s.userDBservice.RenewRefreshToken(ctx, parsedToken.InstanceID, parsedToken.ID, storedToken, s.hashRefreshToken(req.RefreshToken), s.hashRefreshToken(newRefreshToken), sessionInfoFromContext(ctx), s.refreshTokens.MaxSessionsPerUser())
+	if err == mongo.ErrNoDocuments {
+		// the refresh token was used by a concurrent request
+		log.Printf("renew token error: refresh token already used for user %s", parsedToken.ID)
+		return nil, status.Error(codes.Internal, "wrong refresh token")
+	}
 	if err != nil {
 		log.Printf("renew token error: %v", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -106,19 +168,122 @@ func (s *userManagementServer) RevokeAllRefreshTokens(ctx context.Context, req *
 		return nil, status.Error(codes.InvalidArgument, "missing arguments")
 	}
 
-	user, err := s.userDBservice.GetUserByID(req.Token.InstanceId, req.Token.Id)
+	err := s.userDBservice.RemoveAllRefreshTokens(ctx, req.Token.InstanceId, req.Token.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "user not found")
 	}
-	user.Account.RefreshTokens = []string{}
+	if err := s.revokeAccessTokensOfUser(ctx, req.Token.InstanceId, req.Token.Id); err != nil {
+		log.Printf("revoke tokens error: %v", err.Error())
+		return nil, status.Error(codes.Internal, "access tokens could not be revoked")
+	}
+	return &api.ServiceStatus{
+		Status:  api.ServiceStatus_NORMAL,
+		Msg:     "refresh tokens revoked",
+		Version: apiVersion,
+	}, nil
+}
 
-	_, err = s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+// RevokeAccessToken revokes the given access token until it expires, e.g. on logout. Other tokens of the user and its
+// refresh tokens stay valid.
+func (s *userManagementServer) RevokeAccessToken(ctx context.Context, req *api.JWTRequest) (*api.ServiceStatus, error) {
+	if req == nil || req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing arguments")
+	}
+	parsedToken, ok, err := tokens.ValidateToken(s.accessTokens, req.Token, s.clock.Now())
+	if err != nil || !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid token")
+	}
+	if parsedToken.StandardClaims.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "token has no id")
+	}
+
+	err = s.globalDBService.AddRevokedToken(ctx, models.RevokedToken{
+		InstanceID: parsedToken.InstanceID,
+		UserID:     parsedToken.ID,
+		TokenID:    parsedToken.StandardClaims.Id,
+		Expiration: parsedToken.ExpiresAt,
+	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, "user not found")
+		log.Printf("revoke token error: %v", err.Error())
+		return nil, status.Error(codes.Internal, "access token could not be revoked")
 	}
 	return &api.ServiceStatus{
 		Status:  api.ServiceStatus_NORMAL,
-		Msg:     "refresh tokens revoked",
+		Msg:     "access token revoked",
 		Version: apiVersion,
 	}, nil
 }
+
+// revokeAccessTokensOfUser revokes all access tokens issued to the user before the current second, tokens issued in
+// the same second can't be told apart from newer ones. Older tokens expire within the token lifetime, so the entry
+// isn't needed after that.
+func (s *userManagementServer) revokeAccessTokensOfUser(ctx context.Context, instanceID string, userID string) error {
+	now := s.clock.Now()
+	return s.globalDBService.AddRevokedToken(ctx, models.RevokedToken{
+		InstanceID:  instanceID,
+		UserID:      userID,
+		IssuedUntil: now.Unix(),
+		Expiration:  now.Add(s.Intervals.TokenExpiryInterval).Unix(),
+	})
+}
+
+// endAllSessionsOfUser logs the user out on all devices: the refresh tokens are removed and the access tokens issued
+// so far revoked. The access token of a request doesn't identify its session, so the current one ends as well.
+func (s *userManagementServer) endAllSessionsOfUser(ctx context.Context, instanceID string, userID string) error {
+	if err := s.userDBservice.RemoveAllRefreshTokens(ctx, instanceID, userID); err != nil {
+		return err
+	}
+	return s.revokeAccessTokensOfUser(ctx, instanceID, userID)
+}
+
+func (s *userManagementServer) hashRefreshToken(token string) string {
+	return tokens.HashRefreshToken(s.refreshTokens.HashKey, token)
+}
+
+// storedRefreshToken returns the form the refresh token is saved in for the user, or an empty string if the user
+// doesn't have it. Tokens saved in plaintext before hashing was introduced are still accepted and replaced by a hashed
+// token when renewed. Only these legacy tokens are compared in plaintext, otherwise a hash from a DB dump would work
+// as refresh token.
+func (s *userManagementServer) storedRefreshToken(user models.User, token string) string {
+	if hashed := s.hashRefreshToken(token); user.HasRefreshToken(hashed) {
+		return hashed
+	}
+	if user.HasLegacyRefreshToken(token) {
+		return token
+	}
+	return ""
+}
+
+// findRotatedRefreshToken looks for the hashed token among the replaced tokens of the families
+func (s *userManagementServer) findRotatedRefreshToken(user models.User, token string) (string, bool) {
+	return user.FindRotatedRefreshToken(s.hashRefreshToken(token))
+}
+
+// revokeReusedRefreshTokenFamily is called if a refresh token is presented after it was already replaced. Either the
+// token was stolen or the legitimate client was, so none of the tokens of the family can be trusted anymore.
+func (s *userManagementServer) revokeReusedRefreshTokenFamily(ctx context.Context, instanceID string, user models.User, familyID string) {
+	log.Printf("SECURITY WARNING: reuse of replaced refresh token for user %s, revoking token family %s", user.ID.Hex(), familyID)
+	if err := s.userDBservice.RemoveRefreshTokenFamily(ctx, instanceID, user.ID.Hex(), familyID); err != nil {
+		log.Printf("renew token error: unexpected error when revoking token family -> %v", err)
+	}
+	s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_TOKEN_REFRESH_FAILED, "replaced refresh token used again, token family revoked")
+
+	if s.refreshTokens.ReuseNotification {
+		go s.sendRefreshTokenReusedEmail(instanceID, user)
+	}
+}
+
+func (s *userManagementServer) sendRefreshTokenReusedEmail(instanceID string, user models.User) {
+	if s.clients.MessagingService == nil {
+		return
+	}
+	_, err := s.clients.MessagingService.SendInstantEmail(context.TODO(), &messageAPI.SendEmailReq{
+		InstanceId:        instanceID,
+		To:                []string{user.Account.AccountID},
+		MessageType:       emailTypeRefreshTokenReused,
+		PreferredLanguage: user.Account.PreferredLanguage,
+	})
+	if err != nil {
+		log.Printf("sendRefreshTokenReusedEmail: %s", err.Error())
+	}
+}
@@ -2,22 +2,31 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	messageMock "github.com/influenzanet/user-management-service/test/mocks/messaging_service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
 )
 
 func TestValidateJWT(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -41,8 +50,9 @@ func TestValidateJWT(t *testing.T) {
 		}
 	})
 
-	adminToken, err1 := tokens.GenerateNewToken("test-admin-id", true, "testprofid", []string{"PARTICIPANT", "ADMIN"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{})
+	adminToken, err1 := tokens.GenerateNewToken(s.accessTokens, "test-admin-id", true, "testprofid", []string{"PARTICIPANT", "ADMIN"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
 	userToken, err2 := tokens.GenerateNewToken(
+		s.accessTokens,
 		"test-user-id",
 		true,
 		"testprofid",
@@ -52,6 +62,7 @@ func TestValidateJWT(t *testing.T) {
 		"",
 		&models.TempToken{UserID: "test-user-id", Purpose: "testpurpose"},
 		[]string{},
+		time.Now(),
 	)
 	if err1 != nil || err2 != nil {
 		t.Errorf("unexpected error: %s or %s", err1, err2)
@@ -125,6 +136,126 @@ func TestValidateJWT(t *testing.T) {
 	})
 }
 
+func TestValidateJWTIssuerAndAudience(t *testing.T) {
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Minute,
+			VerificationCodeLifetime: 60,
+		},
+		accessTokens: models.AccessTokenConfig{Issuer: "https://deployment-a.example.org", Audience: "participants"},
+	}
+
+	t.Run("token of the server", func(t *testing.T) {
+		token, err := tokens.GenerateNewToken(s.accessTokens, "test-user-id", true, "testprofid", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("token for other audience", func(t *testing.T) {
+		other := models.AccessTokenConfig{Issuer: "https://deployment-a.example.org", Audience: "researchers"}
+		token, err := tokens.GenerateNewToken(other, "test-user-id", true, "testprofid", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token})
+		ok, msg := shouldHaveGrpcErrorStatus(err, "invalid token")
+		if !ok {
+			t.Error(msg)
+		}
+	})
+}
+
+func TestValidateJWTForApp(t *testing.T) {
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Minute,
+			VerificationCodeLifetime: 60,
+		},
+		accessTokens: models.AccessTokenConfig{Audience: "participants"},
+	}
+	for _, app := range []models.AppToken{
+		{AppName: "participant-app", Tokens: []string{"participant-app-token"}, Audiences: []string{"participants"}},
+		{AppName: "management-app", Tokens: []string{"management-app-token"}, Audiences: []string{"researchers"}},
+		{AppName: "other-app", Tokens: []string{"other-app-token"}},
+	} {
+		if err := testGlobalDBService.AddAppToken(context.Background(), app); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	token, err := tokens.GenerateNewToken(s.accessTokens, "test-user-id", true, "testprofid", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("wrong app token", func(t *testing.T) {
+		_, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token, AppToken: "wrong"})
+		ok, msg := shouldHaveGrpcErrorStatus(err, "invalid app token")
+		if !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("app of the audience", func(t *testing.T) {
+		if _, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token, AppToken: "participant-app-token"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("app of other audience", func(t *testing.T) {
+		_, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token, AppToken: "management-app-token"})
+		ok, msg := shouldHaveGrpcErrorStatus(err, "invalid token")
+		if !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("app without audiences", func(t *testing.T) {
+		if _, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token, AppToken: "other-app-token"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestGetJWKS(t *testing.T) {
+	s := userManagementServer{}
+
+	t.Run("with HS256", func(t *testing.T) {
+		resp, err := s.GetJWKS(context.Background(), &empty.Empty{})
+		if err != nil || len(resp.Keys) != 0 {
+			t.Errorf("unexpected response: %v %v", resp, err)
+		}
+	})
+
+	t.Run("with signing key", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tokens.NewSigningKey(tokens.SIGNING_METHOD_ES256, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens.UseSigningKey(key)
+		defer tokens.UseSigningKey(nil)
+
+		resp, err := s.GetJWKS(context.Background(), &empty.Empty{})
+		if err != nil || len(resp.Keys) != 1 || resp.Keys[0].Kid != key.ID || resp.Keys[0].Kty != "EC" {
+			t.Errorf("unexpected response: %v %v", resp, err)
+		}
+	})
+}
+
 func TestRenewJWT(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -133,6 +264,7 @@ func TestRenewJWT(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -161,7 +293,7 @@ func TestRenewJWT(t *testing.T) {
 		t.Errorf("failed to create testusers: %s", err.Error())
 		return
 	}
-	userToken, err := tokens.GenerateNewToken(testUsers[0].ID.Hex(), true, "testprofid", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{})
+	userToken, err := tokens.GenerateNewToken(s.accessTokens, testUsers[0].ID.Hex(), true, "testprofid", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 		return
@@ -268,10 +400,197 @@ func TestRenewJWT(t *testing.T) {
 	})
 }
 
+func TestRefreshTokenReuse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockMessagingClient := messageMock.NewMockMessagingServiceApiClient(mockCtrl)
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			MessagingService: mockMessagingClient,
+			LoggingService:   mockLoggingClient,
+		},
+		refreshTokens: models.RefreshTokenConfig{ReuseNotification: true},
+	}
+	testUser := models.User{
+		Account: models.Account{
+			Type:      "email",
+			AccountID: "test_for_refresh_token_reuse@test.com",
+		},
+		Profiles: []models.Profile{{ID: primitive.NewObjectID(), MainProfile: true}},
+	}
+	testUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily(s.hashRefreshToken("first-refresh-token"), models.SessionInfo{}, time.Now().Unix()), models.MaxRefreshTokens)
+	testUser.AddRefreshTokenFamily(models.NewRefreshTokenFamily(s.hashRefreshToken("other-device-token"), models.SessionInfo{}, time.Now().Unix()), models.MaxRefreshTokens)
+	testUsers, err := addTestUsers([]models.User{testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID := testUsers[0].ID.Hex()
+	accessToken, err := tokens.GenerateNewToken(s.accessTokens, userID, true, "", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: "first-refresh-token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotatedToken := resp.RefreshToken
+
+	t.Run("reuse of replaced token revokes the family", func(t *testing.T) {
+		sent := make(chan *messageAPI.SendEmailReq, 1)
+		mockMessagingClient.EXPECT().SendInstantEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *messageAPI.SendEmailReq, opts ...grpc.CallOption) (*messageAPI.ServiceStatus, error) {
+				sent <- req
+				return nil, nil
+			},
+		)
+
+		_, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: "first-refresh-token"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong refresh token"); !ok {
+			t.Error(msg)
+		}
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, userID)
+		if user.HasRefreshToken(s.hashRefreshToken(rotatedToken)) || !user.HasRefreshToken(s.hashRefreshToken("other-device-token")) {
+			t.Errorf("unexpected refresh tokens: %v", user.Account.RefreshTokenFamilies)
+		}
+
+		select {
+		case req := <-sent:
+			if req.MessageType != emailTypeRefreshTokenReused {
+				t.Errorf("unexpected email: %v", req)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("notification email not sent")
+		}
+	})
+
+	t.Run("other token of the family", func(t *testing.T) {
+		_, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: rotatedToken})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong refresh token"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("other family", func(t *testing.T) {
+		if _, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: "other-device-token"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRefreshTokenHashing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		refreshTokens: models.RefreshTokenConfig{HashKey: []byte("test-hash-key")},
+	}
+	if err := testGlobalDBService.AddAppToken(context.Background(), models.AppToken{
+		AppName:   "refresh-token-hashing-app",
+		Instances: []string{testInstanceID},
+		Tokens:    []string{"refresh-token-hashing-app"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	plaintextToken := "PLAINTEXT-REFRESH-TOKEN"
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:          "email",
+				AccountID:     "test_for_refresh_token_hashing@test.com",
+				RefreshTokens: []string{plaintextToken},
+			},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID(), MainProfile: true}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID := testUsers[0].ID.Hex()
+	accessToken, err := tokens.GenerateNewToken(s.accessTokens, userID, true, "", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var newToken string
+	t.Run("plaintext token is replaced by hashed token", func(t *testing.T) {
+		resp, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: plaintextToken})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		newToken = resp.RefreshToken
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, userID)
+		if user.HasRefreshToken(plaintextToken) || user.HasRefreshToken(newToken) || !user.HasRefreshToken(tokens.HashRefreshToken(s.refreshTokens.HashKey, newToken)) {
+			t.Errorf("unexpected refresh tokens: %v", user.Account.RefreshTokenFamilies)
+		}
+	})
+
+	t.Run("stored hash is not accepted as token", func(t *testing.T) {
+		storedHash := tokens.HashRefreshToken(s.refreshTokens.HashKey, newToken)
+		_, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: storedHash})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong refresh token"); !ok {
+			t.Error(msg)
+		}
+		resp, err := s.IntrospectToken(context.Background(), &api.IntrospectTokenMsg{AppToken: "refresh-token-hashing-app", Token: storedHash})
+		if err != nil || resp.Active {
+			t.Errorf("stored hash should be inactive: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("renew with hashed token", func(t *testing.T) {
+		resp, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: newToken})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.RefreshToken == newToken {
+			t.Errorf("unexpected response: %v", resp)
+		}
+		newToken = resp.RefreshToken
+	})
+
+	t.Run("reuse of replaced plaintext token revokes the family", func(t *testing.T) {
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, userID)
+		if _, found := user.FindRotatedRefreshToken(plaintextToken); found {
+			t.Error("replaced token should not be kept in plaintext")
+		}
+		_, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: plaintextToken})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong refresh token"); !ok {
+			t.Error(msg)
+		}
+		_, err = s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: accessToken, RefreshToken: newToken})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong refresh token"); !ok {
+			t.Errorf("latest token of the family should be revoked: %s", msg)
+		}
+	})
+}
+
 func TestRevokeAllRefreshTokens(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -329,7 +648,7 @@ func TestRevokeAllRefreshTokens(t *testing.T) {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
 		}
-		u, err := s.userDBservice.GetUserByID(testInstanceID, testUsers[0].ID.Hex())
+		u, err := s.userDBservice.GetUserByID(context.Background(), testInstanceID, testUsers[0].ID.Hex())
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
@@ -339,3 +658,130 @@ func TestRevokeAllRefreshTokens(t *testing.T) {
 		}
 	})
 }
+
+func TestAccessTokenRevocation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	fakeClock := clock.NewFake(time.Now())
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           fakeClock,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Minute * 10,
+			VerificationCodeLifetime: 60,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+	}
+	refreshToken := "TEST-REFRESH-TOKEN-STRING"
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:          "email",
+				AccountID:     "test_for_access_token_revocation@test.com",
+				RefreshTokens: []string{refreshToken},
+			},
+			Roles: []string{"PARTICIPANT"},
+		},
+		{
+			Account: models.Account{
+				Type:          "email",
+				AccountID:     "test_for_access_token_revocation2@test.com",
+				RefreshTokens: []string{refreshToken},
+			},
+			Profiles: []models.Profile{
+				{
+					ID:    primitive.NewObjectID(),
+					Alias: "main",
+				},
+			},
+			Roles: []string{"PARTICIPANT", "RESEARCHER"},
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to create testusers: %s", err.Error())
+		return
+	}
+	newToken := func(userID string, roles ...string) string {
+		token, err := tokens.GenerateNewToken(s.accessTokens, userID, true, "", roles, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, fakeClock.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return token
+	}
+	isValid := func(token string) bool {
+		_, err := s.ValidateJWT(context.Background(), &api.JWTRequest{Token: token})
+		return err == nil
+	}
+
+	t.Run("revoke single token", func(t *testing.T) {
+		token1 := newToken(testUsers[0].ID.Hex(), "PARTICIPANT")
+		token2 := newToken(testUsers[0].ID.Hex(), "PARTICIPANT")
+		if _, err := s.RevokeAccessToken(context.Background(), &api.JWTRequest{Token: token1}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if isValid(token1) || !isValid(token2) {
+			t.Error("only the revoked token should be rejected")
+		}
+	})
+
+	t.Run("logout from all devices", func(t *testing.T) {
+		token := newToken(testUsers[0].ID.Hex(), "PARTICIPANT")
+		fakeClock.Advance(time.Second)
+		_, err := s.RevokeAllRefreshTokens(context.Background(), &api.RevokeRefreshTokensReq{
+			Token: &api_types.TokenInfos{InstanceId: testInstanceID, Id: testUsers[0].ID.Hex()},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if isValid(token) {
+			t.Error("token issued before logout should be rejected")
+		}
+		if !isValid(newToken(testUsers[0].ID.Hex(), "PARTICIPANT")) {
+			t.Error("token issued in the second of the logout should be valid")
+		}
+		fakeClock.Advance(time.Second)
+		if !isValid(newToken(testUsers[0].ID.Hex(), "PARTICIPANT")) {
+			t.Error("token issued after logout should be valid")
+		}
+	})
+
+	t.Run("role removal", func(t *testing.T) {
+		token := newToken(testUsers[1].ID.Hex(), "PARTICIPANT", "RESEARCHER")
+		fakeClock.Advance(time.Second)
+		_, err := s.RemoveRoleForUser(context.Background(), &api.RoleMsg{
+			Token:     &api_types.TokenInfos{InstanceId: testInstanceID, Id: "admin", Payload: map[string]string{"roles": "ADMIN"}},
+			AccountId: testUsers[1].Account.AccountID,
+			Role:      "RESEARCHER",
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if isValid(token) {
+			t.Error("token with removed role should be rejected")
+		}
+
+		fakeClock.Advance(time.Second)
+		resp, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: token, RefreshToken: refreshToken})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		parsed, _, err := tokens.ValidateToken(s.accessTokens, resp.AccessToken, fakeClock.Now())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if roles := tokens.GetRolesFromPayload(parsed.Payload); len(roles) != 1 || roles[0] != "PARTICIPANT" {
+			t.Errorf("removed role should not be granted again: %v", roles)
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	constants "github.com/influenzanet/go-utils/pkg/constants"
+)
+
+var errInvalidChallenge = errors.New("invalid or expired challenge")
+
+func passkeyList(user models.User) *api.PasskeyList {
+	list := &api.PasskeyList{Passkeys: []*api.Passkey{}}
+	for _, p := range user.Account.Passkeys {
+		list.Passkeys = append(list.Passkeys, p.ToAPI())
+	}
+	return list
+}
+
+// newPasskeyChallenge creates a challenge for a WebAuthn ceremony and stores it as temp token. The token string is the
+// challenge ID the client sends back with the response.
+func (s *userManagementServer) newPasskeyChallenge(ctx context.Context, instanceID string, userID string, purpose string) (challenge []byte, challengeID string, err error) {
+	challenge, err = webauthn.NewChallenge()
+	if err != nil {
+		return nil, "", err
+	}
+	challengeID, err = s.globalDBService.AddTempToken(ctx, models.TempToken{
+		UserID:     userID,
+		InstanceID: instanceID,
+		Purpose:    purpose,
+		Expiration: s.clock.Now().Unix() + passkeyChallengeLifetime,
+		Info:       map[string]string{"challenge": webauthn.EncodeID(challenge)},
+	})
+	return challenge, challengeID, err
+}
+
+// usePasskeyChallenge returns the challenge and deletes it, so that it can only be answered once
+func (s *userManagementServer) usePasskeyChallenge(ctx context.Context, instanceID string, challengeID string, purpose string) (models.TempToken, []byte, error) {
+	tt, err := s.globalDBService.GetTempToken(ctx, challengeID)
+	if err != nil || tt.InstanceID != instanceID || tt.Purpose != purpose {
+		return tt, nil, errInvalidChallenge
+	}
+	// deleting fails if the challenge was used concurrently
+	if err := s.globalDBService.DeleteTempToken(ctx, challengeID); err != nil {
+		return tt, nil, errInvalidChallenge
+	}
+	if tt.Expiration < s.clock.Now().Unix() {
+		return tt, nil, errInvalidChallenge
+	}
+	challenge, err := webauthn.DecodeID(tt.Info["challenge"])
+	if err != nil || len(challenge) == 0 {
+		return tt, nil, errInvalidChallenge
+	}
+	return tt, challenge, nil
+}
+
+// passkeyAssertion checks the challenge of a login with a passkey and decodes the response. The returned user ID is
+// known from the account the login was started for or from the user handle of the passkey, it is empty if neither is
+// available.
+func (s *userManagementServer) passkeyAssertion(ctx context.Context, instanceID string, challengeID string, credential string) (userID string, challenge []byte, resp webauthn.AssertionResponse, err error) {
+	tt, challenge, err := s.usePasskeyChallenge(ctx, instanceID, challengeID, tokenPurposePasskeyLogin)
+	if err != nil {
+		return "", nil, resp, err
+	}
+	resp, err = webauthn.ParseAssertionResponse(credential)
+	if err != nil {
+		return "", nil, resp, err
+	}
+	userID = tt.UserID
+	if len(resp.Response.UserHandle) > 0 {
+		handle := string(resp.Response.UserHandle)
+		if userID != "" && userID != handle {
+			return "", nil, resp, errors.New("passkey belongs to another user")
+		}
+		userID = handle
+	}
+	return userID, challenge, resp, nil
+}
+
+// verifyPasskey checks the response against the passkeys of the user and stores the new signature counter. User
+// verification is required if the passkey is the only factor.
+func (s *userManagementServer) verifyPasskey(ctx context.Context, instanceID string, user models.User, challenge []byte, resp webauthn.AssertionResponse, requireUserVerification bool) (models.User, error) {
+	credentialID := webauthn.EncodeID(resp.RawID)
+	i := user.Account.FindPasskey(credentialID)
+	if i < 0 {
+		return user, errors.New("unknown passkey")
+	}
+	passkey := user.Account.Passkeys[i]
+	signCount, err := s.relyingParty.VerifyAssertion(challenge, resp, passkey.PublicKey, uint32(passkey.SignCount), requireUserVerification)
+	if err != nil {
+		if err == webauthn.ErrSignCount {
+			s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "passkey signature counter did not increase, the authenticator may be cloned")
+		}
+		return user, err
+	}
+
+	return userdb.UpdateUserWithRetry(ctx, s.userDBservice, instanceID, user, func(user *models.User) error {
+		i := user.Account.FindPasskey(credentialID)
+		if i < 0 {
+			return errors.New("passkey was removed")
+		}
+		if int64(signCount) > user.Account.Passkeys[i].SignCount {
+			user.Account.Passkeys[i].SignCount = int64(signCount)
+		}
+		user.Account.Passkeys[i].LastUsedAt = s.clock.Now().Unix()
+		return nil
+	})
+}
+
+func (s *userManagementServer) StartPasskeyRegistration(ctx context.Context, req *api.PasskeyMsg) (*api.PasskeyChallenge, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if !s.relyingParty.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "passkeys are not available")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if user.Account.Type == models.ACCOUNT_TYPE_EXTERNAL {
+		return nil, status.Error(codes.FailedPrecondition, "passkeys are not available for external accounts")
+	}
+	if len(user.Account.Passkeys) >= maximumPasskeysAllowed {
+		return nil, status.Error(codes.FailedPrecondition, "reached maximum number of passkeys")
+	}
+
+	challenge, challengeID, err := s.newPasskeyChallenge(ctx, req.Token.InstanceId, user.ID.Hex(), tokenPurposePasskeyRegistration)
+	if err != nil {
+		log.Printf("StartPasskeyRegistration: unexpected error when creating challenge -> %v", err)
+		return nil, status.Error(codes.Internal, "challenge generation error")
+	}
+
+	exclude := []webauthn.CredentialDescriptor{}
+	for _, p := range user.Account.Passkeys {
+		id, _ := webauthn.DecodeID(p.CredentialID)
+		exclude = append(exclude, webauthn.NewCredentialDescriptor(id, p.Transports))
+	}
+	options, err := json.Marshal(s.relyingParty.CreationOptions(challenge, webauthn.UserEntity{
+		ID:          []byte(user.ID.Hex()),
+		Name:        user.Account.AccountID,
+		DisplayName: user.Account.AccountID,
+	}, exclude))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.PasskeyChallenge{ChallengeId: challengeID, Options: string(options)}, nil
+}
+
+func (s *userManagementServer) FinishPasskeyRegistration(ctx context.Context, req *api.PasskeyMsg) (*api.PasskeyList, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.ChallengeId == "" || req.Credential == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+
+	tt, challenge, err := s.usePasskeyChallenge(ctx, req.Token.InstanceId, req.ChallengeId, tokenPurposePasskeyRegistration)
+	if err != nil || tt.UserID != req.Token.Id {
+		return nil, status.Error(codes.InvalidArgument, "invalid challenge")
+	}
+	resp, err := webauthn.ParseRegistrationResponse(req.Credential)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+	credential, err := s.relyingParty.VerifyRegistration(challenge, resp)
+	if err != nil {
+		log.Printf("FinishPasskeyRegistration: %v", err)
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	credentialID := webauthn.EncodeID(credential.ID)
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		if user.Account.FindPasskey(credentialID) >= 0 {
+			return status.Error(codes.AlreadyExists, "passkey already registered")
+		}
+		if len(user.Account.Passkeys) >= maximumPasskeysAllowed {
+			return status.Error(codes.FailedPrecondition, "reached maximum number of passkeys")
+		}
+		user.Account.Passkeys = append(user.Account.Passkeys, models.Passkey{
+			CredentialID: credentialID,
+			PublicKey:    credential.PublicKey,
+			SignCount:    int64(credential.SignCount),
+			Transports:   credential.Transports,
+			Nickname:     req.Nickname,
+			CreatedAt:    s.clock.Now().Unix(),
+		})
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("FinishPasskeyRegistration: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, logEventPasskeyAdded, req.Nickname)
+	return passkeyList(user), nil
+}
+
+func (s *userManagementServer) GetPasskeys(ctx context.Context, req *api.PasskeyMsg) (*api.PasskeyList, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return passkeyList(user), nil
+}
+
+func (s *userManagementServer) RemovePasskey(ctx context.Context, req *api.PasskeyMsg) (*api.PasskeyList, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.CredentialId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		i := user.Account.FindPasskey(req.CredentialId)
+		if i < 0 {
+			return status.Error(codes.NotFound, "passkey not found")
+		}
+		user.Account.Passkeys = append(user.Account.Passkeys[:i], user.Account.Passkeys[i+1:]...)
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("RemovePasskey: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, logEventPasskeyRemoved, req.CredentialId)
+	return passkeyList(user), nil
+}
+
+func (s *userManagementServer) StartPasskeyLogin(ctx context.Context, req *api.StartPasskeyLoginMsg) (*api.PasskeyChallenge, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if !s.relyingParty.Enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "passkeys are not available")
+	}
+	if req.InstanceId == "" {
+		req.InstanceId = "default"
+	}
+
+	// without email, the browser offers the passkeys it has for this site. Unknown emails are not reported, so that the
+	// endpoint cannot be used to find out which accounts exist.
+	userID := ""
+	allow := []webauthn.CredentialDescriptor{}
+	if req.Email != "" {
+		user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, utils.SanitizeEmail(req.Email))
+		if err == nil && user.Account.Type != models.ACCOUNT_TYPE_EXTERNAL {
+			userID = user.ID.Hex()
+			for _, p := range user.Account.Passkeys {
+				id, _ := webauthn.DecodeID(p.CredentialID)
+				allow = append(allow, webauthn.NewCredentialDescriptor(id, p.Transports))
+			}
+		}
+	}
+
+	challenge, challengeID, err := s.newPasskeyChallenge(ctx, req.InstanceId, userID, tokenPurposePasskeyLogin)
+	if err != nil {
+		log.Printf("StartPasskeyLogin: unexpected error when creating challenge -> %v", err)
+		return nil, status.Error(codes.Internal, "challenge generation error")
+	}
+	options, err := json.Marshal(s.relyingParty.RequestOptions(challenge, allow))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.PasskeyChallenge{ChallengeId: challengeID, Options: string(options)}, nil
+}
+
+func (s *userManagementServer) LoginWithPasskey(ctx context.Context, req *api.LoginWithPasskeyMsg) (*api.LoginResponse, error) {
+	if req == nil || req.ChallengeId == "" || req.Credential == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+	if req.InstanceId == "" {
+		req.InstanceId = "default"
+	}
+
+	userID, challenge, resp, err := s.passkeyAssertion(ctx, req.InstanceId, req.ChallengeId, req.Credential)
+	if err != nil || userID == "" {
+		log.Printf("SECURITY WARNING: login attempt with invalid passkey response: %v", err)
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.InstanceId, userID)
+	if err != nil {
+		log.Printf("SECURITY WARNING: login attempt with passkey of unknown user %s", userID)
+		s.SaveLogEvent(req.InstanceId, "", loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, "passkey of unknown user")
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
+		log.Printf("SECURITY WARNING: passkey login attempt blocked for %s - too many wrong tries recently", user.ID.Hex())
+		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "passkey login")
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	if user.Account.Type == models.ACCOUNT_TYPE_EXTERNAL {
+		log.Printf("[SECURITY WARNING]: passkey login attempt for external account (%s)", user.ID.Hex())
+		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, "reason: passkey used for external user")
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	user, err = s.verifyPasskey(ctx, req.InstanceId, user, challenge, resp, true)
+	if err != nil {
+		log.Printf("SECURITY WARNING: login attempt with wrong passkey for %s: %v", user.ID.Hex(), err)
+		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_PASSWORD, "passkey login")
+		if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, req.InstanceId, user.ID.Hex()); err2 != nil {
+			log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, "invalid credential")
+	}
+
+	return s.loginUser(ctx, req.InstanceId, user, req.AsParticipant)
+}
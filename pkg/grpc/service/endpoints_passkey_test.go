@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	api_types "github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
+	"github.com/influenzanet/user-management-service/pkg/webauthn/webauthntest"
+	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPasskeyEndpoints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	rp := webauthn.RelyingParty{ID: "example.com", Name: "Test", Origins: []string{"https://www.example.com"}}
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		relyingParty: rp,
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-passkey@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+				AuthType:           "2FA",
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &api_types.TokenInfos{Id: testUsers[0].ID.Hex(), InstanceId: testInstanceID}
+	authenticator, err := webauthntest.New(rp.ID, rp.Origins[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startLogin := func(email string) *api.PasskeyChallenge {
+		challenge, err := s.StartPasskeyLogin(context.Background(), &api.StartPasskeyLoginMsg{InstanceId: testInstanceID, Email: email})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return challenge
+	}
+
+	t.Run("start registration without token", func(t *testing.T) {
+		_, err := s.StartPasskeyRegistration(context.Background(), &api.PasskeyMsg{})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "missing argument"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("register passkey", func(t *testing.T) {
+		challenge, err := s.StartPasskeyRegistration(context.Background(), &api.PasskeyMsg{Token: token})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		credential, err := authenticator.Register(challenge.Options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(authenticator.UserHandle) != token.Id {
+			t.Errorf("unexpected user handle: %s", authenticator.UserHandle)
+		}
+
+		list, err := s.FinishPasskeyRegistration(context.Background(), &api.PasskeyMsg{
+			Token:       token,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+			Nickname:    "phone",
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(list.Passkeys) != 1 || list.Passkeys[0].Nickname != "phone" || list.Passkeys[0].CredentialId != webauthn.EncodeID(authenticator.CredentialID) {
+			t.Errorf("unexpected passkeys: %v", list)
+		}
+
+		// challenges can only be used once
+		_, err = s.FinishPasskeyRegistration(context.Background(), &api.PasskeyMsg{
+			Token:       token,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid challenge"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("register same authenticator again", func(t *testing.T) {
+		challenge, err := s.StartPasskeyRegistration(context.Background(), &api.PasskeyMsg{Token: token})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		credential, _ := authenticator.Register(challenge.Options)
+		_, err = s.FinishPasskeyRegistration(context.Background(), &api.PasskeyMsg{Token: token, ChallengeId: challenge.ChallengeId, Credential: credential})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "passkey already registered"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("login with passkey", func(t *testing.T) {
+		challenge := startLogin("")
+		credential, err := authenticator.Login(challenge.Options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := s.LoginWithPasskey(context.Background(), &api.LoginWithPasskeyMsg{
+			InstanceId:  testInstanceID,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || len(resp.Token.AccessToken) < 1 || len(resp.Token.RefreshToken) < 1 || resp.User.Id != token.Id {
+			t.Errorf("unexpected response: %v", resp)
+		}
+
+		_, err = s.LoginWithPasskey(context.Background(), &api.LoginWithPasskeyMsg{
+			InstanceId:  testInstanceID,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid credential"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("login requires user verification", func(t *testing.T) {
+		authenticator.UserVerified = false
+		defer func() { authenticator.UserVerified = true }()
+		challenge := startLogin("")
+		credential, _ := authenticator.Login(challenge.Options)
+		_, err := s.LoginWithPasskey(context.Background(), &api.LoginWithPasskeyMsg{
+			InstanceId:  testInstanceID,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid credential"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("passkey as second factor", func(t *testing.T) {
+		resp, err := s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:      testUsers[0].Account.AccountID,
+			Password:   password,
+			InstanceId: testInstanceID,
+		})
+		if err != nil || !resp.SecondFactorNeeded {
+			t.Errorf("unexpected response: %v, %v", resp, err)
+			return
+		}
+
+		authenticator.UserVerified = false
+		defer func() { authenticator.UserVerified = true }()
+		challenge := startLogin(testUsers[0].Account.AccountID)
+		credential, _ := authenticator.Login(challenge.Options)
+		resp, err = s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:              testUsers[0].Account.AccountID,
+			Password:           password,
+			InstanceId:         testInstanceID,
+			PasskeyChallengeId: challenge.ChallengeId,
+			PasskeyCredential:  credential,
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || len(resp.Token.AccessToken) < 1 {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("remove passkey", func(t *testing.T) {
+		list, err := s.RemovePasskey(context.Background(), &api.PasskeyMsg{Token: token, CredentialId: webauthn.EncodeID(authenticator.CredentialID)})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(list.Passkeys) != 0 {
+			t.Errorf("unexpected passkeys: %v", list)
+		}
+		if list, _ := s.GetPasskeys(context.Background(), &api.PasskeyMsg{Token: token}); len(list.Passkeys) != 0 {
+			t.Errorf("unexpected passkeys: %v", list)
+		}
+
+		challenge := startLogin("")
+		credential, _ := authenticator.Login(challenge.Options)
+		_, err = s.LoginWithPasskey(context.Background(), &api.LoginWithPasskeyMsg{
+			InstanceId:  testInstanceID,
+			ChallengeId: challenge.ChallengeId,
+			Credential:  credential,
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid credential"); !ok {
+			t.Error(msg)
+		}
+	})
+}
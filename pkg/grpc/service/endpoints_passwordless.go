@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	constants "github.com/influenzanet/go-utils/pkg/constants"
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errPasswordlessNotAllowed = errors.New("passwordless login not allowed for this account")
+
+// checkPasswordlessLoginAllowed refuses accounts that would skip a check of the normal login: external accounts and
+// accounts with a second factor.
+func checkPasswordlessLoginAllowed(user models.User) error {
+	if user.Account.Type == models.ACCOUNT_TYPE_EXTERNAL || user.Account.AuthType == "2FA" || user.Account.TOTP.IsEnabled() {
+		return errPasswordlessNotAllowed
+	}
+	return nil
+}
+
+// lastPasswordlessLoginEmail returns when the last login email was sent to the user, 0 if there is none
+func (s *userManagementServer) lastPasswordlessLoginEmail(ctx context.Context, instanceID string, userID string) int64 {
+	var sentAt int64
+	for _, purpose := range []string{tokenPurposePasswordlessLink, tokenPurposePasswordlessCode} {
+		tts, err := s.globalDBService.GetTempTokenForUser(ctx, instanceID, userID, purpose)
+		if err != nil {
+			continue
+		}
+		for _, tt := range tts {
+			if t, err := strconv.ParseInt(tt.Info["sentAt"], 10, 64); err == nil && t > sentAt {
+				sentAt = t
+			}
+		}
+	}
+	return sentAt
+}
+
+func (s *userManagementServer) RequestPasswordlessLogin(ctx context.Context, req *api.PasswordlessLoginMsg) (*api.ServiceStatus, error) {
+	if req == nil || req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if req.InstanceId == "" {
+		req.InstanceId = "default"
+	}
+	if req.Method == "" {
+		req.Method = models.PASSWORDLESS_METHOD_LINK
+	}
+	if req.Method != models.PASSWORDLESS_METHOD_LINK && req.Method != models.PASSWORDLESS_METHOD_CODE {
+		return nil, status.Error(codes.InvalidArgument, "unsupported method")
+	}
+	if !s.passwordlessLogin.Allows(req.InstanceId, req.Method) {
+		return nil, status.Error(codes.FailedPrecondition, "passwordless login not enabled for this instance")
+	}
+	req.Email = utils.SanitizeEmail(req.Email)
+
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, req.Email)
+	if err != nil {
+		log.Printf("RequestPasswordlessLogin: %s", err.Error())
+		return nil, status.Error(codes.InvalidArgument, "invalid account id")
+	}
+	if err := checkPasswordlessLoginAllowed(user); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
+		log.Printf("SECURITY WARNING: passwordless login blocked for %s - too many wrong tries recently", user.ID.Hex())
+		s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "passwordless login")
+		return nil, status.Error(codes.InvalidArgument, "account blocked for a while")
+	}
+	now := s.clock.Now().Unix()
+	if s.lastPasswordlessLoginEmail(ctx, req.InstanceId, user.ID.Hex()) > now-passwordlessLoginCooldown {
+		log.Printf("SECURITY WARNING: passwordless login email requested too often for %s", user.ID.Hex())
+		return nil, status.Error(codes.InvalidArgument, "cannot send login email so often")
+	}
+
+	// only the latest link or code can be used
+	for _, purpose := range []string{tokenPurposePasswordlessLink, tokenPurposePasswordlessCode} {
+		if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, req.InstanceId, user.ID.Hex(), purpose); err != nil {
+			log.Printf("RequestPasswordlessLogin: unexpected error when deleting temp tokens -> %v", err)
+		}
+	}
+
+	tempToken := models.TempToken{
+		UserID:     user.ID.Hex(),
+		InstanceID: req.InstanceId,
+		Purpose:    tokenPurposePasswordlessLink,
+		Info:       map[string]string{"sentAt": strconv.FormatInt(now, 10)},
+		Expiration: now + passwordlessLinkLifetime,
+	}
+	email := &messageAPI.SendEmailReq{
+		InstanceId:        req.InstanceId,
+		To:                []string{user.Account.AccountID},
+		MessageType:       emailTypeLoginLink,
+		PreferredLanguage: user.Account.PreferredLanguage,
+	}
+	if req.Method == models.PASSWORDLESS_METHOD_CODE {
+		code, err := tokens.GenerateVerificationCode(6)
+		if err != nil {
+			log.Printf("RequestPasswordlessLogin: unexpected error when generating code -> %v", err)
+			return nil, status.Error(codes.Internal, "code generation error")
+		}
+		tempToken.Purpose = tokenPurposePasswordlessCode
+		tempToken.Info["code"] = code
+		tempToken.Expiration = now + s.Intervals.VerificationCodeLifetime
+
+		half := len(code) / 2
+		email.MessageType = constants.EMAIL_TYPE_AUTH_VERIFICATION_CODE
+		email.ContentInfos = map[string]string{"verificationCode": fmt.Sprintf("%s-%s", code[:half], code[half:])}
+	}
+
+	token, err := s.globalDBService.AddTempToken(ctx, tempToken)
+	if err != nil {
+		log.Printf("RequestPasswordlessLogin: unexpected error when saving temp token -> %v", err)
+		return nil, status.Error(codes.Internal, "token couldn't be created")
+	}
+	if req.Method == models.PASSWORDLESS_METHOD_LINK {
+		email.ContentInfos = map[string]string{
+			"token":      token,
+			"validUntil": strconv.Itoa(passwordlessLinkLifetime / 60), // minutes
+		}
+	}
+
+	if _, err := s.clients.MessagingService.SendInstantEmail(ctx, email); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.SaveLogEvent(req.InstanceId, user.ID.Hex(), loggingAPI.LogEventType_LOG, logEventPasswordlessLoginRequested, req.Method)
+
+	return &api.ServiceStatus{
+		Msg:     "email sending triggered",
+		Version: apiVersion,
+		Status:  api.ServiceStatus_NORMAL,
+	}, nil
+}
+
+func (s *userManagementServer) LoginWithPasswordlessToken(ctx context.Context, req *api.PasswordlessLoginTokenMsg) (*api.LoginResponse, error) {
+	if req == nil || (req.Token == "" && (req.Email == "" || req.Code == "")) {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if req.InstanceId == "" {
+		req.InstanceId = "default"
+	}
+	// links or codes sent before the method was disabled cannot be used anymore
+	method := models.PASSWORDLESS_METHOD_CODE
+	if req.Token != "" {
+		method = models.PASSWORDLESS_METHOD_LINK
+	}
+	if !s.passwordlessLogin.Allows(req.InstanceId, method) {
+		return nil, status.Error(codes.FailedPrecondition, "passwordless login not enabled for this instance")
+	}
+
+	var user models.User
+	var err error
+	if req.Token != "" {
+		user, err = s.usePasswordlessLink(ctx, req.InstanceId, req.Token)
+	} else {
+		user, err = s.usePasswordlessCode(ctx, req.InstanceId, req.Email, req.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPasswordlessLoginAllowed(user); err != nil {
+		log.Printf("SECURITY WARNING: passwordless login attempt for %s: %v", user.ID.Hex(), err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return s.loginUser(ctx, req.InstanceId, user, req.AsParticipant)
+}
+
+// usePasswordlessLink validates the token from the login link and deletes it, so that the link can only be used once
+func (s *userManagementServer) usePasswordlessLink(ctx context.Context, instanceID string, token string) (models.User, error) {
+	errInvalidToken := status.Error(codes.InvalidArgument, "invalid token")
+	tokenInfos, err := s.ValidateTempToken(ctx, token, []string{tokenPurposePasswordlessLink})
+	if err != nil || tokenInfos.InstanceID != instanceID {
+		log.Printf("SECURITY WARNING: passwordless login attempt with invalid token: %v", err)
+		return models.User{}, errInvalidToken
+	}
+	// deleting fails if the link was used concurrently
+	if err := s.globalDBService.DeleteTempToken(ctx, tokenInfos.Token); err != nil {
+		return models.User{}, errInvalidToken
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, instanceID, tokenInfos.UserID)
+	if err != nil {
+		log.Printf("SECURITY WARNING: passwordless login attempt for unknown user %s", tokenInfos.UserID)
+		return models.User{}, errInvalidToken
+	}
+	return user, nil
+}
+
+// usePasswordlessCode checks the code sent to the email address and deletes it on success. Wrong codes count as failed
+// login attempts.
+func (s *userManagementServer) usePasswordlessCode(ctx context.Context, instanceID string, email string, code string) (models.User, error) {
+	errWrongCode := status.Error(codes.InvalidArgument, "wrong verfication code")
+	email = utils.SanitizeEmail(email)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, instanceID, email)
+	if err != nil {
+		log.Printf("SECURITY WARNING: passwordless login attempt with wrong email address for %s", email)
+		s.SaveLogEvent(instanceID, "", loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_ACCOUNT_ID, email)
+		return user, errWrongCode
+	}
+	if utils.HasMoreAttemptsRecently(user.Account.FailedLoginAttempts, allowedPasswordAttempts, loginFailedAttemptWindow, s.clock.Now().Unix()) {
+		log.Printf("SECURITY WARNING: passwordless login attempt blocked for %s - too many wrong tries recently", user.ID.Hex())
+		s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_LOGIN_ATTEMPT_ON_BLOCKED_ACCOUNT, "passwordless login")
+		return user, errWrongCode
+	}
+
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	tts, err := s.globalDBService.GetTempTokenForUser(ctx, instanceID, user.ID.Hex(), tokenPurposePasswordlessCode)
+	if err == nil {
+		for _, tt := range tts {
+			if tt.Expiration < s.clock.Now().Unix() || subtle.ConstantTimeCompare([]byte(tt.Info["code"]), []byte(code)) != 1 {
+				continue
+			}
+			// deleting fails if the code was used concurrently
+			if err := s.globalDBService.DeleteTempToken(ctx, tt.Token); err == nil {
+				return user, nil
+			}
+		}
+	}
+
+	log.Printf("SECURITY WARNING: passwordless login attempt with wrong code for %s", user.ID.Hex())
+	s.SaveLogEvent(instanceID, user.ID.Hex(), loggingAPI.LogEventType_SECURITY, constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE, "passwordless login")
+	if err2 := s.userDBservice.SaveFailedLoginAttempt(ctx, instanceID, user.ID.Hex()); err2 != nil {
+		log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
+	}
+	return user, errWrongCode
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	messageMock "github.com/influenzanet/user-management-service/test/mocks/messaging_service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+func TestPasswordlessLogin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockMessagingClient := messageMock.NewMockMessagingServiceApiClient(mockCtrl)
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	fakeClock := clock.NewFake(time.Now())
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           fakeClock,
+		Intervals: models.Intervals{
+			TokenExpiryInterval:      time.Second * 2,
+			VerificationCodeLifetime: 60,
+		},
+		clients: &models.APIClients{
+			MessagingService: mockMessagingClient,
+			LoggingService:   mockLoggingClient,
+		},
+		passwordlessLogin: models.PasswordlessLoginConfig{
+			testInstanceID: {models.PASSWORDLESS_METHOD_LINK, models.PASSWORDLESS_METHOD_CODE},
+		},
+	}
+
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-passwordless@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-passwordless-2fa@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				AuthType:           "2FA",
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	email := testUsers[0].Account.AccountID
+
+	// the email is sent before the request returns
+	expectEmail := func() chan *messageAPI.SendEmailReq {
+		sent := make(chan *messageAPI.SendEmailReq, 1)
+		mockMessagingClient.EXPECT().SendInstantEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *messageAPI.SendEmailReq, opts ...grpc.CallOption) (*messageAPI.ServiceStatus, error) {
+				sent <- req
+				return nil, nil
+			},
+		)
+		return sent
+	}
+
+	t.Run("instance not configured", func(t *testing.T) {
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: "other", Email: email})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "passwordless login not enabled for this instance"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("account with second factor", func(t *testing.T) {
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: testUsers[1].Account.AccountID})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, errPasswordlessNotAllowed.Error()); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("login with link", func(t *testing.T) {
+		sent := expectEmail()
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: email})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		req := <-sent
+		if req.MessageType != emailTypeLoginLink || req.ContentInfos["token"] == "" {
+			t.Errorf("unexpected email: %v", req)
+			return
+		}
+
+		_, err = s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: email})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "cannot send login email so often"); !ok {
+			t.Error(msg)
+		}
+
+		loginMsg := &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Token: req.ContentInfos["token"]}
+		resp, err := s.LoginWithPasswordlessToken(context.Background(), loginMsg)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || len(resp.Token.AccessToken) < 1 || resp.User.Id != testUsers[0].ID.Hex() {
+			t.Errorf("unexpected response: %v", resp)
+		}
+
+		_, err = s.LoginWithPasswordlessToken(context.Background(), loginMsg)
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid token"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("login with code", func(t *testing.T) {
+		fakeClock.Advance(passwordlessLoginCooldown * time.Second)
+		sent := expectEmail()
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: email, Method: models.PASSWORDLESS_METHOD_CODE})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		req := <-sent
+		code := req.ContentInfos["verificationCode"]
+		if len(code) != 7 {
+			t.Errorf("unexpected email: %v", req)
+			return
+		}
+
+		_, err = s.LoginWithPasswordlessToken(context.Background(), &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Email: email, Code: "000-000"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+
+		resp, err := s.LoginWithPasswordlessToken(context.Background(), &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Email: email, Code: code})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || resp.User.Id != testUsers[0].ID.Hex() {
+			t.Errorf("unexpected response: %v", resp)
+		}
+
+		_, err = s.LoginWithPasswordlessToken(context.Background(), &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Email: email, Code: code})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		fakeClock.Advance(passwordlessLoginCooldown * time.Second)
+		sent := expectEmail()
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: email, Method: models.PASSWORDLESS_METHOD_CODE})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		req := <-sent
+		fakeClock.Advance(61 * time.Second)
+		_, err = s.LoginWithPasswordlessToken(context.Background(), &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Email: email, Code: req.ContentInfos["verificationCode"]})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("method disabled after the code was sent", func(t *testing.T) {
+		fakeClock.Advance(passwordlessLoginCooldown * time.Second)
+		sent := expectEmail()
+		_, err := s.RequestPasswordlessLogin(context.Background(), &api.PasswordlessLoginMsg{InstanceId: testInstanceID, Email: email, Method: models.PASSWORDLESS_METHOD_CODE})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		req := <-sent
+
+		s.passwordlessLogin = models.PasswordlessLoginConfig{testInstanceID: {models.PASSWORDLESS_METHOD_LINK}}
+		_, err = s.LoginWithPasswordlessToken(context.Background(), &api.PasswordlessLoginTokenMsg{InstanceId: testInstanceID, Email: email, Code: req.ContentInfos["verificationCode"]})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "passwordless login not enabled for this instance"); !ok {
+			t.Error(msg)
+		}
+	})
+}
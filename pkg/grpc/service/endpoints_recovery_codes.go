@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errWrongRecoveryCode = errors.New("wrong recovery code")
+
+func (s *userManagementServer) GenerateRecoveryCodes(ctx context.Context, req *api.RecoveryCodesMsg) (*api.RecoveryCodes, error) {
+	return s.createRecoveryCodes(ctx, req, false)
+}
+
+func (s *userManagementServer) RegenerateRecoveryCodes(ctx context.Context, req *api.RecoveryCodesMsg) (*api.RecoveryCodes, error) {
+	return s.createRecoveryCodes(ctx, req, true)
+}
+
+// createRecoveryCodes generates a new set of recovery codes. Existing codes are only replaced if replace is set.
+func (s *userManagementServer) createRecoveryCodes(ctx context.Context, req *api.RecoveryCodesMsg, replace bool) (*api.RecoveryCodes, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user and/or password")
+	}
+	if err := s.checkPasswordAgain(ctx, req.Token.InstanceId, user, req.Password, "recovery codes endpoint"); err != nil {
+		return nil, err
+	}
+	if user.Account.AuthType != "2FA" && !user.Account.TOTP.IsEnabled() {
+		return nil, status.Error(codes.FailedPrecondition, "second factor not enabled")
+	}
+	errCodesExist := status.Error(codes.FailedPrecondition, "recovery codes already generated")
+	if !replace && len(user.Account.RecoveryCodes) > 0 {
+		return nil, errCodesExist
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		if recoveryCodes[i], err = tokens.GenerateRecoveryCode(); err != nil {
+			log.Printf("createRecoveryCodes: unexpected error when generating code -> %v", err)
+			return nil, status.Error(codes.Internal, "code generation error")
+		}
+		if hashes[i], err = pwhash.HashPassword(tokens.NormalizeRecoveryCode(recoveryCodes[i])); err != nil {
+			log.Printf("createRecoveryCodes: unexpected error when hashing code -> %v", err)
+			return nil, status.Error(codes.Internal, "code generation error")
+		}
+	}
+
+	_, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		if !replace && len(user.Account.RecoveryCodes) > 0 {
+			return errCodesExist
+		}
+		user.Account.RecoveryCodes = hashes
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("createRecoveryCodes: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, logEventRecoveryCodesGenerated, "")
+	return &api.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
+// useRecoveryCode checks the code against the stored hashes and removes the matching one, so that each code can only
+// be used once. Returns errWrongRecoveryCode if no code matches.
+func (s *userManagementServer) useRecoveryCode(ctx context.Context, instanceID string, user models.User, code string) (models.User, error) {
+	code = tokens.NormalizeRecoveryCode(code)
+	used := ""
+	for _, hash := range user.Account.RecoveryCodes {
+		if match, err := pwhash.ComparePasswordWithHash(hash, code); err == nil && match {
+			used = hash
+			break
+		}
+	}
+	if used == "" {
+		return user, errWrongRecoveryCode
+	}
+
+	return userdb.UpdateUserWithRetry(ctx, s.userDBservice, instanceID, user, func(user *models.User) error {
+		for i, hash := range user.Account.RecoveryCodes {
+			if hash == used {
+				user.Account.RecoveryCodes = append(user.Account.RecoveryCodes[:i], user.Account.RecoveryCodes[i+1:]...)
+				return nil
+			}
+		}
+		// used concurrently, or replaced by a new set
+		return errWrongRecoveryCode
+	})
+}
+
+func (s *userManagementServer) sendRecoveryCodeUsedEmail(instanceID string, user models.User) {
+	if s.clients.MessagingService == nil {
+		return
+	}
+	_, err := s.clients.MessagingService.SendInstantEmail(context.TODO(), &messageAPI.SendEmailReq{
+		InstanceId:  instanceID,
+		To:          []string{user.Account.AccountID},
+		MessageType: emailTypeRecoveryCodeUsed,
+		ContentInfos: map[string]string{
+			"recoveryCodesLeft": fmt.Sprint(len(user.Account.RecoveryCodes)),
+		},
+		PreferredLanguage: user.Account.PreferredLanguage,
+	})
+	if err != nil {
+		log.Printf("sendRecoveryCodeUsedEmail: %s", err.Error())
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	api_types "github.com/influenzanet/go-utils/pkg/api_types"
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
+	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	messageMock "github.com/influenzanet/user-management-service/test/mocks/messaging_service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockMessagingClient := messageMock.NewMockMessagingServiceApiClient(mockCtrl)
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			MessagingService: mockMessagingClient,
+			LoggingService:   mockLoggingClient,
+		},
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-recovery-codes@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+				AuthType:           "2FA",
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-recovery-codes-no-2fa@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &api_types.TokenInfos{Id: testUsers[0].ID.Hex(), InstanceId: testInstanceID}
+	var recoveryCodes []string
+
+	login := func(code string) (*api.LoginResponse, error) {
+		return s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:        testUsers[0].Account.AccountID,
+			Password:     password,
+			InstanceId:   testInstanceID,
+			RecoveryCode: code,
+		})
+	}
+
+	t.Run("without second factor", func(t *testing.T) {
+		_, err := s.GenerateRecoveryCodes(context.Background(), &api.RecoveryCodesMsg{
+			Token:    &api_types.TokenInfos{Id: testUsers[1].ID.Hex(), InstanceId: testInstanceID},
+			Password: password,
+		})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "second factor not enabled"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("with wrong password", func(t *testing.T) {
+		_, err := s.GenerateRecoveryCodes(context.Background(), &api.RecoveryCodesMsg{Token: token, Password: password + "w"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid user and/or password"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("generate codes", func(t *testing.T) {
+		resp, err := s.GenerateRecoveryCodes(context.Background(), &api.RecoveryCodesMsg{Token: token, Password: password})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		recoveryCodes = resp.Codes
+		if len(recoveryCodes) != recoveryCodeCount {
+			t.Errorf("unexpected codes: %v", recoveryCodes)
+		}
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, token.Id)
+		if len(user.Account.RecoveryCodes) != recoveryCodeCount || user.Account.RecoveryCodes[0] == recoveryCodes[0] {
+			t.Errorf("codes should be stored hashed: %v", user.Account.RecoveryCodes)
+		}
+
+		_, err = s.GenerateRecoveryCodes(context.Background(), &api.RecoveryCodesMsg{Token: token, Password: password})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "recovery codes already generated"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("login with recovery code", func(t *testing.T) {
+		sent := make(chan *messageAPI.SendEmailReq, 1)
+		mockMessagingClient.EXPECT().SendInstantEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *messageAPI.SendEmailReq, opts ...grpc.CallOption) (*messageAPI.ServiceStatus, error) {
+				sent <- req
+				return nil, nil
+			},
+		)
+
+		resp, err := login(strings.ToLower(recoveryCodes[0]))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || resp.User.Account.RecoveryCodesLeft != recoveryCodeCount-1 {
+			t.Errorf("unexpected response: %v", resp)
+		}
+
+		select {
+		case req := <-sent:
+			if req.MessageType != emailTypeRecoveryCodeUsed || req.ContentInfos["recoveryCodesLeft"] != "9" {
+				t.Errorf("unexpected email: %v", req)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("notification email not sent")
+		}
+	})
+
+	t.Run("recovery code can only be used once", func(t *testing.T) {
+		_, err := login(recoveryCodes[0])
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("regenerate codes", func(t *testing.T) {
+		resp, err := s.RegenerateRecoveryCodes(context.Background(), &api.RecoveryCodesMsg{Token: token, Password: password})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(resp.Codes) != recoveryCodeCount || resp.Codes[0] == recoveryCodes[1] {
+			t.Errorf("unexpected codes: %v", resp.Codes)
+		}
+
+		_, err = login(recoveryCodes[1])
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// sessionInfoFromContext reads the client infos the gateway passed on as metadata
+func sessionInfoFromContext(ctx context.Context) models.SessionInfo {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return models.SessionInfo{}
+	}
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+	return models.SessionInfo{
+		UserAgent: first(metadataKeyUserAgent),
+		IPAddress: strings.TrimSpace(strings.Split(first(metadataKeyClientIP), ",")[0]),
+		Label:     first(metadataKeySessionLabel),
+	}
+}
+
+func sessionList(user models.User) *api.SessionList {
+	list := &api.SessionList{Sessions: []*api.Session{}}
+	for _, f := range user.Account.RefreshTokenFamilies {
+		list.Sessions = append(list.Sessions, f.ToAPI())
+	}
+	return list
+}
+
+func (s *userManagementServer) GetSessions(ctx context.Context, req *api.SessionMsg) (*api.SessionList, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return sessionList(user), nil
+}
+
+func (s *userManagementServer) RevokeSession(ctx context.Context, req *api.SessionMsg) (*api.SessionList, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if user.FindRefreshTokenFamily(req.SessionId) < 0 {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+
+	if err := s.userDBservice.RemoveRefreshTokenFamily(ctx, req.Token.InstanceId, req.Token.Id, req.SessionId); err != nil {
+		log.Printf("RevokeSession: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+	user, err = s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, logEventSessionRevoked, "")
+	return sessionList(user), nil
+}
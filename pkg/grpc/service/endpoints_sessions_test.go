@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	api_types "github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/memdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
+	"github.com/influenzanet/user-management-service/pkg/tokens"
+	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestSessionInfoFromContext(t *testing.T) {
+	if info := sessionInfoFromContext(context.Background()); info != (models.SessionInfo{}) {
+		t.Errorf("unexpected session info: %v", info)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		metadataKeyUserAgent, "Mozilla/5.0",
+		metadataKeyClientIP, "203.0.113.7, 10.0.0.1",
+		metadataKeySessionLabel, "work laptop",
+	))
+	info := sessionInfoFromContext(ctx)
+	if info.UserAgent != "Mozilla/5.0" || info.IPAddress != "203.0.113.7" || info.Label != "work laptop" {
+		t.Errorf("unexpected session info: %v", info)
+	}
+}
+
+func TestSessionEndpoints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// the store sets the last use of renewed sessions, so it has to use the same clock
+	fakeClock := clock.NewFake(time.Now())
+	userDB := memdb.NewUserDBService(fakeClock)
+	s := userManagementServer{
+		userDBservice:   userDB,
+		globalDBService: testGlobalDBService,
+		clock:           fakeClock,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		refreshTokens: models.RefreshTokenConfig{MaxSessions: 2},
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	email := "test-sessions@test.com"
+	userID, err := userDB.AddUser(context.Background(), testInstanceID, models.User{
+		Account: models.Account{
+			Type:               "email",
+			AccountID:          email,
+			AccountConfirmedAt: time.Now().Unix(),
+			Password:           hashedPw,
+		},
+		Roles:    []string{"PARTICIPANT"},
+		Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &api_types.TokenInfos{Id: userID, InstanceId: testInstanceID}
+
+	login := func(label string) *api.LoginResponse {
+		fakeClock.Advance(time.Minute)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataKeySessionLabel, label))
+		resp, err := s.LoginWithEmail(ctx, &api.LoginWithEmailMsg{
+			Email:      email,
+			Password:   password,
+			InstanceId: testInstanceID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	t.Run("get sessions without token", func(t *testing.T) {
+		_, err := s.GetSessions(context.Background(), &api.SessionMsg{})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "missing argument"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("least recently used session is removed", func(t *testing.T) {
+		first := login("first")
+		login("second")
+
+		// using the first session makes the second one the least recently used
+		fakeClock.Advance(time.Minute)
+		_, err := s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: first.Token.AccessToken, RefreshToken: first.Token.RefreshToken})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		login("third")
+
+		list, err := s.GetSessions(context.Background(), &api.SessionMsg{Token: token})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(list.Sessions) != 2 || list.Sessions[0].Label != "first" || list.Sessions[1].Label != "third" {
+			t.Errorf("unexpected sessions: %v", list)
+		}
+		if list.Sessions[0].LastUsedAt <= list.Sessions[0].CreatedAt {
+			t.Errorf("last use not updated: %v", list.Sessions[0])
+		}
+	})
+
+	t.Run("revoke session", func(t *testing.T) {
+		_, err := s.RevokeSession(context.Background(), &api.SessionMsg{Token: token, SessionId: "unknown"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "session not found"); !ok {
+			t.Error(msg)
+		}
+
+		list, _ := s.GetSessions(context.Background(), &api.SessionMsg{Token: token})
+		list, err = s.RevokeSession(context.Background(), &api.SessionMsg{Token: token, SessionId: list.Sessions[0].Id})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(list.Sessions) != 1 || list.Sessions[0].Label != "third" {
+			t.Errorf("unexpected sessions: %v", list)
+		}
+	})
+}
+
+func TestSessionLifetimes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	fakeClock := clock.NewFake(time.Now())
+	userDB := memdb.NewUserDBService(fakeClock)
+	s := userManagementServer{
+		userDBservice:   userDB,
+		globalDBService: testGlobalDBService,
+		clock:           fakeClock,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		refreshTokens: models.RefreshTokenConfig{
+			Lifetime: models.SessionLifetime{IdleTimeout: 3600, MaxAge: 3 * 3600},
+			RoleLifetimes: map[string]models.SessionLifetime{
+				"ADMIN": {IdleTimeout: 600},
+			},
+		},
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addUser := func(email string, roles []string) {
+		_, err := userDB.AddUser(context.Background(), testInstanceID, models.User{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          email,
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+			},
+			Roles:    roles,
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	addUser("participant-lifetime@test.com", []string{"PARTICIPANT"})
+	addUser("admin-lifetime@test.com", []string{"PARTICIPANT", "ADMIN"})
+
+	login := func(email string) *api.TokenResponse {
+		resp, err := s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:      email,
+			Password:   password,
+			InstanceId: testInstanceID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Token
+	}
+	renew := func(token *api.TokenResponse) (*api.TokenResponse, error) {
+		return s.RenewJWT(context.Background(), &api.RefreshJWTRequest{AccessToken: token.AccessToken, RefreshToken: token.RefreshToken})
+	}
+
+	t.Run("idle timeout", func(t *testing.T) {
+		token := login("participant-lifetime@test.com")
+		fakeClock.Advance(50 * time.Minute)
+		token, err := renew(token)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		fakeClock.Advance(61 * time.Minute)
+		_, err = renew(token)
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "session expired"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		token := login("participant-lifetime@test.com")
+		var err error
+		for i := 0; i < 4; i++ {
+			fakeClock.Advance(50 * time.Minute)
+			token, err = renew(token)
+			if err != nil {
+				break
+			}
+		}
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "session expired"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("shorter idle timeout for role", func(t *testing.T) {
+		token := login("admin-lifetime@test.com")
+		fakeClock.Advance(11 * time.Minute)
+		_, err := renew(token)
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "session expired"); !ok {
+			t.Error(msg)
+		}
+		user, _ := userDB.GetUserByAccountID(context.Background(), testInstanceID, "admin-lifetime@test.com")
+		if len(user.Account.RefreshTokenFamilies) != 0 {
+			t.Errorf("expired session should be removed: %v", user.Account.RefreshTokenFamilies)
+		}
+	})
+
+	t.Run("legacy refresh token", func(t *testing.T) {
+		addLegacyUser := func(email string, lastLogin time.Time) *api.TokenResponse {
+			id, err := userDB.AddUser(context.Background(), testInstanceID, models.User{
+				Account: models.Account{
+					Type:          "email",
+					AccountID:     email,
+					RefreshTokens: []string{email + "-token"},
+				},
+				Roles:      []string{"PARTICIPANT"},
+				Profiles:   []models.Profile{{ID: primitive.NewObjectID()}},
+				Timestamps: models.Timestamps{LastLogin: lastLogin.Unix()},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			accessToken, err := tokens.GenerateNewToken(s.accessTokens, id, true, "", []string{"PARTICIPANT"}, testInstanceID, s.Intervals.TokenExpiryInterval, "", nil, []string{}, fakeClock.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &api.TokenResponse{AccessToken: accessToken, RefreshToken: email + "-token"}
+		}
+
+		if _, err := renew(addLegacyUser("legacy-recent@test.com", fakeClock.Now().Add(-30*time.Minute))); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		_, err := renew(addLegacyUser("legacy-idle@test.com", fakeClock.Now().Add(-2*time.Hour)))
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "session expired"); !ok {
+			t.Error(msg)
+		}
+		user, _ := userDB.GetUserByAccountID(context.Background(), testInstanceID, "legacy-idle@test.com")
+		if len(user.Account.RefreshTokens) != 0 {
+			t.Errorf("expired legacy tokens should be removed: %v", user.Account.RefreshTokens)
+		}
+	})
+}
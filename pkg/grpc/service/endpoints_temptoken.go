@@ -24,13 +24,13 @@ func (s *userManagementServer) GetOrCreateTemptoken(ctx context.Context, t *api_
 	}
 
 	// Cleanup temptokens if this was not done recently:
-	now := time.Now().Unix()
+	now := s.clock.Now().Unix()
 	if lastTempTokenDeleteTime+deleteTempTokensMinInterval < now {
-		go s.CleanExpiredTemptokens(3600)
+		go s.CleanExpiredTemptokens(context.Background(), 3600)
 		lastTempTokenDeleteTime = now
 	}
 
-	tList, err := s.globalDBService.GetTempTokenForUser(t.InstanceId, t.UserId, t.Purpose)
+	tList, err := s.globalDBService.GetTempTokenForUser(ctx, t.InstanceId, t.UserId, t.Purpose)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -47,10 +47,10 @@ func (s *userManagementServer) GetOrCreateTemptoken(ctx context.Context, t *api_
 		}
 
 		if tempToken.Expiration == 0 {
-			tempToken.Expiration = tokens.GetExpirationTime(time.Hour * 24 * 10)
+			tempToken.Expiration = tokens.GetExpirationTime(time.Hour*24*10, s.clock.Now())
 		}
 
-		token, err := s.globalDBService.AddTempToken(tempToken)
+		token, err := s.globalDBService.AddTempToken(ctx, tempToken)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -67,9 +67,9 @@ func (s *userManagementServer) GenerateTempToken(ctx context.Context, t *api_typ
 	}
 
 	// Cleanup temptokens if this was not done recently:
-	now := time.Now().Unix()
+	now := s.clock.Now().Unix()
 	if lastTempTokenDeleteTime+deleteTempTokensMinInterval < now {
-		go s.CleanExpiredTemptokens(3600)
+		go s.CleanExpiredTemptokens(context.Background(), 3600)
 		lastTempTokenDeleteTime = now
 	}
 
@@ -82,10 +82,10 @@ func (s *userManagementServer) GenerateTempToken(ctx context.Context, t *api_typ
 	}
 
 	if tempToken.Expiration == 0 {
-		tempToken.Expiration = tokens.GetExpirationTime(time.Hour * 24 * 10)
+		tempToken.Expiration = tokens.GetExpirationTime(time.Hour*24*10, s.clock.Now())
 	}
 
-	token, err := s.globalDBService.AddTempToken(tempToken)
+	token, err := s.globalDBService.AddTempToken(ctx, tempToken)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -100,7 +100,7 @@ func (s *userManagementServer) GetTempTokens(ctx context.Context, t *api_types.T
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	tokens, err := s.globalDBService.GetTempTokenForUser(t.InstanceId, t.UserId, t.Purpose)
+	tokens, err := s.globalDBService.GetTempTokenForUser(ctx, t.InstanceId, t.UserId, t.Purpose)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -112,7 +112,7 @@ func (s *userManagementServer) DeleteTempToken(ctx context.Context, t *api.TempT
 	if t == nil || t.Token == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
-	if err := s.globalDBService.DeleteTempToken(t.Token); err != nil {
+	if err := s.globalDBService.DeleteTempToken(ctx, t.Token); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -127,7 +127,7 @@ func (s *userManagementServer) PurgeUserTempTokens(ctx context.Context, t *api_t
 	if t == nil || t.UserId == "" || t.InstanceId == "" {
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
-	if err := s.globalDBService.DeleteAllTempTokenForUser(t.InstanceId, t.UserId, t.Purpose); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, t.InstanceId, t.UserId, t.Purpose); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &api.ServiceStatus{
@@ -7,6 +7,7 @@ import (
 
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	"google.golang.org/grpc/status"
@@ -16,6 +17,7 @@ func TestGetOrCreateTemptokenEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -29,9 +31,9 @@ func TestGetOrCreateTemptokenEndpoint(t *testing.T) {
 		Info: map[string]string{
 			"key": "test_info",
 		},
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempToken)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -91,6 +93,7 @@ func TestGenerateTempTokenEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -144,6 +147,7 @@ func TestGetTempTokensEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -157,9 +161,9 @@ func TestGetTempTokensEndpoint(t *testing.T) {
 		Info: map[string]string{
 			"key": "test_info",
 		},
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempToken)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -224,6 +228,7 @@ func TestDeleteTempTokenEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -237,9 +242,9 @@ func TestDeleteTempTokenEndpoint(t *testing.T) {
 		Info: map[string]string{
 			"key": "test_info",
 		},
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempToken)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -276,7 +281,7 @@ func TestDeleteTempTokenEndpoint(t *testing.T) {
 			t.Errorf("wrong response: %s", resp)
 			return
 		}
-		tt, err := testGlobalDBService.GetTempToken(testTempToken.Token)
+		tt, err := testGlobalDBService.GetTempToken(context.Background(), testTempToken.Token)
 		if err != nil || len(tt.Token) < 5 {
 			t.Error("token should not be deleted yet")
 			return
@@ -292,7 +297,7 @@ func TestDeleteTempTokenEndpoint(t *testing.T) {
 			return
 		}
 
-		tt, err := testGlobalDBService.GetTempToken(testTempToken.Token)
+		tt, err := testGlobalDBService.GetTempToken(context.Background(), testTempToken.Token)
 		if err == nil || len(tt.Token) > 0 {
 			t.Error("token should be deleted by now")
 			return
@@ -304,6 +309,7 @@ func TestPurgeUserTempTokensEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -317,9 +323,9 @@ func TestPurgeUserTempTokensEndpoint(t *testing.T) {
 		Info: map[string]string{
 			"key": "test_info",
 		},
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempToken)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -357,7 +363,7 @@ func TestPurgeUserTempTokensEndpoint(t *testing.T) {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
 		}
-		tokens, err := testGlobalDBService.GetTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		tokens, err := testGlobalDBService.GetTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -377,7 +383,7 @@ func TestPurgeUserTempTokensEndpoint(t *testing.T) {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
 		}
-		tokens, err := testGlobalDBService.GetTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		tokens, err := testGlobalDBService.GetTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -398,7 +404,7 @@ func TestPurgeUserTempTokensEndpoint(t *testing.T) {
 			return
 		}
 
-		tokens, err := testGlobalDBService.GetTempTokenForUser(testTempToken.InstanceID, testTempToken.UserID, "")
+		tokens, err := testGlobalDBService.GetTempTokenForUser(context.Background(), testTempToken.InstanceID, testTempToken.UserID, "")
 		if err != nil {
 			t.Error(err)
 			return
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/totp"
+	"github.com/influenzanet/user-management-service/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *userManagementServer) StartTOTPEnrollment(ctx context.Context, req *api.TOTPMsg) (*api.TOTPEnrollmentResponse, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if s.totp.SecretKeys == nil {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP is not available")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if user.Account.TOTP.IsEnabled() {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Printf("StartTOTPEnrollment: unexpected error when generating secret -> %v", err)
+		return nil, status.Error(codes.Internal, "secret generation error")
+	}
+	encrypted, err := s.totp.SecretKeys.Encrypt(secret)
+	if err != nil {
+		log.Printf("StartTOTPEnrollment: unexpected error when encrypting secret -> %v", err)
+		return nil, status.Error(codes.Internal, "secret generation error")
+	}
+
+	// a pending enrollment is replaced, e.g. if the user did not finish setting up the app
+	_, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		if user.Account.TOTP.IsEnabled() {
+			return status.Error(codes.FailedPrecondition, "TOTP already enabled")
+		}
+		user.Account.TOTP = models.TOTP{Secret: encrypted}
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("StartTOTPEnrollment: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	return &api.TOTPEnrollmentResponse{
+		Secret:     secret,
+		OtpauthUri: totp.KeyURI(s.totp.Issuer, user.Account.AccountID, secret),
+	}, nil
+}
+
+func (s *userManagementServer) ConfirmTOTPEnrollment(ctx context.Context, req *api.TOTPMsg) (*api.User, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if user.Account.TOTP.IsEnabled() {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP already enabled")
+	}
+	if user.Account.TOTP.Secret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no pending TOTP enrollment")
+	}
+
+	user, err = s.useTOTPCode(ctx, req.Token.InstanceId, user, req.Code, func(user *models.User) {
+		user.Account.TOTP.ConfirmedAt = s.clock.Now().Unix()
+	})
+	if err != nil {
+		if err == totp.ErrInvalidCode {
+			return nil, status.Error(codes.InvalidArgument, "wrong verification code")
+		}
+		log.Printf("ConfirmTOTPEnrollment: unexpected error -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_LOG, logEventTOTPEnabled, "")
+	return user.ToAPI(), nil
+}
+
+func (s *userManagementServer) DisableTOTP(ctx context.Context, req *api.TOTPMsg) (*api.User, error) {
+	if req == nil || utils.IsTokenEmpty(req.Token) || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, req.Token.InstanceId, req.Token.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user and/or password")
+	}
+
+	if err := s.checkPasswordAgain(ctx, req.Token.InstanceId, user, req.Password, "disable TOTP endpoint"); err != nil {
+		return nil, err
+	}
+
+	if user.Account.TOTP.Secret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP not enabled")
+	}
+
+	user, err = userdb.UpdateUserWithRetry(ctx, s.userDBservice, req.Token.InstanceId, user, func(user *models.User) error {
+		user.Account.TOTP = models.TOTP{}
+		return nil
+	})
+	if err != nil {
+		log.Printf("DisableTOTP: unexpected error when saving user -> %v", err)
+		return nil, status.Error(codes.Internal, "user couldn't be updated")
+	}
+
+	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_SECURITY, logEventTOTPDisabled, "")
+	return user.ToAPI(), nil
+}
+
+// useTOTPCode checks the code against the user's TOTP secret and stores its time step, so that it is only accepted
+// once, also by concurrent requests. onSuccess can apply further changes to the user in the same update. The secret is
+// re-encrypted if it was encrypted with an old key. Returns totp.ErrInvalidCode if the code is not accepted.
+func (s *userManagementServer) useTOTPCode(ctx context.Context, instanceID string, user models.User, code string, onSuccess func(user *models.User)) (models.User, error) {
+	if s.totp.SecretKeys == nil {
+		return user, errors.New("TOTP secret keys are not configured")
+	}
+	updated, err := userdb.UpdateUserWithRetry(ctx, s.userDBservice, instanceID, user, func(user *models.User) error {
+		secret, err := s.totp.SecretKeys.Decrypt(user.Account.TOTP.Secret)
+		if err != nil {
+			return err
+		}
+		step, err := totp.Validate(secret, code, s.clock.Now(), user.Account.TOTP.LastUsedStep)
+		if err != nil {
+			return err
+		}
+		user.Account.TOTP.LastUsedStep = step
+		if s.totp.SecretKeys.NeedsReencryption(user.Account.TOTP.Secret) {
+			if user.Account.TOTP.Secret, err = s.totp.SecretKeys.Encrypt(secret); err != nil {
+				return err
+			}
+		}
+		if onSuccess != nil {
+			onSuccess(user)
+		}
+		return nil
+	})
+	if err != nil {
+		return user, err
+	}
+	return updated, nil
+}
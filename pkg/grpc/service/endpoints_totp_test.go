@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	api_types "github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/pwhash"
+	"github.com/influenzanet/user-management-service/pkg/totp"
+	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testTOTPConfig(t *testing.T) models.TOTPConfig {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := fieldcrypt.ParseSecretKeyring("k1:"+base64.StdEncoding.EncodeToString(key), "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return models.TOTPConfig{Issuer: "Test", SecretKeys: keyring}
+}
+
+func TestTOTPEndpoints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	clk := clock.NewFake(time.Now())
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clk,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		totp: testTOTPConfig(t),
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-totp@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &api_types.TokenInfos{Id: testUsers[0].ID.Hex(), InstanceId: testInstanceID}
+	secret := ""
+
+	login := func(code string) (*api.LoginResponse, error) {
+		return s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:            testUsers[0].Account.AccountID,
+			Password:         password,
+			InstanceId:       testInstanceID,
+			VerificationCode: code,
+		})
+	}
+	currentCode := func() string {
+		code, err := totp.GenerateCode(secret, clk.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	t.Run("start enrollment without token", func(t *testing.T) {
+		_, err := s.StartTOTPEnrollment(context.Background(), &api.TOTPMsg{})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "missing argument"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("start enrollment", func(t *testing.T) {
+		resp, err := s.StartTOTPEnrollment(context.Background(), &api.TOTPMsg{Token: token})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		secret = resp.Secret
+		if secret == "" || !strings.HasPrefix(resp.OtpauthUri, "otpauth://totp/Test:test-totp@test.com?") {
+			t.Errorf("unexpected response: %v", resp)
+		}
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, token.Id)
+		if user.Account.TOTP.Secret == "" || user.Account.TOTP.Secret == secret || user.Account.TOTP.IsEnabled() {
+			t.Errorf("secret should be stored encrypted and pending: %v", user.Account.TOTP)
+		}
+	})
+
+	t.Run("pending enrollment does not require a second factor", func(t *testing.T) {
+		resp, err := login("")
+		if err != nil || resp.SecondFactorNeeded || resp.Token == nil {
+			t.Errorf("unexpected response: %v, %v", resp, err)
+		}
+	})
+
+	t.Run("confirm with wrong code", func(t *testing.T) {
+		_, err := s.ConfirmTOTPEnrollment(context.Background(), &api.TOTPMsg{Token: token, Code: "000000"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verification code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("confirm enrollment", func(t *testing.T) {
+		resp, err := s.ConfirmTOTPEnrollment(context.Background(), &api.TOTPMsg{Token: token, Code: currentCode()})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !resp.Account.TotpEnabled {
+			t.Errorf("TOTP should be enabled: %v", resp.Account)
+		}
+		if _, err := s.StartTOTPEnrollment(context.Background(), &api.TOTPMsg{Token: token}); err == nil {
+			t.Error("enrollment should not be restarted while TOTP is enabled")
+		}
+	})
+
+	clk.Advance(totp.Period * time.Second)
+
+	t.Run("login asks for second factor", func(t *testing.T) {
+		resp, err := login("")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !resp.SecondFactorNeeded || resp.Token != nil || !resp.User.Account.TotpEnabled {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("login with wrong TOTP code", func(t *testing.T) {
+		_, err := login("123 456")
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	code := currentCode()
+	t.Run("login with TOTP code", func(t *testing.T) {
+		resp, err := login(code)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Token == nil || len(resp.Token.AccessToken) < 1 {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("TOTP code cannot be replayed", func(t *testing.T) {
+		_, err := login(code)
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "wrong verfication code"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("disable with wrong password", func(t *testing.T) {
+		_, err := s.DisableTOTP(context.Background(), &api.TOTPMsg{Token: token, Password: password + "w"})
+		if ok, msg := shouldHaveGrpcErrorStatus(err, "invalid user and/or password"); !ok {
+			t.Error(msg)
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		resp, err := s.DisableTOTP(context.Background(), &api.TOTPMsg{Token: token, Password: password})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if resp.Account.TotpEnabled {
+			t.Errorf("TOTP should be disabled: %v", resp.Account)
+		}
+		user, _ := testUserDBService.GetUserByID(context.Background(), testInstanceID, token.Id)
+		if user.Account.TOTP.Secret != "" {
+			t.Errorf("secret should be removed: %v", user.Account.TOTP)
+		}
+	})
+}
+
+func TestTOTPLoginWithEmailCode(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLoggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	mockLoggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	s := userManagementServer{
+		userDBservice:   testUserDBService,
+		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
+		Intervals: models.Intervals{
+			TokenExpiryInterval: time.Second * 2,
+		},
+		clients: &models.APIClients{
+			LoggingService: mockLoggingClient,
+		},
+		totp: testTOTPConfig(t),
+	}
+
+	password := "SuperSecurePassword123!§$"
+	hashedPw, err := pwhash.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := s.totp.SecretKeys.Encrypt(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUsers, err := addTestUsers([]models.User{
+		{
+			Account: models.Account{
+				Type:               "email",
+				AccountID:          "test-totp-2fa@test.com",
+				AccountConfirmedAt: time.Now().Unix(),
+				Password:           hashedPw,
+				AuthType:           "2FA",
+				VerificationCode: models.VerificationCode{
+					Code:      "456345",
+					ExpiresAt: time.Now().Unix() + 15,
+				},
+				TOTP: models.TOTP{Secret: encrypted, ConfirmedAt: time.Now().Unix()},
+			},
+			Roles:    []string{"PARTICIPANT"},
+			Profiles: []models.Profile{{ID: primitive.NewObjectID()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("emailed code is still accepted", func(t *testing.T) {
+		resp, err := s.LoginWithEmail(context.Background(), &api.LoginWithEmailMsg{
+			Email:            testUsers[0].Account.AccountID,
+			Password:         password,
+			InstanceId:       testInstanceID,
+			VerificationCode: testUsers[0].Account.VerificationCode.Code,
+		})
+		if err != nil || resp.Token == nil {
+			t.Errorf("unexpected response: %v, %v", resp, err)
+		}
+	})
+}
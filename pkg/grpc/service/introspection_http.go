@@ -0,0 +1,98 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// introspectionResponse is the JSON form of api.TokenIntrospection, active is always included as required by RFC 7662
+type introspectionResponse struct {
+	Active          bool     `json:"active"`
+	TokenType       string   `json:"token_type,omitempty"`
+	Sub             string   `json:"sub,omitempty"`
+	InstanceID      string   `json:"instance_id,omitempty"`
+	Roles           []string `json:"roles,omitempty"`
+	ProfileID       string   `json:"profile_id,omitempty"`
+	OtherProfileIDs []string `json:"other_profile_ids,omitempty"`
+	Exp             int64    `json:"exp,omitempty"`
+	Iat             int64    `json:"iat,omitempty"`
+	Iss             string   `json:"iss,omitempty"`
+	Aud             string   `json:"aud,omitempty"`
+	Jti             string   `json:"jti,omitempty"`
+	Username        string   `json:"username,omitempty"`
+}
+
+// IntrospectionHandler serves IntrospectToken as the HTTP endpoint of RFC 7662. The token and token_type_hint are
+// posted as form, the client authenticates with its app token in the header "Authorization: Bearer <app token>".
+func IntrospectionHandler(srv api.UserManagementApiServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		appToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if appToken == "" || appToken == r.Header.Get("Authorization") {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := srv.IntrospectToken(r.Context(), &api.IntrospectTokenMsg{
+			AppToken:      appToken,
+			Token:         r.PostForm.Get("token"),
+			TokenTypeHint: r.PostForm.Get("token_type_hint"),
+		})
+		if err != nil {
+			st := status.Convert(err)
+			switch {
+			case st.Message() == "invalid app token":
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			case st.Code() == codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+			default:
+				http.Error(w, "internal error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(introspectionResponse{
+			Active:          resp.Active,
+			TokenType:       resp.TokenType,
+			Sub:             resp.Sub,
+			InstanceID:      resp.InstanceId,
+			Roles:           resp.Roles,
+			ProfileID:       resp.ProfileId,
+			OtherProfileIDs: resp.OtherProfileIds,
+			Exp:             resp.Exp,
+			Iat:             resp.Iat,
+			Iss:             resp.Iss,
+			Aud:             resp.Aud,
+			Jti:             resp.Jti,
+			Username:        resp.Username,
+		})
+	})
+}
+
+// serveIntrospection offers token introspection over HTTP for resource servers without gRPC client
+func serveIntrospection(port string, srv api.UserManagementApiServer) {
+	mux := http.NewServeMux()
+	mux.Handle("/introspect", IntrospectionHandler(srv))
+	log.Println("serving token introspection on port " + port)
+	if err := http.ListenAndServe(":"+port, mux); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -27,13 +27,13 @@ func (s *userManagementServer) InitiatePasswordReset(ctx context.Context, req *a
 	}
 	req.AccountId = utils.SanitizeEmail(req.AccountId)
 
-	user, err := s.userDBservice.GetUserByAccountID(req.InstanceId, req.AccountId)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.InstanceId, req.AccountId)
 	if err != nil {
 		log.Printf("InitiatePasswordReset: %s", err.Error())
 		return nil, status.Error(codes.InvalidArgument, "invalid account id")
 	}
 
-	if utils.HasMoreAttemptsRecently(user.Account.PasswordResetTriggers, 5, passwordResetAttemptWindow) {
+	if utils.HasMoreAttemptsRecently(user.Account.PasswordResetTriggers, 5, passwordResetAttemptWindow, s.clock.Now().Unix()) {
 		log.Printf("SECURITY WARNING: password reset attempt blocked for email address for %s - too many tries recently", req.AccountId)
 		time.Sleep(5 * time.Second)
 		return nil, status.Error(codes.InvalidArgument, "account blocked for a while")
@@ -47,9 +47,9 @@ func (s *userManagementServer) InitiatePasswordReset(ctx context.Context, req *a
 		Info: map[string]string{
 			"email": user.Account.AccountID,
 		},
-		Expiration: tokens.GetExpirationTime(time.Hour * 24),
+		Expiration: tokens.GetExpirationTime(time.Hour*24, s.clock.Now()),
 	}
-	tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+	tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -70,7 +70,7 @@ func (s *userManagementServer) InitiatePasswordReset(ctx context.Context, req *a
 	}
 	// <---
 
-	if err2 := s.userDBservice.SavePasswordResetTrigger(req.InstanceId, user.ID.Hex()); err != nil {
+	if err2 := s.userDBservice.SavePasswordResetTrigger(ctx, req.InstanceId, user.ID.Hex()); err != nil {
 		log.Printf("DB ERROR: unexpected error when updating user: %s ", err2.Error())
 	}
 
@@ -89,7 +89,7 @@ func (s *userManagementServer) GetInfosForPasswordReset(ctx context.Context, req
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	tokenInfos, err := s.ValidateTempToken(req.Token, []string{
+	tokenInfos, err := s.ValidateTempToken(ctx, req.Token, []string{
 		constants.TOKEN_PURPOSE_PASSWORD_RESET,
 		constants.TOKEN_PURPOSE_INVITATION,
 	})
@@ -98,7 +98,7 @@ func (s *userManagementServer) GetInfosForPasswordReset(ctx context.Context, req
 		return nil, status.Error(codes.InvalidArgument, "wrong token")
 	}
 
-	user, err := s.userDBservice.GetUserByID(tokenInfos.InstanceID, tokenInfos.UserID)
+	user, err := s.userDBservice.GetUserByID(ctx, tokenInfos.InstanceID, tokenInfos.UserID)
 	if err != nil {
 		log.Printf("GetInfosForPasswordReset: %s", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -114,7 +114,7 @@ func (s *userManagementServer) ResetPassword(ctx context.Context, req *api.Reset
 		return nil, status.Error(codes.InvalidArgument, "missing argument")
 	}
 
-	tokenInfos, err := s.ValidateTempToken(req.Token,
+	tokenInfos, err := s.ValidateTempToken(ctx, req.Token,
 		[]string{
 			constants.TOKEN_PURPOSE_INVITATION,
 			constants.TOKEN_PURPOSE_PASSWORD_RESET,
@@ -134,13 +134,18 @@ func (s *userManagementServer) ResetPassword(ctx context.Context, req *api.Reset
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	err = s.userDBservice.UpdateUserPassword(tokenInfos.InstanceID, tokenInfos.UserID, password)
+	err = s.userDBservice.UpdateUserPassword(ctx, tokenInfos.InstanceID, tokenInfos.UserID, password)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	log.Printf("user %s initiated password change", tokenInfos.UserID)
 
-	user, err := s.userDBservice.GetUserByID(tokenInfos.InstanceID, tokenInfos.UserID)
+	if err := s.endAllSessionsOfUser(ctx, tokenInfos.InstanceID, tokenInfos.UserID); err != nil {
+		log.Printf("ResetPassword: %s", err.Error())
+		return nil, status.Error(codes.Internal, "sessions could not be ended")
+	}
+
+	user, err := s.userDBservice.GetUserByID(ctx, tokenInfos.InstanceID, tokenInfos.UserID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -149,7 +154,7 @@ func (s *userManagementServer) ResetPassword(ctx context.Context, req *api.Reset
 		newContactPrefs := user.ContactPreferences
 		newContactPrefs.SubscribedToNewsletter = true
 		newContactPrefs.SubscribedToWeekly = true
-		_, err = s.userDBservice.UpdateContactPreferences(tokenInfos.InstanceID, tokenInfos.UserID, newContactPrefs)
+		_, err = s.userDBservice.UpdateContactPreferences(ctx, tokenInfos.InstanceID, tokenInfos.UserID, newContactPrefs)
 		if err != nil {
 			log.Printf("unexpected error when updating contact preferences: %v", err)
 		}
@@ -169,7 +174,7 @@ func (s *userManagementServer) ResetPassword(ctx context.Context, req *api.Reset
 	// ---
 
 	// remove all temptokens for password reset:
-	if err := s.globalDBService.DeleteAllTempTokenForUser(tokenInfos.InstanceID, tokenInfos.UserID, constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
+	if err := s.globalDBService.DeleteAllTempTokenForUser(ctx, tokenInfos.InstanceID, tokenInfos.UserID, constants.TOKEN_PURPOSE_PASSWORD_RESET); err != nil {
 		log.Printf("ChangePassword: %s", err.Error())
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/influenzanet/go-utils/pkg/constants"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
 	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
@@ -24,6 +25,7 @@ func TestInitiatePasswordResetEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -106,6 +108,7 @@ func TestGetInfosForPasswordResetEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -139,7 +142,7 @@ func TestGetInfosForPasswordResetEndpoint(t *testing.T) {
 		Purpose:    constants.TOKEN_PURPOSE_PASSWORD_RESET,
 		Expiration: time.Now().Unix() - 10,
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempTokenOld)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempTokenOld)
 	if err != nil {
 		t.Error(err)
 		return
@@ -150,9 +153,9 @@ func TestGetInfosForPasswordResetEndpoint(t *testing.T) {
 		UserID:     testUsers[0].ID.Hex(),
 		InstanceID: testInstanceID,
 		Purpose:    constants.TOKEN_PURPOSE_PASSWORD_RESET,
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err = testGlobalDBService.AddTempToken(testTempToken)
+	token, err = testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -219,6 +222,7 @@ func TestResetPasswordEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -232,8 +236,12 @@ func TestResetPasswordEndpoint(t *testing.T) {
 	testUsers, err := addTestUsers([]models.User{
 		{
 			Account: models.Account{
-				Type:      "email",
-				AccountID: "test_for_pwreset@test.com",
+				Type:          "email",
+				AccountID:     "test_for_pwreset@test.com",
+				RefreshTokens: []string{"legacy-refresh-token"},
+				RefreshTokenFamilies: []models.RefreshTokenFamily{
+					models.NewRefreshTokenFamily("refresh-token", models.SessionInfo{}, time.Now().Unix()),
+				},
 			},
 			ContactInfos: []models.ContactInfo{
 				{
@@ -256,7 +264,7 @@ func TestResetPasswordEndpoint(t *testing.T) {
 		Purpose:    constants.TOKEN_PURPOSE_PASSWORD_RESET,
 		Expiration: time.Now().Unix() - 10,
 	}
-	token, err := testGlobalDBService.AddTempToken(testTempTokenOld)
+	token, err := testGlobalDBService.AddTempToken(context.Background(), testTempTokenOld)
 	if err != nil {
 		t.Error(err)
 		return
@@ -267,9 +275,9 @@ func TestResetPasswordEndpoint(t *testing.T) {
 		UserID:     testUsers[0].ID.Hex(),
 		InstanceID: testInstanceID,
 		Purpose:    constants.TOKEN_PURPOSE_PASSWORD_RESET,
-		Expiration: tokens.GetExpirationTime(10 * time.Second),
+		Expiration: tokens.GetExpirationTime(10*time.Second, time.Now()),
 	}
-	token, err = testGlobalDBService.AddTempToken(testTempToken)
+	token, err = testGlobalDBService.AddTempToken(context.Background(), testTempToken)
 	if err != nil {
 		t.Error(err)
 		return
@@ -343,5 +351,14 @@ func TestResetPasswordEndpoint(t *testing.T) {
 			t.Errorf("unexpected error: %s", err.Error())
 			return
 		}
+
+		u, err := testUserDBService.GetUserByID(context.Background(), testInstanceID, testUsers[0].ID.Hex())
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if len(u.Account.RefreshTokens) > 0 || len(u.Account.RefreshTokenFamilies) > 0 {
+			t.Errorf("sessions should be ended: %v, %v", u.Account.RefreshTokens, u.Account.RefreshTokenFamilies)
+		}
 	})
 }
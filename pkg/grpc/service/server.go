@@ -8,9 +8,12 @@ import (
 	"os/signal"
 
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
 	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/oidc"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
 	"google.golang.org/grpc"
 )
 
@@ -22,51 +25,75 @@ const (
 type userManagementServer struct {
 	api.UnimplementedUserManagementApiServer
 	clients           *models.APIClients
-	userDBservice     *userdb.UserDBService
-	globalDBService   *globaldb.GlobalDBService
+	userDBservice     userdb.UserStore
+	globalDBService   globaldb.GlobalStore
 	Intervals         models.Intervals
+	accessTokens      models.AccessTokenConfig
 	newUserCountLimit int64
+	totp              models.TOTPConfig
+	relyingParty      webauthn.RelyingParty
+	passwordlessLogin models.PasswordlessLoginConfig
+	refreshTokens     models.RefreshTokenConfig
+	externalIDPs      oidc.Config
+	clock             clock.Clock
+}
+
+// Config holds the dependencies and settings of the service
+type Config struct {
+	Clients           *models.APIClients
+	UserDBService     userdb.UserStore
+	GlobalDBService   globaldb.GlobalStore
+	Intervals         models.Intervals
+	AccessTokens      models.AccessTokenConfig
+	NewUserCountLimit int64
+	TOTP              models.TOTPConfig
+	RelyingParty      webauthn.RelyingParty
+	PasswordlessLogin models.PasswordlessLoginConfig
+	RefreshTokens     models.RefreshTokenConfig
+	ExternalIDPs      oidc.Config
+	Clock             clock.Clock // defaults to clock.Real
+	// IntrospectionHTTPPort enables IntrospectToken over HTTP on this port (RunServer only)
+	IntrospectionHTTPPort string
 }
 
 // NewUserManagementServer creates a new service instance
-func NewUserManagementServer(
-	clients *models.APIClients,
-	userDBservice *userdb.UserDBService,
-	globalDBservice *globaldb.GlobalDBService,
-	intervals models.Intervals,
-	newUserCountLimit int64,
-) api.UserManagementApiServer {
+func NewUserManagementServer(conf Config) api.UserManagementApiServer {
+	clk := conf.Clock
+	if clk == nil {
+		clk = clock.Real
+	}
 	return &userManagementServer{
-		clients:           clients,
-		userDBservice:     userDBservice,
-		globalDBService:   globalDBservice,
-		Intervals:         intervals,
-		newUserCountLimit: newUserCountLimit,
+		clients:           conf.Clients,
+		userDBservice:     conf.UserDBService,
+		globalDBService:   conf.GlobalDBService,
+		Intervals:         conf.Intervals,
+		accessTokens:      conf.AccessTokens,
+		newUserCountLimit: conf.NewUserCountLimit,
+		totp:              conf.TOTP,
+		relyingParty:      conf.RelyingParty,
+		passwordlessLogin: conf.PasswordlessLogin,
+		refreshTokens:     conf.RefreshTokens,
+		externalIDPs:      conf.ExternalIDPs,
+		clock:             clk,
 	}
 }
 
-// RunServer runs gRPC service to publish ToDo service
-func RunServer(ctx context.Context, port string,
-	clients *models.APIClients,
-	userDBservice *userdb.UserDBService,
-	globalDBservice *globaldb.GlobalDBService,
-	intervals models.Intervals,
-	newUserCountLimit int64,
-) error {
+// RunServer runs gRPC service to publish ToDo service. The HTTP introspection endpoint, if enabled, is served by the
+// same service instance.
+func RunServer(ctx context.Context, port string, conf Config) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	srv := NewUserManagementServer(conf)
+	if conf.IntrospectionHTTPPort != "" {
+		go serveIntrospection(conf.IntrospectionHTTPPort, srv)
+	}
+
 	// register service
 	server := grpc.NewServer()
-	api.RegisterUserManagementApiServer(server, NewUserManagementServer(
-		clients,
-		userDBservice,
-		globalDBservice,
-		intervals,
-		newUserCountLimit,
-	))
+	api.RegisterUserManagementApiServer(server, srv)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -1,15 +1,15 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"time"
 
 	"github.com/coneno/logger"
 	"github.com/influenzanet/user-management-service/pkg/models"
 )
 
-func (s *userManagementServer) CleanExpiredTemptokens(offset int64) {
-	err := s.globalDBService.DeleteTempTokensExpireBefore("", "", time.Now().Unix()-offset)
+func (s *userManagementServer) CleanExpiredTemptokens(ctx context.Context, offset int64) {
+	err := s.globalDBService.DeleteTempTokensExpireBefore(ctx, "", "", s.clock.Now().Unix()-offset)
 	if err != nil {
 		logger.Error.Printf("unexpected error while deleting expired temp tokens: %v", err)
 		return
@@ -17,14 +17,14 @@ func (s *userManagementServer) CleanExpiredTemptokens(offset int64) {
 	logger.Debug.Println("Expired temp tokens cleaned up.")
 }
 
-func (s *userManagementServer) ValidateTempToken(token string, purposes []string) (tt *models.TempToken, err error) {
-	tokenInfos, err := s.globalDBService.GetTempToken(token)
+func (s *userManagementServer) ValidateTempToken(ctx context.Context, token string, purposes []string) (tt *models.TempToken, err error) {
+	tokenInfos, err := s.globalDBService.GetTempToken(ctx, token)
 	if err != nil {
 		return nil, errors.New("wrong token")
 	}
 
-	if time.Now().Unix() > tokenInfos.Expiration {
-		_ = s.globalDBService.DeleteTempToken(tokenInfos.Token)
+	if s.clock.Now().Unix() > tokenInfos.Expiration {
+		_ = s.globalDBService.DeleteTempToken(ctx, tokenInfos.Token)
 		return &tokenInfos, errors.New("token expired")
 	}
 
@@ -43,7 +43,7 @@ func (s *userManagementServer) CreateUser(ctx context.Context, req *api.CreateUs
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	accountCreatedAt := time.Now().Unix() + userCreationTimestampOffset
+	accountCreatedAt := s.clock.Now().Unix() + userCreationTimestampOffset
 	if req.CreatedAt > 0 {
 		accountCreatedAt = req.CreatedAt
 	}
@@ -70,7 +70,7 @@ func (s *userManagementServer) CreateUser(ctx context.Context, req *api.CreateUs
 			ID:                 primitive.NewObjectID(),
 			Alias:              utils.BlurEmailAddress(req.AccountId),
 			AvatarID:           "default",
-			ConsentConfirmedAt: time.Now().Unix(),
+			ConsentConfirmedAt: s.clock.Now().Unix(),
 			MainProfile:        true,
 		})
 	} else {
@@ -79,13 +79,13 @@ func (s *userManagementServer) CreateUser(ctx context.Context, req *api.CreateUs
 				ID:                 primitive.NewObjectID(),
 				Alias:              pn,
 				AvatarID:           "default",
-				ConsentConfirmedAt: time.Now().Unix(),
+				ConsentConfirmedAt: s.clock.Now().Unix(),
 				MainProfile:        i == 0,
 			})
 		}
 	}
 
-	newUser.AddNewEmail(req.AccountId, false)
+	newUser.AddNewEmail(req.AccountId, false, s.clock.Now().Unix())
 	if req.Use_2Fa {
 		newUser.Account.AuthType = "2FA"
 	}
@@ -95,7 +95,7 @@ func (s *userManagementServer) CreateUser(ctx context.Context, req *api.CreateUs
 	newUser.ContactPreferences.ReceiveWeeklyMessageDayOfWeek = int32(rand.Intn(7))
 
 	instanceID := req.Token.InstanceId
-	id, err := s.userDBservice.AddUser(instanceID, newUser)
+	id, err := s.userDBservice.AddUser(ctx, instanceID, newUser)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -110,9 +110,9 @@ func (s *userManagementServer) CreateUser(ctx context.Context, req *api.CreateUs
 			"type":  "email",
 			"email": newUser.Account.AccountID,
 		},
-		Expiration: tokens.GetExpirationTime(time.Hour * 24 * 28),
+		Expiration: tokens.GetExpirationTime(time.Hour*24*28, s.clock.Now()),
 	}
-	tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+	tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -146,14 +146,11 @@ func (s *userManagementServer) AddRoleForUser(ctx context.Context, req *api.Role
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
 
-	user, err := s.userDBservice.GetUserByAccountID(req.Token.InstanceId, req.AccountId)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.Token.InstanceId, req.AccountId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if err := user.AddRole(req.Role); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	user, err = s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	user, err = s.userDBservice.AddRole(ctx, req.Token.InstanceId, user.ID.Hex(), req.Role)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -170,17 +167,17 @@ func (s *userManagementServer) RemoveRoleForUser(ctx context.Context, req *api.R
 	if !utils.CheckRoleInToken(req.Token, constants.USER_ROLE_ADMIN) {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
-	user, err := s.userDBservice.GetUserByAccountID(req.Token.InstanceId, req.AccountId)
+	user, err := s.userDBservice.GetUserByAccountID(ctx, req.Token.InstanceId, req.AccountId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if err := user.RemoveRole(req.Role); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	user, err = s.userDBservice.UpdateUser(req.Token.InstanceId, user)
+	user, err = s.userDBservice.RemoveRole(ctx, req.Token.InstanceId, user.ID.Hex(), req.Role)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if err := s.revokeAccessTokensOfUser(ctx, req.Token.InstanceId, user.ID.Hex()); err != nil {
+		log.Printf("RemoveRoleForUser: %s", err.Error())
+	}
 
 	s.SaveLogEvent(req.Token.InstanceId, req.Token.Id, loggingAPI.LogEventType_LOG, constants.LOG_EVENT_ACCOUNT_ROLE_REMOVED, user.Account.AccountID+"("+user.ID.Hex()+") - "+req.Role)
 	return user.ToAPI(), nil
@@ -194,7 +191,7 @@ func (s *userManagementServer) FindNonParticipantUsers(ctx context.Context, req
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
 
-	users, err := s.userDBservice.FindNonParticipantUsers(req.Token.InstanceId)
+	users, err := s.userDBservice.FindNonParticipantUsers(ctx, req.Token.InstanceId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -212,7 +209,7 @@ func (s *userManagementServer) StreamUsers(req *api.StreamUsersMsg, stream api.U
 		return status.Error(codes.InvalidArgument, "missing arguments")
 	}
 
-	ctx := context.Background()
+	ctx := stream.Context()
 
 	sendUserOverGrpc := func(instanceID string, user models.User, args ...interface{}) error {
 		if len(args) != 1 {
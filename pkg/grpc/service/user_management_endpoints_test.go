@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	loggingMock "github.com/influenzanet/user-management-service/test/mocks/logging_service"
 	messageMock "github.com/influenzanet/user-management-service/test/mocks/messaging_service"
@@ -23,6 +24,7 @@ func TestCreateUserEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -137,6 +139,7 @@ func TestAddRoleForUserEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -253,6 +256,7 @@ func TestRemoveRoleForUserEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -364,6 +368,7 @@ func TestFindNonParticipantUsersEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -458,6 +463,14 @@ func TestFindNonParticipantUsersEndpoint(t *testing.T) {
 type UserManagementServiceAPI_GetUsers struct {
 	grpc.ServerStream
 	Results []*api.User
+	ctx     context.Context
+}
+
+func (_m *UserManagementServiceAPI_GetUsers) Context() context.Context {
+	if _m.ctx == nil {
+		return context.Background()
+	}
+	return _m.ctx
 }
 
 func (_m *UserManagementServiceAPI_GetUsers) Send(user *api.User) error {
@@ -469,6 +482,7 @@ func TestStreamUsersEndpoint(t *testing.T) {
 	s := userManagementServer{
 		userDBservice:   testUserDBService,
 		globalDBService: testGlobalDBService,
+		clock:           clock.Real,
 		Intervals: models.Intervals{
 			TokenExpiryInterval:      time.Second * 2,
 			VerificationCodeLifetime: 60,
@@ -545,4 +559,19 @@ func TestStreamUsersEndpoint(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("with cancelled stream context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mock := &UserManagementServiceAPI_GetUsers{ctx: ctx}
+		req := &api.StreamUsersMsg{InstanceId: testInstanceID}
+		err := s.StreamUsers(req, mock)
+		if err == nil {
+			t.Error("should return an error")
+			return
+		}
+		if len(mock.Results) > 0 {
+			t.Errorf("unexpected number of users: %d", len(mock.Results))
+		}
+	})
 }
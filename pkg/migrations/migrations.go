@@ -0,0 +1,154 @@
+// Package migrations brings stored user documents to the current shape of models.User. Migrations are ordered by
+// version, the last applied version is recorded per instance in the user DB.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// Migration transforms a single user document. Apply must be idempotent and report whether the user was changed,
+// since a migration can run again on the same document if a previous run was interrupted.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(user *models.User) (changed bool)
+}
+
+// Result summarises what a migration did (or would do in dry-run mode) for one instance
+type Result struct {
+	InstanceID   string
+	Version      int
+	Description  string
+	ChangedUsers int
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: migration %d (%s) - %d users changed", r.InstanceID, r.Version, r.Description, r.ChangedUsers)
+}
+
+type Runner struct {
+	userDBService   userdb.UserStore
+	globalDBService globaldb.GlobalStore
+	migrations      []Migration
+}
+
+func NewRunner(userDBService userdb.UserStore, globalDBService globaldb.GlobalStore, migrations []Migration) *Runner {
+	return &Runner{
+		userDBService:   userDBService,
+		globalDBService: globalDBService,
+		migrations:      migrations,
+	}
+}
+
+// LatestVersion returns the schema version after all migrations are applied
+func (r *Runner) LatestVersion() int {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
+
+func (r *Runner) checkOrder() error {
+	for i := 1; i < len(r.migrations); i++ {
+		if r.migrations[i].Version <= r.migrations[i-1].Version {
+			return fmt.Errorf("migrations not ordered: version %d after %d", r.migrations[i].Version, r.migrations[i-1].Version)
+		}
+	}
+	return nil
+}
+
+// Run applies pending migrations for all instances. With dryRun, nothing is written but the results show what would change.
+func (r *Runner) Run(ctx context.Context, dryRun bool) ([]Result, error) {
+	instances, err := r.globalDBService.GetAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for _, instance := range instances {
+		res, err := r.RunForInstance(ctx, instance.InstanceID, dryRun)
+		results = append(results, res...)
+		if err != nil {
+			return results, fmt.Errorf("%s: %v", instance.InstanceID, err)
+		}
+	}
+	return results, nil
+}
+
+// RunForInstance applies all migrations newer than the recorded schema version of the instance. Users are
+// loaded once and all pending migrations are applied in order before the user is saved.
+func (r *Runner) RunForInstance(ctx context.Context, instanceID string, dryRun bool) ([]Result, error) {
+	if err := r.checkOrder(); err != nil {
+		return nil, err
+	}
+
+	currentVersion, err := r.userDBService.GetSchemaVersion(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+	results := []Result{}
+	for _, m := range r.migrations {
+		if m.Version > currentVersion {
+			pending = append(pending, m)
+			results = append(results, Result{InstanceID: instanceID, Version: m.Version, Description: m.Description})
+		}
+	}
+	if len(pending) == 0 {
+		logger.Debug.Printf("%s: user schema up to date (version %d)", instanceID, currentVersion)
+		return results, nil
+	}
+
+	// errSkipUpdate stops UpdateUserWithRetry before saving
+	errSkipUpdate := errors.New("skip update")
+	migrateUser := func(instanceID string, user models.User, args ...interface{}) error {
+		var changedBy []bool
+		_, err := userdb.UpdateUserWithRetry(ctx, r.userDBService, instanceID, user, func(user *models.User) error {
+			// migrations are applied again if the user was modified concurrently
+			changedBy = make([]bool, len(pending))
+			changed := false
+			for i, m := range pending {
+				changedBy[i] = m.Apply(user)
+				changed = changed || changedBy[i]
+			}
+			if !changed || dryRun {
+				return errSkipUpdate
+			}
+			return nil
+		})
+		if err != nil && err != errSkipUpdate {
+			return err
+		}
+		for i, c := range changedBy {
+			if c {
+				results[i].ChangedUsers += 1
+			}
+		}
+		return nil
+	}
+
+	err = r.userDBService.PerfomActionForUsers(ctx, instanceID, userdb.UserFilter{ReminderWeekDay: -1}, migrateUser)
+	if err != nil {
+		return results, err
+	}
+
+	for _, res := range results {
+		if dryRun {
+			logger.Info.Printf("[dry-run] %s", res)
+		} else {
+			logger.Info.Printf("%s", res)
+		}
+	}
+	if dryRun {
+		return results, nil
+	}
+	return results, r.userDBService.SetSchemaVersion(ctx, instanceID, r.LatestVersion())
+}
@@ -0,0 +1,132 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/memdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+const testInstanceID = "test-instance"
+
+func addTestUsers(t *testing.T, userDB *memdb.UserDBService) (oldUserID string, currentUserID string) {
+	oldUser := models.User{
+		Account: models.Account{
+			AccountID: "old@test.com",
+		},
+		Profiles: []models.Profile{{Alias: "old"}, {Alias: "second"}},
+	}
+	currentUser := models.User{
+		Account: models.Account{
+			Type:                  models.ACCOUNT_TYPE_EMAIL,
+			AccountID:             "current@test.com",
+			RefreshTokens:         []string{},
+			FailedLoginAttempts:   []int64{},
+			PasswordResetTriggers: []int64{},
+		},
+		Roles:    []string{constants.USER_ROLE_PARTICIPANT},
+		Profiles: []models.Profile{{Alias: "current", MainProfile: true}},
+	}
+	var err error
+	if oldUserID, err = userDB.AddUser(context.Background(), testInstanceID, oldUser); err != nil {
+		t.Fatal(err)
+	}
+	if currentUserID, err = userDB.AddUser(context.Background(), testInstanceID, currentUser); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestRunner(t *testing.T) {
+	userDB := memdb.NewUserDBService(clock.Real)
+	globalDB := memdb.NewGlobalDBService()
+	globalDB.AddInstance(global_types.Instance{InstanceID: testInstanceID})
+	oldUserID, currentUserID := addTestUsers(t, userDB)
+
+	runner := NewRunner(userDB, globalDB, UserMigrations)
+
+	t.Run("dry run", func(t *testing.T) {
+		results, err := runner.Run(context.Background(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != len(UserMigrations) {
+			t.Fatalf("unexpected number of results: %d", len(results))
+		}
+		for _, r := range results {
+			if r.ChangedUsers != 1 {
+				t.Errorf("%s: expected exactly one user to change", r)
+			}
+		}
+		user, _ := userDB.GetUserByID(context.Background(), testInstanceID, oldUserID)
+		if user.Account.Type != "" {
+			t.Error("dry run should not modify users")
+		}
+		if v, _ := userDB.GetSchemaVersion(context.Background(), testInstanceID); v != 0 {
+			t.Errorf("dry run should not record schema version: %d", v)
+		}
+	})
+
+	t.Run("apply migrations", func(t *testing.T) {
+		if _, err := runner.Run(context.Background(), false); err != nil {
+			t.Fatal(err)
+		}
+		user, _ := userDB.GetUserByID(context.Background(), testInstanceID, oldUserID)
+		if user.Account.Type != models.ACCOUNT_TYPE_EMAIL {
+			t.Errorf("unexpected account type: %s", user.Account.Type)
+		}
+		if !user.Profiles[0].MainProfile || user.Profiles[1].MainProfile {
+			t.Errorf("first profile should be main profile: %v", user.Profiles)
+		}
+		if !user.HasRole(constants.USER_ROLE_PARTICIPANT) || user.Account.RefreshTokens == nil {
+			t.Errorf("defaults not set: %v", user)
+		}
+		current, _ := userDB.GetUserByID(context.Background(), testInstanceID, currentUserID)
+		if current.Timestamps.UpdatedAt != 0 {
+			t.Error("unchanged user should not be saved")
+		}
+		if v, _ := userDB.GetSchemaVersion(context.Background(), testInstanceID); v != runner.LatestVersion() {
+			t.Errorf("unexpected schema version: %d", v)
+		}
+	})
+
+	t.Run("only pending migrations run", func(t *testing.T) {
+		applied := false
+		extended := append(append([]Migration{}, UserMigrations...), Migration{
+			Version:     runner.LatestVersion() + 1,
+			Description: "test",
+			Apply: func(user *models.User) bool {
+				applied = true
+				return false
+			},
+		})
+		results, err := NewRunner(userDB, globalDB, extended).RunForInstance(context.Background(), testInstanceID, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 || !applied {
+			t.Errorf("unexpected results: %v", results)
+		}
+	})
+
+	t.Run("unordered migrations", func(t *testing.T) {
+		unordered := []Migration{UserMigrations[1], UserMigrations[0]}
+		if _, err := NewRunner(userDB, globalDB, unordered).RunForInstance(context.Background(), testInstanceID, true); err == nil {
+			t.Error("error expected")
+		}
+	})
+}
+
+func TestUserMigrationsAreIdempotent(t *testing.T) {
+	user := models.User{Profiles: []models.Profile{{Alias: "a"}}}
+	for _, m := range UserMigrations {
+		m.Apply(&user)
+		if m.Apply(&user) {
+			t.Errorf("migration %d changed the user a second time", m.Version)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// UserMigrations is the ordered list of migrations for user documents. Append new migrations with a higher version,
+// never change or reorder released ones.
+var UserMigrations = []Migration{
+	{
+		Version:     1,
+		Description: "set missing account type to email",
+		Apply: func(user *models.User) bool {
+			if user.Account.Type != "" {
+				return false
+			}
+			user.Account.Type = models.ACCOUNT_TYPE_EMAIL
+			return true
+		},
+	},
+	{
+		Version:     2,
+		Description: "mark first profile as main profile if none is marked",
+		Apply: func(user *models.User) bool {
+			if len(user.Profiles) == 0 {
+				return false
+			}
+			for _, p := range user.Profiles {
+				if p.MainProfile {
+					return false
+				}
+			}
+			user.Profiles[0].MainProfile = true
+			return true
+		},
+	},
+	{
+		Version:     3,
+		Description: "replace missing roles and lists with defaults",
+		Apply: func(user *models.User) bool {
+			changed := false
+			if len(user.Roles) == 0 {
+				user.Roles = []string{constants.USER_ROLE_PARTICIPANT}
+				changed = true
+			}
+			if user.Account.RefreshTokens == nil {
+				user.Account.RefreshTokens = []string{}
+				changed = true
+			}
+			if user.Account.FailedLoginAttempts == nil {
+				user.Account.FailedLoginAttempts = []int64{}
+				changed = true
+			}
+			if user.Account.PasswordResetTriggers == nil {
+				user.Account.PasswordResetTriggers = []int64{}
+				changed = true
+			}
+			return changed
+		},
+	},
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/influenzanet/user-management-service/pkg/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Account holds information about user authentication methods
@@ -12,9 +13,14 @@ type Account struct {
 	Password           string           `bson:"password"`
 	AuthType           string           `bson:"authType"`
 	VerificationCode   VerificationCode `bson:"verificationCode"`
-	RefreshTokens      []string         `bson:"refreshTokens"`
+	TOTP               TOTP             `bson:"totp"`
+	Passkeys           []Passkey        `bson:"passkeys"`
+	RecoveryCodes      []string         `bson:"recoveryCodes"` // hashed, a code is removed once it was used
+	RefreshTokens      []string         `bson:"refreshTokens"` // issued before refresh token families, moved into a family when renewed
 	PreferredLanguage  string           `bson:"preferredLanguage"`
 
+	RefreshTokenFamilies []RefreshTokenFamily `bson:"refreshTokenFamilies,omitempty"`
+
 	// Rate limiting
 	FailedLoginAttempts   []int64 `bson:"failedLoginAttempts"`
 	PasswordResetTriggers []int64 `bson:"passwordResetTriggers"`
@@ -28,6 +34,113 @@ type VerificationCode struct {
 	ExpiresAt int64  `bson:"expiresAt"`
 }
 
+// RefreshTokenFamily holds the refresh tokens issued for one login, i.e. a session. Each renewal replaces Token and
+// keeps the replaced one in RotatedTokens, so that a token presented again after its rotation can be recognized.
+type RefreshTokenFamily struct {
+	ID            string   `bson:"id"`
+	Token         string   `bson:"token"`
+	RotatedTokens []string `bson:"rotatedTokens"` // only the newest MaxRotatedRefreshTokens
+	CreatedAt     int64    `bson:"createdAt"`
+	LastUsedAt    int64    `bson:"lastUsedAt"` // login or last renewal
+
+	SessionInfo `bson:",inline"`
+}
+
+// SessionInfo describes the client a session was started from, as passed on by the gateway
+type SessionInfo struct {
+	UserAgent string `bson:"userAgent"`
+	IPAddress string `bson:"ipAddress"` // of the last login or renewal
+	Label     string `bson:"label"`
+}
+
+// NewRefreshTokenFamily starts a family with the token issued at login
+func NewRefreshTokenFamily(token string, session SessionInfo, now int64) RefreshTokenFamily {
+	return RefreshTokenFamily{
+		ID:            primitive.NewObjectID().Hex(),
+		Token:         token,
+		RotatedTokens: []string{},
+		CreatedAt:     now,
+		LastUsedAt:    now,
+		SessionInfo:   session,
+	}
+}
+
+// IsExpired checks if the session was unused for longer than the idle timeout or started longer than the max age ago
+func (f RefreshTokenFamily) IsExpired(lifetime SessionLifetime, now int64) bool {
+	if lifetime.IdleTimeout > 0 && f.LastUsedAt+lifetime.IdleTimeout < now {
+		return true
+	}
+	return lifetime.MaxAge > 0 && f.CreatedAt+lifetime.MaxAge < now
+}
+
+// ExpiresAt returns the time the session expires if it is not used before, 0 if it doesn't expire
+func (f RefreshTokenFamily) ExpiresAt(lifetime SessionLifetime) int64 {
+	expiresAt := int64(0)
+	if lifetime.IdleTimeout > 0 {
+		expiresAt = f.LastUsedAt + lifetime.IdleTimeout
+	}
+	if lifetime.MaxAge > 0 && (expiresAt == 0 || f.CreatedAt+lifetime.MaxAge < expiresAt) {
+		expiresAt = f.CreatedAt + lifetime.MaxAge
+	}
+	return expiresAt
+}
+
+// ToAPI converts the object from DB to API format
+func (f RefreshTokenFamily) ToAPI() *api.Session {
+	return &api.Session{
+		Id:         f.ID,
+		CreatedAt:  f.CreatedAt,
+		LastUsedAt: f.LastUsedAt,
+		UserAgent:  f.UserAgent,
+		IpAddress:  f.IPAddress,
+		Label:      f.Label,
+	}
+}
+
+// TOTP holds the authenticator-app second factor of the account
+type TOTP struct {
+	Secret       string `bson:"secret"`       // encrypted, set when the enrollment is started
+	ConfirmedAt  int64  `bson:"confirmedAt"`  // enrollment is pending until the first code was entered
+	LastUsedStep int64  `bson:"lastUsedStep"` // time step of the last accepted code, so that codes cannot be replayed
+}
+
+// IsEnabled is true once the enrollment is confirmed
+func (t TOTP) IsEnabled() bool {
+	return t.Secret != "" && t.ConfirmedAt > 0
+}
+
+// Passkey is a WebAuthn credential registered for the account
+type Passkey struct {
+	CredentialID string   `bson:"credentialID"` // base64url encoded
+	PublicKey    []byte   `bson:"publicKey"`    // COSE encoded
+	SignCount    int64    `bson:"signCount"`
+	Transports   []string `bson:"transports"`
+	Nickname     string   `bson:"nickname"`
+	CreatedAt    int64    `bson:"createdAt"`
+	LastUsedAt   int64    `bson:"lastUsedAt"`
+}
+
+// ToAPI converts the object from DB to API format
+func (p Passkey) ToAPI() *api.Passkey {
+	return &api.Passkey{
+		CredentialId: p.CredentialID,
+		Nickname:     p.Nickname,
+		Transports:   p.Transports,
+		CreatedAt:    p.CreatedAt,
+		LastUsedAt:   p.LastUsedAt,
+	}
+}
+
+// FindPasskey returns the index of the passkey with the credential ID, or -1
+func (a Account) FindPasskey(credentialID string) int {
+	for i, p := range a.Passkeys {
+		if p.CredentialID == credentialID {
+			return i
+		}
+	}
+	return -1
+}
+
 func AccountFromAPI(a *api.User_Account) Account {
 	if a == nil {
 		return Account{}
@@ -47,5 +160,7 @@ func (a Account) ToAPI() *api.User_Account {
 		AccountId:          a.AccountID,
 		AccountConfirmedAt: a.AccountConfirmedAt,
 		PreferredLanguage:  a.PreferredLanguage,
+		TotpEnabled:        a.TOTP.IsEnabled(),
+		RecoveryCodesLeft:  int32(len(a.RecoveryCodes)),
 	}
 }
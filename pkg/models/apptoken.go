@@ -10,4 +10,28 @@ type AppToken struct {
 	AppName   string             `bson:"appName"`
 	Tokens    []string           `bson:"tokens"`
 	Instances []string           `bson:"instances"`
+	Audiences []string           `bson:"audiences,omitempty"` // accepted audiences of access tokens, any if empty
+}
+
+// HasInstance checks if the app has access to the instance
+func (t AppToken) HasInstance(instanceID string) bool {
+	for _, i := range t.Instances {
+		if i == instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsAudience checks if access tokens with the audience are meant for the app
+func (t AppToken) AcceptsAudience(aud string) bool {
+	if len(t.Audiences) == 0 {
+		return true
+	}
+	for _, a := range t.Audiences {
+		if a == aud {
+			return true
+		}
+	}
+	return false
 }
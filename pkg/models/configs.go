@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+)
 
 type DBConfig struct {
 	URI             string
@@ -11,7 +15,114 @@ type DBConfig struct {
 	IdleConnTimeout int
 }
 
+// SQLDBConfig is used if users and global data are stored in a SQL database instead of MongoDB
+type SQLDBConfig struct {
+	Driver  string // "postgres" or "sqlite3"
+	DSN     string
+	Timeout int
+}
+
+// TOTPConfig configures the authenticator-app second factor
+type TOTPConfig struct {
+	Issuer     string              // shown in the authenticator app
+	SecretKeys *fieldcrypt.Keyring // encrypts the stored secrets, TOTP cannot be enabled if nil
+}
+
+// Methods of the passwordless login
+const (
+	PASSWORDLESS_METHOD_LINK = "link"
+	PASSWORDLESS_METHOD_CODE = "code"
+)
+
+// PasswordlessLoginConfig maps the instances that allow logging in with a link or code sent by email to the allowed
+// methods
+type PasswordlessLoginConfig map[string][]string
+
+// Allows is true if the method can be used in the instance
+func (c PasswordlessLoginConfig) Allows(instanceID string, method string) bool {
+	for _, m := range c[instanceID] {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshTokenConfig configures the handling of refresh tokens
+type RefreshTokenConfig struct {
+	ReuseNotification bool   // send an email to the user if a replaced refresh token is used again
+	MaxSessions       int    // per user, the least recently used session is removed at the next login, MaxRefreshTokens if 0
+	HashKey           []byte // refresh tokens are stored as keyed hashes
+	Lifetime          SessionLifetime
+	RoleLifetimes     map[string]SessionLifetime // stricter limits for users with the role
+}
+
+// SessionLifetime limits how long a session can be renewed, in seconds. 0 means no limit.
+type SessionLifetime struct {
+	IdleTimeout int64 // since the login or last renewal
+	MaxAge      int64 // since the login
+}
+
+// shorter combines both lifetimes, the shorter limit applies
+func (l SessionLifetime) shorter(other SessionLifetime) SessionLifetime {
+	min := func(a, b int64) int64 {
+		if a == 0 || (b > 0 && b < a) {
+			return b
+		}
+		return a
+	}
+	return SessionLifetime{
+		IdleTimeout: min(l.IdleTimeout, other.IdleTimeout),
+		MaxAge:      min(l.MaxAge, other.MaxAge),
+	}
+}
+
+// IsLimited is false if sessions never expire
+func (l SessionLifetime) IsLimited() bool {
+	return l.IdleTimeout > 0 || l.MaxAge > 0
+}
+
+// SessionLifetimeForRoles returns the lifetime for a user with the roles. The limits of the roles only make the default
+// shorter, for a user with several roles the shortest limit applies.
+func (c RefreshTokenConfig) SessionLifetimeForRoles(roles []string) SessionLifetime {
+	lifetime := c.Lifetime
+	for _, role := range roles {
+		if l, ok := c.RoleLifetimes[role]; ok {
+			lifetime = lifetime.shorter(l)
+		}
+	}
+	return lifetime
+}
+
+// HasSessionLifetimes is true if sessions of any user can expire
+func (c RefreshTokenConfig) HasSessionLifetimes() bool {
+	if c.Lifetime.IsLimited() {
+		return true
+	}
+	for _, l := range c.RoleLifetimes {
+		if l.IsLimited() {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSessionsPerUser returns the configured limit or the default
+func (c RefreshTokenConfig) MaxSessionsPerUser() int {
+	if c.MaxSessions > 0 {
+		return c.MaxSessions
+	}
+	return MaxRefreshTokens
+}
+
 type Intervals struct {
 	TokenExpiryInterval      time.Duration // interpreted in minutes later
 	VerificationCodeLifetime int64         // in seconds
 }
+
+// AccessTokenConfig sets the iss and aud claims of new access tokens. Only tokens with the same values are valid, an
+// empty value is neither written nor checked.
+type AccessTokenConfig struct {
+	Issuer   string
+	Audience string
+}
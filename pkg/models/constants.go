@@ -4,3 +4,9 @@ const (
 	ACCOUNT_TYPE_EMAIL    = "email"
 	ACCOUNT_TYPE_EXTERNAL = "external"
 )
+
+// MaxRefreshTokens is the default number of sessions (i.e. devices) a user can have at the same time
+const MaxRefreshTokens = 10
+
+// MaxRotatedRefreshTokens is the number of replaced refresh tokens kept per family to detect their reuse
+const MaxRotatedRefreshTokens = 20
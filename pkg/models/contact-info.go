@@ -17,6 +17,20 @@ type ContactInfo struct {
 	Phone                  string             `bson:"phone,omitempty"`
 }
 
+// NewEmailContactInfo creates a contact info entry with a new ID, if confirmed, `now` is used as confirmation time
+func NewEmailContactInfo(addr string, confirmed bool, now int64) ContactInfo {
+	contactInfo := ContactInfo{
+		ID:          primitive.NewObjectID(),
+		Type:        "email",
+		ConfirmedAt: 0,
+		Email:       addr,
+	}
+	if confirmed {
+		contactInfo.ConfirmedAt = now
+	}
+	return contactInfo
+}
+
 func ContactInfoFromAPI(obj *api.ContactInfo) ContactInfo {
 	if obj == nil {
 		return ContactInfo{}
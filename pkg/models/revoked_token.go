@@ -0,0 +1,29 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RevokedToken is a database entry for revoked access tokens. With a token ID (jti) only that token is revoked,
+// without it all tokens of the user issued before IssuedUntil. The issue time has only second precision, so tokens
+// issued in the second of IssuedUntil stay valid, they may have been issued after the revocation. The entry is needed
+// until the tokens expired.
+type RevokedToken struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	InstanceID  string             `bson:"instanceID"`
+	UserID      string             `bson:"userID"`
+	TokenID     string             `bson:"tokenID,omitempty"`
+	IssuedUntil int64              `bson:"issuedUntil,omitempty"`
+	Expiration  int64              `bson:"expiration"`
+}
+
+// Matches checks if the entry revokes the access token of the user with the token ID and issue time
+func (t RevokedToken) Matches(instanceID string, userID string, tokenID string, issuedAt int64) bool {
+	if t.InstanceID != instanceID {
+		return false
+	}
+	if t.TokenID != "" {
+		return tokenID != "" && t.TokenID == tokenID
+	}
+	return t.UserID == userID && issuedAt < t.IssuedUntil
+}
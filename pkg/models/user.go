@@ -2,7 +2,6 @@ package models
 
 import (
 	"errors"
-	"time"
 
 	"github.com/influenzanet/user-management-service/pkg/api"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,6 +16,9 @@ type User struct {
 	Profiles           []Profile          `bson:"profiles"`
 	ContactPreferences ContactPreferences `bson:"contactPreferences"`
 	ContactInfos       []ContactInfo      `bson:"contactInfos"`
+
+	// Revision is incremented on each update, it is used to detect concurrent modifications
+	Revision int64 `bson:"revision" json:"-"`
 }
 
 // ToAPI converts the object from DB to API format
@@ -72,40 +74,31 @@ func (u *User) RemoveRole(role string) error {
 	return errors.New("role not found")
 }
 
-// Add a new email address
-func (u *User) AddNewEmail(addr string, confirmed bool) {
-	contactInfo := ContactInfo{
-		ID:          primitive.NewObjectID(),
-		Type:        "email",
-		ConfirmedAt: 0,
-		Email:       addr,
-	}
-	if confirmed {
-		contactInfo.ConfirmedAt = time.Now().Unix()
-	}
-	u.ContactInfos = append(u.ContactInfos, contactInfo)
+// Add a new email address, if confirmed, `now` is used as confirmation time
+func (u *User) AddNewEmail(addr string, confirmed bool, now int64) {
+	u.ContactInfos = append(u.ContactInfos, NewEmailContactInfo(addr, confirmed, now))
 }
 
-func (u *User) ConfirmContactInfo(t string, addr string) error {
+func (u *User) ConfirmContactInfo(t string, addr string, confirmedAt int64) error {
 	for i, ci := range u.ContactInfos {
 		if t == "email" && ci.Email == addr {
-			u.ContactInfos[i].ConfirmedAt = time.Now().Unix()
+			u.ContactInfos[i].ConfirmedAt = confirmedAt
 			return nil
 		} else if t == "phone" && ci.Phone == addr {
-			u.ContactInfos[i].ConfirmedAt = time.Now().Unix()
+			u.ContactInfos[i].ConfirmedAt = confirmedAt
 			return nil
 		}
 	}
 	return errors.New("contact not found")
 }
 
-func (u *User) SetContactInfoVerificationSent(t string, addr string) {
+func (u *User) SetContactInfoVerificationSent(t string, addr string, sentAt int64) {
 	for i, ci := range u.ContactInfos {
 		if t == "email" && ci.Email == addr {
-			u.ContactInfos[i].ConfirmationLinkSentAt = time.Now().Unix()
+			u.ContactInfos[i].ConfirmationLinkSentAt = sentAt
 			return
 		} else if t == "phone" && ci.Phone == addr {
-			u.ContactInfos[i].ConfirmationLinkSentAt = time.Now().Unix()
+			u.ContactInfos[i].ConfirmationLinkSentAt = sentAt
 			return
 		}
 	}
@@ -169,9 +162,9 @@ func (u *User) ReplaceContactInfoInContactPreferences(oldId string, newId string
 }
 
 // AddProfile generates unique ID and adds profile to the user's array
-func (u *User) AddProfile(p Profile) {
+func (u *User) AddProfile(p Profile, createdAt int64) {
 	p.ID = primitive.NewObjectID()
-	p.CreatedAt = time.Now().Unix()
+	p.CreatedAt = createdAt
 	u.Profiles = append(u.Profiles, p)
 }
 
@@ -211,16 +204,75 @@ func (u *User) RemoveProfile(id string) error {
 	return errors.New("profile with given ID not found")
 }
 
-// AddRefreshToken add a new refresh token to the user's list
-func (u *User) AddRefreshToken(token string) {
-	u.Account.RefreshTokens = append(u.Account.RefreshTokens, token)
-	if len(u.Account.RefreshTokens) > 10 {
-		_, u.Account.RefreshTokens = u.Account.RefreshTokens[0], u.Account.RefreshTokens[1:]
+// AddRefreshTokenFamily starts a new session. If there are more than maxFamilies, the least recently used ones are
+// removed.
+func (u *User) AddRefreshTokenFamily(family RefreshTokenFamily, maxFamilies int) {
+	u.Account.RefreshTokenFamilies = append(u.Account.RefreshTokenFamilies, family)
+	for len(u.Account.RefreshTokenFamilies) > maxFamilies {
+		lru := 0
+		for i, f := range u.Account.RefreshTokenFamilies {
+			if f.LastUsedAt < u.Account.RefreshTokenFamilies[lru].LastUsedAt {
+				lru = i
+			}
+		}
+		u.Account.RefreshTokenFamilies = append(u.Account.RefreshTokenFamilies[:lru], u.Account.RefreshTokenFamilies[lru+1:]...)
+	}
+}
+
+// FindRefreshTokenFamily returns the index of the family with the ID, or -1
+func (u *User) FindRefreshTokenFamily(familyID string) int {
+	for i, f := range u.Account.RefreshTokenFamilies {
+		if f.ID == familyID {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindRefreshTokenFamilyByToken returns the index of the family with the token as current token, or -1
+func (u *User) FindRefreshTokenFamilyByToken(token string) int {
+	for i, f := range u.Account.RefreshTokenFamilies {
+		if f.Token == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// ExpiredRefreshTokenFamilies returns the IDs of the families that cannot be renewed anymore
+func (u *User) ExpiredRefreshTokenFamilies(lifetime SessionLifetime, now int64) []string {
+	ids := []string{}
+	for _, f := range u.Account.RefreshTokenFamilies {
+		if f.IsExpired(lifetime, now) {
+			ids = append(ids, f.ID)
+		}
 	}
+	return ids
+}
+
+// legacyRefreshTokenSession describes the refresh tokens from before the token families were introduced. They have no
+// timestamps, so the last renewal or login of the user counts as start and last use of the session.
+func (u *User) legacyRefreshTokenSession() RefreshTokenFamily {
+	lastUsed := u.Timestamps.LastTokenRefresh
+	if u.Timestamps.LastLogin > lastUsed {
+		lastUsed = u.Timestamps.LastLogin
+	}
+	return RefreshTokenFamily{CreatedAt: lastUsed, LastUsedAt: lastUsed}
+}
+
+// LegacyRefreshTokensExpired is true if the user has refresh tokens from before the families were introduced and their
+// session cannot be renewed anymore
+func (u *User) LegacyRefreshTokensExpired(lifetime SessionLifetime, now int64) bool {
+	return len(u.Account.RefreshTokens) > 0 && u.legacyRefreshTokenSession().IsExpired(lifetime, now)
 }
 
 // HasRefreshToken checks weather a user has a particular refresh token
 func (u *User) HasRefreshToken(token string) bool {
+	for _, f := range u.Account.RefreshTokenFamilies {
+		if f.Token == token {
+			return true
+		}
+	}
 	for _, t := range u.Account.RefreshTokens {
 		if t == token {
 			return true
@@ -229,17 +281,93 @@ func (u *User) HasRefreshToken(token string) bool {
 	return false
 }
 
-// RemoveRefreshToken deletes a refresh token from the user's list
-func (u *User) RemoveRefreshToken(token string) error {
-	for i, t := range u.Account.RefreshTokens {
+// HasLegacyRefreshToken checks for a refresh token stored in plaintext before the token families were introduced. The
+// tokens of the families are stored hashed, so a presented token must never be compared with them directly.
+func (u *User) HasLegacyRefreshToken(token string) bool {
+	for _, t := range u.Account.RefreshTokens {
 		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// RenewRefreshToken replaces oldToken by newToken in its family and updates the session with the client's infos. A
+// refresh token from before the families were introduced starts a new family, keeping at most maxFamilies.
+// rotatedToken is the hashed form of oldToken, kept to detect its reuse. For tokens of families it is oldToken, legacy
+// tokens are stored in plaintext and must not end up among the rotated tokens as such.
+func (u *User) RenewRefreshToken(oldToken string, rotatedToken string, newToken string, session SessionInfo, now int64, maxFamilies int) error {
+	for i, f := range u.Account.RefreshTokenFamilies {
+		if f.Token == oldToken {
+			f.Token = newToken
+			f.RotatedTokens = append(f.RotatedTokens, rotatedToken)
+			if len(f.RotatedTokens) > MaxRotatedRefreshTokens {
+				f.RotatedTokens = f.RotatedTokens[len(f.RotatedTokens)-MaxRotatedRefreshTokens:]
+			}
+			f.LastUsedAt = now
+			f.updateSessionInfo(session)
+			u.Account.RefreshTokenFamilies[i] = f
+			return nil
+		}
+	}
+	for i, t := range u.Account.RefreshTokens {
+		if t == oldToken {
 			u.Account.RefreshTokens = append(u.Account.RefreshTokens[:i], u.Account.RefreshTokens[i+1:]...)
+			family := NewRefreshTokenFamily(newToken, session, now)
+			family.RotatedTokens = append(family.RotatedTokens, rotatedToken)
+			u.AddRefreshTokenFamily(family, maxFamilies)
 			return nil
 		}
 	}
 	return errors.New("token was missing")
 }
 
+// updateSessionInfo keeps the known values if the gateway did not pass them on with the renewal
+func (f *RefreshTokenFamily) updateSessionInfo(session SessionInfo) {
+	if session.UserAgent != "" {
+		f.UserAgent = session.UserAgent
+	}
+	if session.IPAddress != "" {
+		f.IPAddress = session.IPAddress
+	}
+	if session.Label != "" {
+		f.Label = session.Label
+	}
+}
+
+// FindRotatedRefreshToken returns the ID of the family the token was replaced in
+func (u *User) FindRotatedRefreshToken(token string) (familyID string, found bool) {
+	for _, f := range u.Account.RefreshTokenFamilies {
+		for _, t := range f.RotatedTokens {
+			if t == token {
+				return f.ID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RemoveRefreshTokenFamily revokes all tokens of the family
+func (u *User) RemoveRefreshTokenFamily(familyID string) error {
+	i := u.FindRefreshTokenFamily(familyID)
+	if i < 0 {
+		return errors.New("refresh token family not found")
+	}
+	u.Account.RefreshTokenFamilies = append(u.Account.RefreshTokenFamilies[:i], u.Account.RefreshTokenFamilies[i+1:]...)
+	return nil
+}
+
+// RemoveAllRefreshTokens revokes all refresh tokens of the user
+func (u *User) RemoveAllRefreshTokens() {
+	u.Account.RefreshTokens = []string{}
+	u.Account.RefreshTokenFamilies = []RefreshTokenFamily{}
+}
+
+// RemoveLegacyRefreshTokens removes the refresh tokens from before the token families were introduced
+func (u *User) RemoveLegacyRefreshTokens() {
+	u.Account.RefreshTokens = []string{}
+}
+
 // Timestamps describes metadata for the User
 // createdAt contains the account creation time, an offset is added if this account is created by admin, to reduce
 // risk this account to be deleled if account verification is not done in time (use case of migration when users are invited from previous platfom).
@@ -0,0 +1,243 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksMaxAge is how long keys fetched from a URL are used before they are fetched again
+	jwksMaxAge = time.Hour
+	// jwksMinRefreshInterval limits refetching the keys for tokens with an unknown key ID
+	jwksMinRefreshInterval = time.Minute
+	jwksFetchTimeout       = 10 * time.Second
+)
+
+// jsonWebKey is a public key of a JWKS (RFC 7517), only RSA and EC keys are supported
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey decodes the key. Keys of other types return nil without error, so that they can be skipped.
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := b64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("invalid EC key")
+		}
+		return pub, nil
+	}
+	return nil, nil
+}
+
+type signingKey struct {
+	id  string
+	key interface{}
+}
+
+// parseJWKS reads the signature keys of a JSON Web Key Set
+func parseJWKS(data []byte) ([]signingKey, error) {
+	set := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := []signingKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", k.Kid, err)
+		}
+		if key != nil {
+			keys = append(keys, signingKey{id: k.Kid, key: key})
+		}
+	}
+	if len(keys) < 1 {
+		return nil, errors.New("no signature keys in JWKS")
+	}
+	return keys, nil
+}
+
+// keySet holds the keys of a provider. Keys from a file are read once, keys from a URL are cached and fetched again
+// when they are older than jwksMaxAge or a token is signed with an unknown key. The keys are fetched without holding
+// the lock, concurrent requests wait for the running fetch instead of starting their own.
+type keySet struct {
+	url    string
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        []signingKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	// fetching is closed when the running fetch is done, nil if none is running
+	fetching chan struct{}
+	fetchErr error
+}
+
+func newKeySetFromFile(path string) (*keySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &keySet{keys: keys}, nil
+}
+
+func newKeySetFromURL(url string) *keySet {
+	return &keySet{
+		url:    url,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+	}
+}
+
+// key returns the key with the ID, or the only key if the token has no key ID
+func (s *keySet) key(kid string, now time.Time) (interface{}, error) {
+	s.mu.Lock()
+	// a running fetch may bring the key, so it is waited for even within jwksMinRefreshInterval
+	canFetch := s.url != "" && (s.keys == nil || s.fetching != nil || now.Sub(s.lastAttempt) > jwksMinRefreshInterval)
+	expired := s.keys == nil || now.Sub(s.fetchedAt) > jwksMaxAge
+	s.mu.Unlock()
+
+	var fetchErr error
+	if canFetch && expired {
+		fetchErr = s.fetch(now)
+		canFetch = false
+	}
+	if key := s.find(kid); key != nil {
+		return key, nil
+	}
+	if canFetch {
+		fetchErr = s.fetch(now)
+		if key := s.find(kid); key != nil {
+			return key, nil
+		}
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	return nil, fmt.Errorf("unknown key ID '%s'", kid)
+}
+
+func (s *keySet) find(kid string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if kid == "" {
+		if len(s.keys) == 1 {
+			return s.keys[0].key
+		}
+		return nil
+	}
+	for _, k := range s.keys {
+		if k.id == kid {
+			return k.key
+		}
+	}
+	return nil
+}
+
+// fetch loads the keys from the URL, or waits for the fetch already running. On failure the previous keys are kept.
+func (s *keySet) fetch(now time.Time) error {
+	s.mu.Lock()
+	if running := s.fetching; running != nil {
+		s.mu.Unlock()
+		<-running
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.fetchErr
+	}
+	done := make(chan struct{})
+	s.fetching = done
+	s.lastAttempt = now
+	s.mu.Unlock()
+
+	keys, err := s.download()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		s.keys = keys
+		s.fetchedAt = now
+	}
+	s.fetchErr = err
+	s.fetching = nil
+	close(done)
+	return err
+}
+
+func (s *keySet) download() ([]signingKey, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: status %d", s.url, resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", s.url, err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeySetFetch(t *testing.T) {
+	key1, _ := rsa.GenerateKey(rand.Reader, 2048)
+	key2, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var fetches int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) > 1 {
+			<-release
+		}
+		_, _ = w.Write(jwksJSON(t, rsaJWK("k1", &key1.PublicKey), rsaJWK("k2", &key2.PublicKey)))
+	}))
+	defer server.Close()
+
+	keys := newKeySetFromURL(server.URL)
+	now := time.Now()
+	if _, err := keys.key("k1", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// only k1 is known until the next fetch
+	keys.mu.Lock()
+	keys.keys = keys.keys[:1]
+	keys.mu.Unlock()
+
+	later := now.Add(2 * time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keys.key("k2", later); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	t.Run("known keys are found while fetching", func(t *testing.T) {
+		for atomic.LoadInt32(&fetches) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		found := make(chan error)
+		go func() {
+			_, err := keys.key("k1", later)
+			found <- err
+		}()
+		select {
+		case err := <-found:
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Error("lookup of known key waits for the fetch")
+		}
+	})
+
+	close(release)
+	wg.Wait()
+
+	t.Run("concurrent lookups share the fetch", func(t *testing.T) {
+		if n := atomic.LoadInt32(&fetches); n != 2 {
+			t.Errorf("unexpected number of fetches: %d", n)
+		}
+	})
+}
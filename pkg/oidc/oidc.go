@@ -0,0 +1,271 @@
+// Package oidc verifies ID tokens of external OpenID Connect identity providers. The providers are configured per
+// instance with their issuer, the accepted audiences (client IDs) and the keys the tokens are signed with, either as
+// JWKS file or as JWKS URL of the provider.
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// clockSkew tolerated when checking exp, nbf and iat
+	clockSkew = time.Minute
+
+	defaultEmailClaim  = "email"
+	defaultGroupsClaim = "groups"
+)
+
+var (
+	// ErrInvalidToken is returned if the ID token is not valid for the provider, the wrapped error has the details
+	ErrInvalidToken = errors.New("invalid ID token")
+	// ErrUnknownProvider is returned if no provider of the instance matches the request or token
+	ErrUnknownProvider = errors.New("unknown identity provider")
+
+	validMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// Provider is an identity provider whose ID tokens are accepted for an instance
+type Provider struct {
+	// Name identifies the provider in requests and logs
+	Name string `json:"name"`
+	// Issuer must match the iss claim
+	Issuer string `json:"issuer"`
+	// Audiences are the client IDs, the aud claim must contain one of them
+	Audiences []string `json:"audiences"`
+	// JWKSURL or JWKSFile contain the keys of the provider
+	JWKSURL  string `json:"jwksURL,omitempty"`
+	JWKSFile string `json:"jwksFile,omitempty"`
+	// EmailClaim and GroupsClaim name the claims with the email address and the groups of the user, "email" and
+	// "groups" by default
+	EmailClaim  string `json:"emailClaim,omitempty"`
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupRoles maps groups to the roles users of the group get
+	GroupRoles map[string]string `json:"groupRoles,omitempty"`
+	// DefaultRole is given to users without a group in GroupRoles. If empty, these users cannot log in.
+	DefaultRole string `json:"defaultRole,omitempty"`
+	// Customer is stored as auth type of new accounts
+	Customer string `json:"customer,omitempty"`
+	// NonceOptional accepts tokens without nonce, e.g. for flows where the client does not send one
+	NonceOptional bool `json:"nonceOptional,omitempty"`
+	// EmailsVerified accepts tokens without email_verified claim, for providers that only issue verified addresses
+	EmailsVerified bool `json:"emailsVerified,omitempty"`
+
+	keys *keySet
+}
+
+// Identity is the user an ID token was issued for
+type Identity struct {
+	Subject string
+	Email   string
+	Groups  []string
+}
+
+// Config contains the identity providers of each instance
+type Config struct {
+	// TrustCaller keeps the old behaviour of LoginWithExternalIDP, which takes email, role and IdP from the request
+	// without verification. Only for deployments where the caller authenticated the user itself.
+	TrustCaller bool
+	Providers   map[string][]*Provider
+}
+
+// ParseProviders reads the providers of each instance from JSON, an object with the instance IDs as keys and the
+// lists of providers as values. JWKS files are read immediately, keys from URLs when the first token is verified.
+func ParseProviders(data []byte) (map[string][]*Provider, error) {
+	providers := map[string][]*Provider{}
+	if err := json.Unmarshal(data, &providers); err != nil {
+		return nil, err
+	}
+	for instanceID, instanceProviders := range providers {
+		names := map[string]bool{}
+		for _, p := range instanceProviders {
+			if err := p.init(); err != nil {
+				return nil, fmt.Errorf("%s/%s: %v", instanceID, p.Name, err)
+			}
+			if names[p.Name] {
+				return nil, fmt.Errorf("%s: provider %s is defined twice", instanceID, p.Name)
+			}
+			names[p.Name] = true
+		}
+	}
+	return providers, nil
+}
+
+func (p *Provider) init() error {
+	if p.Name == "" || p.Issuer == "" {
+		return errors.New("name and issuer are required")
+	}
+	if len(p.Audiences) < 1 {
+		return errors.New("audiences are required")
+	}
+	if (p.JWKSURL == "") == (p.JWKSFile == "") {
+		return errors.New("either jwksURL or jwksFile must be set")
+	}
+	if p.EmailClaim == "" {
+		p.EmailClaim = defaultEmailClaim
+	}
+	if p.GroupsClaim == "" {
+		p.GroupsClaim = defaultGroupsClaim
+	}
+	if p.JWKSURL != "" {
+		p.keys = newKeySetFromURL(p.JWKSURL)
+		return nil
+	}
+	keys, err := newKeySetFromFile(p.JWKSFile)
+	if err != nil {
+		return err
+	}
+	p.keys = keys
+	return nil
+}
+
+// Provider finds the provider for the ID token. If name is empty, the provider is selected by the issuer of the token.
+func (c Config) Provider(instanceID string, name string, idToken string) (*Provider, error) {
+	issuer := ""
+	if name == "" {
+		claims := jwt.MapClaims{}
+		if _, _, err := new(jwt.Parser).ParseUnverified(idToken, claims); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		issuer, _ = claims["iss"].(string)
+	}
+	for _, p := range c.Providers[instanceID] {
+		if (name != "" && p.Name == name) || (name == "" && p.Issuer == issuer) {
+			return p, nil
+		}
+	}
+	return nil, ErrUnknownProvider
+}
+
+// Verify checks the signature and claims of the ID token and returns the user. The nonce must be the one the client
+// sent in the authentication request. It is only compared with the nonce claim; the caller has to make sure it was
+// issued for the current login and not taken from the request, otherwise the check doesn't prevent replays.
+func (p *Provider) Verify(idToken string, nonce string, now time.Time) (Identity, error) {
+	parser := jwt.Parser{ValidMethods: validMethods, SkipClaimsValidation: true}
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.key(kid, now)
+	})
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err := p.checkClaims(claims, nonce, now); err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	identity := Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims[p.EmailClaim].(string)
+	if identity.Subject == "" || identity.Email == "" {
+		return Identity{}, fmt.Errorf("%w: sub or %s claim missing", ErrInvalidToken, p.EmailClaim)
+	}
+	if !p.emailVerified(claims) {
+		return Identity{}, fmt.Errorf("%w: email not verified", ErrInvalidToken)
+	}
+	identity.Groups = stringList(claims[p.GroupsClaim])
+	return identity, nil
+}
+
+func (p *Provider) checkClaims(claims jwt.MapClaims, nonce string, now time.Time) error {
+	if iss, _ := claims["iss"].(string); iss != p.Issuer {
+		return fmt.Errorf("wrong issuer '%s'", iss)
+	}
+	if !containsAny(stringList(claims["aud"]), p.Audiences) {
+		return errors.New("wrong audience")
+	}
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return errors.New("exp claim missing")
+	}
+	if now.Add(-clockSkew).Unix() >= exp {
+		return errors.New("token expired")
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(clockSkew).Unix() < nbf {
+		return errors.New("token not valid yet")
+	}
+	if iat, ok := numericClaim(claims, "iat"); ok && now.Add(clockSkew).Unix() < iat {
+		return errors.New("token issued in the future")
+	}
+
+	tokenNonce, _ := claims["nonce"].(string)
+	if tokenNonce != nonce || (nonce == "" && !p.NonceOptional) {
+		return errors.New("wrong nonce")
+	}
+	return nil
+}
+
+// emailVerified checks the email_verified claim, some providers send it as string. Without the claim the email is
+// only trusted if the provider is configured with EmailsVerified.
+func (p *Provider) emailVerified(claims jwt.MapClaims) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	case nil:
+		return p.EmailsVerified
+	}
+	return false
+}
+
+// Roles returns the roles for a user with the groups
+func (p *Provider) Roles(groups []string) []string {
+	roles := []string{}
+	for _, group := range groups {
+		role, ok := p.GroupRoles[group]
+		if ok && !containsAny(roles, []string{role}) {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) < 1 && p.DefaultRole != "" {
+		roles = append(roles, p.DefaultRole)
+	}
+	return roles
+}
+
+// stringList reads a claim that is a string or a list of strings, a string may contain several values separated by
+// spaces
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func numericClaim(claims jwt.MapClaims, name string) (int64, bool) {
+	switch v := claims[name].(type) {
+	case float64:
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testClientID = "user-management"
+)
+
+func rsaJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"use": "sig",
+		"kid": kid,
+		"n":   b64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   b64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, pub *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   b64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32))),
+		"y":   b64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func jwksJSON(t *testing.T, keys ...map[string]string) []byte {
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims(now time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            testIssuer,
+		"aud":            testClientID,
+		"sub":            "external-user-1",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"groups":         []string{"staff", "admins"},
+		"nonce":          "n-123",
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+func TestParseProviders(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	dir, err := ioutil.TempDir("", "oidc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := ioutil.WriteFile(jwksFile, jwksJSON(t, rsaJWK("k1", &rsaKey.PublicKey)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		providers, err := ParseProviders([]byte(fmt.Sprintf(`{"inst1": [
+			{"name": "idp", "issuer": "%s", "audiences": ["%s"], "jwksFile": "%s"},
+			{"name": "other", "issuer": "https://other.example.com", "audiences": ["a"], "jwksURL": "https://other.example.com/keys"}
+		]}`, testIssuer, testClientID, jwksFile)))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(providers["inst1"]) != 2 || providers["inst1"][0].EmailClaim != "email" || providers["inst1"][0].GroupsClaim != "groups" {
+			t.Errorf("unexpected providers: %v", providers)
+		}
+	})
+
+	for name, config := range map[string]string{
+		"missing audiences": `{"inst1": [{"name": "idp", "issuer": "i", "jwksURL": "https://example.com"}]}`,
+		"missing keys":      `{"inst1": [{"name": "idp", "issuer": "i", "audiences": ["a"]}]}`,
+		"file and URL":      fmt.Sprintf(`{"inst1": [{"name": "idp", "issuer": "i", "audiences": ["a"], "jwksURL": "https://example.com", "jwksFile": "%s"}]}`, jwksFile),
+		"missing file":      `{"inst1": [{"name": "idp", "issuer": "i", "audiences": ["a"], "jwksFile": "/does/not/exist"}]}`,
+		"duplicate name":    `{"inst1": [{"name": "idp", "issuer": "i", "audiences": ["a"], "jwksURL": "u"}, {"name": "idp", "issuer": "j", "audiences": ["a"], "jwksURL": "u"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseProviders([]byte(config)); err == nil {
+				t.Error("error expected")
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write(jwksJSON(t, rsaJWK("rsa-key", &rsaKey.PublicKey), ecJWK("ec-key", &ecKey.PublicKey)))
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		Name:       "idp",
+		Issuer:     testIssuer,
+		Audiences:  []string{"other-client", testClientID},
+		JWKSURL:    server.URL,
+		GroupRoles: map[string]string{"admins": "ADMIN"},
+	}
+	if err := p.init(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	t.Run("valid RSA token", func(t *testing.T) {
+		identity, err := p.Verify(signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", validClaims(now)), "n-123", now)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if identity.Subject != "external-user-1" || identity.Email != "user@example.com" || len(identity.Groups) != 2 {
+			t.Errorf("unexpected identity: %v", identity)
+		}
+		if roles := p.Roles(identity.Groups); len(roles) != 1 || roles[0] != "ADMIN" {
+			t.Errorf("unexpected roles: %v", roles)
+		}
+	})
+
+	t.Run("valid EC token with audience list", func(t *testing.T) {
+		claims := validClaims(now)
+		claims["aud"] = []string{"x", testClientID}
+		if _, err := p.Verify(signToken(t, jwt.SigningMethodES256, ecKey, "ec-key", claims), "n-123", now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("keys are cached", func(t *testing.T) {
+		if fetches != 1 {
+			t.Errorf("unexpected number of fetches: %d", fetches)
+		}
+	})
+
+	invalid := map[string]func() string{
+		"wrong signature": func() string {
+			return signToken(t, jwt.SigningMethodRS256, otherKey, "rsa-key", validClaims(now))
+		},
+		"unknown key": func() string {
+			return signToken(t, jwt.SigningMethodRS256, otherKey, "other-key", validClaims(now))
+		},
+		"HS256": func() string {
+			return signToken(t, jwt.SigningMethodHS256, []byte("secret"), "rsa-key", validClaims(now))
+		},
+		"wrong issuer": func() string {
+			claims := validClaims(now)
+			claims["iss"] = "https://evil.example.com"
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"wrong audience": func() string {
+			claims := validClaims(now)
+			claims["aud"] = "someone-else"
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"expired": func() string {
+			claims := validClaims(now)
+			claims["exp"] = now.Add(-2 * time.Minute).Unix()
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"missing exp": func() string {
+			claims := validClaims(now)
+			delete(claims, "exp")
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"not valid yet": func() string {
+			claims := validClaims(now)
+			claims["nbf"] = now.Add(10 * time.Minute).Unix()
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"wrong nonce": func() string {
+			claims := validClaims(now)
+			claims["nonce"] = "replayed"
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"email not verified": func() string {
+			claims := validClaims(now)
+			claims["email_verified"] = false
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"email_verified missing": func() string {
+			claims := validClaims(now)
+			delete(claims, "email_verified")
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+		"missing email": func() string {
+			claims := validClaims(now)
+			delete(claims, "email")
+			return signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		},
+	}
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := p.Verify(token(), "n-123", now); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("keys are fetched again for unknown key at most once a minute", func(t *testing.T) {
+		if fetches != 1 {
+			t.Errorf("unexpected number of fetches: %d", fetches)
+		}
+		later := now.Add(2 * time.Minute)
+		token := signToken(t, jwt.SigningMethodRS256, otherKey, "other-key", validClaims(later))
+		if _, err := p.Verify(token, "n-123", later); err == nil {
+			t.Error("error expected")
+		}
+		if fetches != 2 {
+			t.Errorf("unexpected number of fetches: %d", fetches)
+		}
+	})
+
+	t.Run("provider with verified emails", func(t *testing.T) {
+		claims := validClaims(now)
+		delete(claims, "email_verified")
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		p.EmailsVerified = true
+		defer func() { p.EmailsVerified = false }()
+		if _, err := p.Verify(token, "n-123", now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		claims["email_verified"] = false
+		token = signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		if _, err := p.Verify(token, "n-123", now); err == nil {
+			t.Error("email_verified false should still be rejected")
+		}
+	})
+
+	t.Run("nonce", func(t *testing.T) {
+		claims := validClaims(now)
+		delete(claims, "nonce")
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-key", claims)
+		if _, err := p.Verify(token, "", now); err == nil {
+			t.Error("token without nonce should be rejected")
+		}
+		p.NonceOptional = true
+		defer func() { p.NonceOptional = false }()
+		if _, err := p.Verify(token, "", now); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := p.Verify(token, "n-123", now); err == nil {
+			t.Error("missing nonce should be rejected if the client sent one")
+		}
+	})
+}
+
+func TestConfigProvider(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	p1 := &Provider{Name: "idp1", Issuer: testIssuer}
+	p2 := &Provider{Name: "idp2", Issuer: "https://other.example.com"}
+	conf := Config{Providers: map[string][]*Provider{"inst1": {p1, p2}}}
+	token := signToken(t, jwt.SigningMethodRS256, key, "", jwt.MapClaims{"iss": "https://other.example.com"})
+
+	if p, err := conf.Provider("inst1", "idp1", token); err != nil || p != p1 {
+		t.Errorf("unexpected provider by name: %v, %v", p, err)
+	}
+	if p, err := conf.Provider("inst1", "", token); err != nil || p != p2 {
+		t.Errorf("unexpected provider by issuer: %v, %v", p, err)
+	}
+	if _, err := conf.Provider("inst2", "", token); err != ErrUnknownProvider {
+		t.Errorf("unexpected error for other instance: %v", err)
+	}
+	if _, err := conf.Provider("inst1", "", "not a token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("unexpected error for invalid token: %v", err)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	p := &Provider{GroupRoles: map[string]string{"a": "ADMIN", "b": "ADMIN", "r": "RESEARCHER"}}
+	if roles := p.Roles([]string{"a", "b", "r", "x"}); len(roles) != 2 {
+		t.Errorf("unexpected roles: %v", roles)
+	}
+	if roles := p.Roles([]string{"x"}); len(roles) != 0 {
+		t.Errorf("unexpected roles without default: %v", roles)
+	}
+	p.DefaultRole = "PARTICIPANT"
+	if roles := p.Roles(nil); len(roles) != 1 || roles[0] != "PARTICIPANT" {
+		t.Errorf("unexpected default roles: %v", roles)
+	}
+}
@@ -0,0 +1,195 @@
+// Package testharness boots the user management service in-process for end-to-end tests. The real gRPC server
+// runs on a bufconn listener, backed by the in-memory stores and local messaging/logging stand-ins.
+package testharness
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/memdb"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+	"github.com/influenzanet/user-management-service/pkg/grpc/service"
+	"github.com/influenzanet/user-management-service/pkg/models"
+	"github.com/influenzanet/user-management-service/pkg/oidc"
+	"github.com/influenzanet/user-management-service/pkg/timer_event"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufSize = 1024 * 1024
+
+	// DefaultTimeout is used by the helpers waiting for asynchronously sent emails
+	DefaultTimeout = 5 * time.Second
+)
+
+// DefaultRelyingParty is used for passkeys, unless configured otherwise
+var DefaultRelyingParty = webauthn.RelyingParty{ID: "localhost", Name: "Test", Origins: []string{"https://localhost"}}
+
+// Config for the harness, zero values are replaced by defaults
+type Config struct {
+	Intervals                         models.Intervals
+	AccessTokens                      models.AccessTokenConfig
+	NewUserCountLimit                 int64
+	CleanUpUnverifiedUsersAfter       int64                 // seconds
+	ReminderToUnverifiedAccountsAfter int64                 // seconds
+	StartTime                         time.Time             // initial time of the fake clock, defaults to now
+	TOTP                              models.TOTPConfig     // defaults to a random secret key
+	WebAuthn                          webauthn.RelyingParty // defaults to DefaultRelyingParty
+	PasswordlessLogin                 models.PasswordlessLoginConfig
+	RefreshTokens                     models.RefreshTokenConfig
+	ExternalIDPs                      oidc.Config
+}
+
+type Harness struct {
+	Client    api.UserManagementApiClient
+	Messaging *MessagingService
+	Logging   *LoggingService
+	UserDB    *memdb.UserDBService
+	GlobalDB  *memdb.GlobalDBService
+	// Clock is shared by the server, the stores and the timer service, advance it to time-travel
+	Clock *clock.Fake
+	// Timer runs the background jobs on demand (e.g. h.Timer.CleanUpUnverifiedUsers(context.Background())), it is not started automatically
+	Timer *timer_event.UserManagementTimerService
+
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+// New starts a new service instance with fresh stores. Close must be called to stop it.
+func New(conf Config) (*Harness, error) {
+	if conf.Intervals.TokenExpiryInterval == 0 {
+		conf.Intervals.TokenExpiryInterval = 10 * time.Minute
+	}
+	if conf.Intervals.VerificationCodeLifetime == 0 {
+		conf.Intervals.VerificationCodeLifetime = 15 * 60
+	}
+	if conf.NewUserCountLimit == 0 {
+		conf.NewUserCountLimit = 100
+	}
+	if conf.CleanUpUnverifiedUsersAfter == 0 {
+		conf.CleanUpUnverifiedUsersAfter = 24 * 3600
+	}
+	if conf.ReminderToUnverifiedAccountsAfter == 0 {
+		conf.ReminderToUnverifiedAccountsAfter = 3600
+	}
+	if conf.StartTime.IsZero() {
+		conf.StartTime = time.Now()
+	}
+	if conf.TOTP.SecretKeys == nil {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		keys, err := fieldcrypt.ParseSecretKeyring("test:"+base64.StdEncoding.EncodeToString(key), "test")
+		if err != nil {
+			return nil, err
+		}
+		conf.TOTP.SecretKeys = keys
+	}
+	if !conf.WebAuthn.Enabled() {
+		conf.WebAuthn = DefaultRelyingParty
+	}
+
+	fakeClock := clock.NewFake(conf.StartTime)
+	h := &Harness{
+		Messaging: NewMessagingService(),
+		Logging:   NewLoggingService(),
+		UserDB:    memdb.NewUserDBService(fakeClock),
+		GlobalDB:  memdb.NewGlobalDBService(),
+		Clock:     fakeClock,
+	}
+	clients := &models.APIClients{
+		MessagingService: h.Messaging,
+		LoggingService:   h.Logging,
+	}
+	h.Timer = timer_event.NewUserManagmentTimerService(
+		0,
+		h.GlobalDB,
+		h.UserDB,
+		clients,
+		conf.CleanUpUnverifiedUsersAfter,
+		conf.ReminderToUnverifiedAccountsAfter,
+		conf.RefreshTokens,
+		fakeClock,
+	)
+
+	lis := bufconn.Listen(bufSize)
+	h.server = grpc.NewServer()
+	api.RegisterUserManagementApiServer(h.server, service.NewUserManagementServer(service.Config{
+		Clients:           clients,
+		UserDBService:     h.UserDB,
+		GlobalDBService:   h.GlobalDB,
+		Intervals:         conf.Intervals,
+		AccessTokens:      conf.AccessTokens,
+		NewUserCountLimit: conf.NewUserCountLimit,
+		TOTP:              conf.TOTP,
+		RelyingParty:      conf.WebAuthn,
+		PasswordlessLogin: conf.PasswordlessLogin,
+		RefreshTokens:     conf.RefreshTokens,
+		ExternalIDPs:      conf.ExternalIDPs,
+		Clock:             fakeClock,
+	}))
+	go func() {
+		_ = h.server.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		h.server.Stop()
+		return nil, err
+	}
+	h.conn = conn
+	h.Client = api.NewUserManagementApiClient(conn)
+	return h, nil
+}
+
+// Close stops the server and closes the client connection
+func (h *Harness) Close() {
+	h.conn.Close()
+	h.server.Stop()
+}
+
+// ExtractTempTokenFromLastRegistrationEmail waits for the registration email to the address and returns the contained temp token
+func (h *Harness) ExtractTempTokenFromLastRegistrationEmail(address string) (string, error) {
+	email, err := h.Messaging.WaitForEmail(address, constants.EMAIL_TYPE_REGISTRATION, 0, DefaultTimeout)
+	if err != nil {
+		return "", err
+	}
+	token, ok := email.ContentInfos["token"]
+	if !ok || token == "" {
+		return "", errors.New("registration email without token")
+	}
+	return token, nil
+}
+
+// ExtractVerificationCodeFromEmail waits for a verification code email to the address, sent after the first `skip` emails,
+// and returns the code in the format expected by the login endpoints.
+func (h *Harness) ExtractVerificationCodeFromEmail(address string, skip int) (string, error) {
+	email, err := h.Messaging.WaitForEmail(address, constants.EMAIL_TYPE_AUTH_VERIFICATION_CODE, skip, DefaultTimeout)
+	if err != nil {
+		return "", err
+	}
+	code, ok := email.ContentInfos["verificationCode"]
+	if !ok || code == "" {
+		return "", errors.New("verification code email without code")
+	}
+	// code is formatted for readability as "123-456"
+	return strings.ReplaceAll(code, "-", ""), nil
+}
@@ -0,0 +1,273 @@
+package testharness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/go-utils/pkg/global_types"
+	"github.com/influenzanet/user-management-service/pkg/api"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func TestSignupVerifyLoginRenewFlow(t *testing.T) {
+	h, err := New(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer h.Close()
+
+	ctx := context.Background()
+	instanceID := "flow-test"
+	email := "flow-test@test.com"
+	password := "SuperSecurePassword123!§$"
+
+	signupResp, err := h.Client.SignupWithEmail(ctx, &api.SignupWithEmailMsg{
+		InstanceId:        instanceID,
+		Email:             email,
+		Password:          password,
+		PreferredLanguage: "en",
+		Use_2Fa:           true,
+	})
+	if err != nil {
+		t.Fatalf("signup failed: %v", err)
+	}
+	if signupResp.AccessToken == "" || signupResp.RefreshToken == "" {
+		t.Fatalf("unexpected signup response: %v", signupResp)
+	}
+
+	t.Run("verify contact with token from registration email", func(t *testing.T) {
+		token, err := h.ExtractTempTokenFromLastRegistrationEmail(email)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		user, err := h.Client.VerifyContact(ctx, &api.TempToken{Token: token})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Account.AccountConfirmedAt <= 0 {
+			t.Errorf("account should be confirmed: %v", user.Account)
+		}
+	})
+
+	var loginResp *api.LoginResponse
+	t.Run("login with second factor", func(t *testing.T) {
+		sentEmails := h.Messaging.Count()
+		resp, err := h.Client.LoginWithEmail(ctx, &api.LoginWithEmailMsg{
+			InstanceId: instanceID,
+			Email:      email,
+			Password:   password,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.SecondFactorNeeded || resp.Token != nil {
+			t.Fatalf("second factor should be needed: %v", resp)
+		}
+
+		code, err := h.ExtractVerificationCodeFromEmail(email, sentEmails)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = h.Client.LoginWithEmail(ctx, &api.LoginWithEmailMsg{
+			InstanceId:       instanceID,
+			Email:            email,
+			Password:         password,
+			VerificationCode: code + "1",
+		})
+		if err == nil {
+			t.Error("wrong verification code should be rejected")
+		}
+
+		loginResp, err = h.Client.LoginWithEmail(ctx, &api.LoginWithEmailMsg{
+			InstanceId:       instanceID,
+			Email:            email,
+			Password:         password,
+			VerificationCode: code,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loginResp.Token == nil || loginResp.Token.AccessToken == "" {
+			t.Fatalf("token missing: %v", loginResp)
+		}
+	})
+
+	t.Run("renew token", func(t *testing.T) {
+		if loginResp == nil {
+			t.Skip("login failed")
+		}
+		resp, err := h.Client.RenewJWT(ctx, &api.RefreshJWTRequest{
+			AccessToken:  loginResp.Token.AccessToken,
+			RefreshToken: loginResp.Token.RefreshToken,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.RefreshToken == "" || resp.RefreshToken == loginResp.Token.RefreshToken {
+			t.Errorf("refresh token should be rotated: %v", resp)
+		}
+
+		_, err = h.Client.RenewJWT(ctx, &api.RefreshJWTRequest{
+			AccessToken:  loginResp.Token.AccessToken,
+			RefreshToken: loginResp.Token.RefreshToken,
+		})
+		if err == nil {
+			t.Error("used refresh token should be rejected")
+		}
+	})
+
+	t.Run("log events are recorded", func(t *testing.T) {
+		user, err := h.UserDB.GetUserByAccountID(context.Background(), instanceID, email)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, eventName := range []string{
+			constants.LOG_EVENT_ACCOUNT_CREATED,
+			constants.LOG_EVENT_CONTACT_VERIFIED,
+			constants.LOG_EVENT_AUTH_WRONG_VERIFICATION_CODE,
+			constants.LOG_EVENT_LOGIN_SUCCESS,
+			constants.LOG_EVENT_TOKEN_REFRESH_SUCCESS,
+			constants.LOG_EVENT_TOKEN_REFRESH_FAILED,
+		} {
+			if len(h.Logging.EventsFor(user.ID.Hex(), eventName)) != 1 {
+				t.Errorf("expected one %s event", eventName)
+			}
+		}
+	})
+}
+
+func TestTimeTravel(t *testing.T) {
+	h, err := New(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer h.Close()
+
+	ctx := context.Background()
+	instanceID := "time-travel-test"
+	password := "SuperSecurePassword123!§$"
+	h.GlobalDB.AddInstance(global_types.Instance{InstanceID: instanceID})
+
+	signup := func(email string) {
+		_, err := h.Client.SignupWithEmail(ctx, &api.SignupWithEmailMsg{
+			InstanceId:        instanceID,
+			Email:             email,
+			Password:          password,
+			PreferredLanguage: "en",
+			Use_2Fa:           true,
+		})
+		if err != nil {
+			t.Fatalf("signup failed: %v", err)
+		}
+	}
+
+	t.Run("verification code expires", func(t *testing.T) {
+		email := "expire@test.com"
+		signup(email)
+
+		sentEmails := h.Messaging.Count()
+		if _, err := h.Client.LoginWithEmail(ctx, &api.LoginWithEmailMsg{InstanceId: instanceID, Email: email, Password: password}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		code, err := h.ExtractVerificationCodeFromEmail(email, sentEmails)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		h.Clock.Advance(16 * time.Minute)
+		_, err = h.Client.LoginWithEmail(ctx, &api.LoginWithEmailMsg{InstanceId: instanceID, Email: email, Password: password, VerificationCode: code})
+		if err == nil {
+			t.Error("expired verification code should be rejected")
+		}
+	})
+
+	t.Run("access token expires", func(t *testing.T) {
+		resp, err := h.Client.SignupWithEmail(ctx, &api.SignupWithEmailMsg{
+			InstanceId:        instanceID,
+			Email:             "token@test.com",
+			Password:          password,
+			PreferredLanguage: "en",
+		})
+		if err != nil {
+			t.Fatalf("signup failed: %v", err)
+		}
+		if _, err := h.Client.ValidateJWT(ctx, &api.JWTRequest{Token: resp.AccessToken}); err != nil {
+			t.Errorf("token should be valid: %v", err)
+		}
+		h.Clock.Advance(11 * time.Minute)
+		if _, err := h.Client.ValidateJWT(ctx, &api.JWTRequest{Token: resp.AccessToken}); err == nil {
+			t.Error("token should be expired")
+		}
+	})
+
+	t.Run("unverified users are removed by the timer job", func(t *testing.T) {
+		email := "cleanup@test.com"
+		signup(email)
+
+		h.Timer.CleanUpUnverifiedUsers(context.Background())
+		if _, err := h.UserDB.GetUserByAccountID(context.Background(), instanceID, email); err != nil {
+			t.Errorf("user should not be removed yet: %v", err)
+		}
+
+		h.Clock.Advance(25 * time.Hour)
+		h.Timer.CleanUpUnverifiedUsers(context.Background())
+		if _, err := h.UserDB.GetUserByAccountID(context.Background(), instanceID, email); err == nil {
+			t.Error("user should be removed")
+		}
+	})
+}
+
+func TestExpiredSessionsArePruned(t *testing.T) {
+	h, err := New(Config{
+		RefreshTokens: models.RefreshTokenConfig{Lifetime: models.SessionLifetime{IdleTimeout: 3600}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer h.Close()
+
+	ctx := context.Background()
+	instanceID := "session-lifetime-test"
+	email := "sessions@test.com"
+	h.GlobalDB.AddInstance(global_types.Instance{InstanceID: instanceID})
+
+	if _, err := h.Client.SignupWithEmail(ctx, &api.SignupWithEmailMsg{
+		InstanceId:        instanceID,
+		Email:             email,
+		Password:          "SuperSecurePassword123!§$",
+		PreferredLanguage: "en",
+	}); err != nil {
+		t.Fatalf("signup failed: %v", err)
+	}
+	legacyEmail := "legacy-sessions@test.com"
+	if _, err := h.UserDB.AddUser(ctx, instanceID, models.User{
+		Account:    models.Account{Type: "email", AccountID: legacyEmail, RefreshTokens: []string{"legacy-token"}},
+		Timestamps: models.Timestamps{LastLogin: h.Clock.Now().Unix()},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sessionCount := func(email string) int {
+		user, err := h.UserDB.GetUserByAccountID(context.Background(), instanceID, email)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return len(user.Account.RefreshTokenFamilies) + len(user.Account.RefreshTokens)
+	}
+
+	h.Timer.PruneExpiredSessions(context.Background())
+	if n := sessionCount(email) + sessionCount(legacyEmail); n != 2 {
+		t.Errorf("sessions should not be removed yet: %d", n)
+	}
+
+	h.Clock.Advance(61 * time.Minute)
+	h.Timer.PruneExpiredSessions(context.Background())
+	if n := sessionCount(email); n != 0 {
+		t.Errorf("expired session should be removed: %d", n)
+	}
+	if n := sessionCount(legacyEmail); n != 0 {
+		t.Errorf("expired legacy refresh token should be removed: %d", n)
+	}
+}
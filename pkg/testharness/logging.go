@@ -0,0 +1,59 @@
+package testharness
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// LoggingService is an in-process stand-in for the logging service recording every saved log event.
+// Only SaveLogEvent is implemented, calling any other method panics.
+type LoggingService struct {
+	loggingAPI.LoggingServiceApiClient
+
+	mu     sync.Mutex
+	events []*loggingAPI.NewLogEvent
+}
+
+func NewLoggingService() *LoggingService {
+	return &LoggingService{
+		events: []*loggingAPI.NewLogEvent{},
+	}
+}
+
+func (l *LoggingService) SaveLogEvent(ctx context.Context, in *loggingAPI.NewLogEvent, opts ...grpc.CallOption) (*api_types.ServiceStatus, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, proto.Clone(in).(*loggingAPI.NewLogEvent))
+	return &api_types.ServiceStatus{
+		Status: api_types.ServiceStatus_NORMAL,
+		Msg:    "event saved",
+	}, nil
+}
+
+// Events returns all log events saved so far, in order
+func (l *LoggingService) Events() []*loggingAPI.NewLogEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := make([]*loggingAPI.NewLogEvent, len(l.events))
+	copy(events, l.events)
+	return events
+}
+
+// EventsFor returns the log events saved for the user. If eventName is not empty, only events with this name are returned.
+func (l *LoggingService) EventsFor(userID string, eventName string) []*loggingAPI.NewLogEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := []*loggingAPI.NewLogEvent{}
+	for _, e := range l.events {
+		if e.UserId != userID || (len(eventName) > 0 && e.EventName != eventName) {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events
+}
@@ -0,0 +1,108 @@
+package testharness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	messageAPI "github.com/influenzanet/messaging-service/pkg/api/messaging_service"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// MessagingService is an in-process stand-in for the messaging service. It records every email the
+// user management service asks to send. Only the methods used by this service are implemented, calling
+// any other method panics.
+type MessagingService struct {
+	messageAPI.MessagingServiceApiClient
+
+	mu      sync.Mutex
+	emails  []*messageAPI.SendEmailReq
+	updated chan struct{}
+}
+
+func NewMessagingService() *MessagingService {
+	return &MessagingService{
+		emails:  []*messageAPI.SendEmailReq{},
+		updated: make(chan struct{}),
+	}
+}
+
+func (m *MessagingService) SendInstantEmail(ctx context.Context, in *messageAPI.SendEmailReq, opts ...grpc.CallOption) (*messageAPI.ServiceStatus, error) {
+	m.mu.Lock()
+	m.emails = append(m.emails, proto.Clone(in).(*messageAPI.SendEmailReq))
+	// wake up everyone waiting for a new email
+	close(m.updated)
+	m.updated = make(chan struct{})
+	m.mu.Unlock()
+
+	return &messageAPI.ServiceStatus{
+		Status: messageAPI.ServiceStatus_NORMAL,
+		Msg:    "email sent",
+	}, nil
+}
+
+// SentEmails returns all emails sent so far, in order
+func (m *MessagingService) SentEmails() []*messageAPI.SendEmailReq {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	emails := make([]*messageAPI.SendEmailReq, len(m.emails))
+	copy(emails, m.emails)
+	return emails
+}
+
+// LastEmailTo returns the last email sent to the address. If messageType is not empty, only emails of this type are considered.
+func (m *MessagingService) LastEmailTo(address string, messageType string) (*messageAPI.SendEmailReq, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return findLastEmail(m.emails, address, messageType)
+}
+
+func findLastEmail(emails []*messageAPI.SendEmailReq, address string, messageType string) (*messageAPI.SendEmailReq, bool) {
+	for i := len(emails) - 1; i >= 0; i-- {
+		e := emails[i]
+		if len(messageType) > 0 && e.MessageType != messageType {
+			continue
+		}
+		for _, to := range e.To {
+			if strings.EqualFold(to, address) {
+				return e, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// WaitForEmail waits until an email matching LastEmailTo was sent after the first `skip` emails.
+// Some endpoints send emails asynchronously, so tests should use this method instead of LastEmailTo.
+func (m *MessagingService) WaitForEmail(address string, messageType string, skip int, timeout time.Duration) (*messageAPI.SendEmailReq, error) {
+	deadline := time.After(timeout)
+	for {
+		m.mu.Lock()
+		updated := m.updated
+		var email *messageAPI.SendEmailReq
+		found := false
+		if len(m.emails) > skip {
+			email, found = findLastEmail(m.emails[skip:], address, messageType)
+		}
+		m.mu.Unlock()
+		if found {
+			return email, nil
+		}
+
+		select {
+		case <-updated:
+		case <-deadline:
+			return nil, errors.New("no email to " + address + " received in time")
+		}
+	}
+}
+
+// Count returns the number of emails sent so far
+func (m *MessagingService) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.emails)
+}
@@ -0,0 +1,17 @@
+package timer_event
+
+import (
+	"context"
+
+	"github.com/coneno/logger"
+)
+
+// CleanUpRevokedTokens removes the revocation entries of access tokens that expired anyway. MongoDB does this with a
+// TTL index, the other stores rely on this job.
+func (s *UserManagementTimerService) CleanUpRevokedTokens(ctx context.Context) {
+	if err := s.globalDBService.DeleteRevokedTokensExpireBefore(ctx, s.clock.Now().Unix()); err != nil {
+		logger.Error.Printf("unexpected error while deleting expired revoked tokens: %v", err)
+		return
+	}
+	logger.Debug.Println("Expired revoked tokens cleaned up.")
+}
@@ -1,21 +1,21 @@
 package timer_event
 
 import (
-	"time"
+	"context"
 
 	"github.com/coneno/logger"
 )
 
 // CleanUpUnverifiedUsers handles the deletion of unverified accounts after a threshold delay
-func (s *UserManagementTimerService) CleanUpUnverifiedUsers() {
+func (s *UserManagementTimerService) CleanUpUnverifiedUsers(ctx context.Context) {
 	logger.Debug.Println("Starting clean up job for unverified users:")
-	instances, err := s.globalDBService.GetAllInstances()
+	instances, err := s.globalDBService.GetAllInstances(ctx)
 	if err != nil {
 		logger.Error.Printf("unexpected error: %s", err.Error())
 	}
 	deleteUnverifiedUsersAfter := s.CleanUpTimeThreshold
 	for _, instance := range instances {
-		count, err := s.userDBService.DeleteUnverfiedUsers(instance.InstanceID, time.Now().Unix()-deleteUnverifiedUsersAfter)
+		count, err := s.userDBService.DeleteUnverfiedUsers(ctx, instance.InstanceID, s.clock.Now().Unix()-deleteUnverifiedUsersAfter)
 		if err != nil {
 			logger.Error.Printf("unexpected error: %s", err.Error())
 			continue
@@ -0,0 +1,56 @@
+package timer_event
+
+import (
+	"context"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+// PruneExpiredSessions removes the refresh token families that exceeded the idle timeout or max age from the users, and
+// refresh tokens from before the families were introduced if the last login or renewal of the user is too long ago
+func (s *UserManagementTimerService) PruneExpiredSessions(ctx context.Context) {
+	if !s.RefreshTokens.HasSessionLifetimes() {
+		return
+	}
+	logger.Debug.Println("Starting clean up job for expired sessions:")
+	instances, err := s.globalDBService.GetAllInstances(ctx)
+	if err != nil {
+		logger.Error.Printf("unexpected error: %s", err.Error())
+	}
+
+	pruneSessions := func(instanceID string, user models.User, args ...interface{}) error {
+		count, _ := args[0].(*int)
+		lifetime := s.RefreshTokens.SessionLifetimeForRoles(user.Roles)
+		for _, familyID := range user.ExpiredRefreshTokenFamilies(lifetime, s.clock.Now().Unix()) {
+			if err := s.userDBService.RemoveRefreshTokenFamily(ctx, instanceID, user.ID.Hex(), familyID); err != nil {
+				logger.Error.Printf("unexpected error: %s", err.Error())
+				continue
+			}
+			*count = *count + 1
+		}
+		if user.LegacyRefreshTokensExpired(lifetime, s.clock.Now().Unix()) {
+			if err := s.userDBService.RemoveLegacyRefreshTokens(ctx, instanceID, user.ID.Hex()); err != nil {
+				logger.Error.Printf("unexpected error: %s", err.Error())
+				return nil
+			}
+			*count = *count + 1
+		}
+		return nil
+	}
+
+	for _, instance := range instances {
+		count := 0
+		err := s.userDBService.PerfomActionForUsers(ctx, instance.InstanceID, userdb.UserFilter{ReminderWeekDay: -1}, pruneSessions, &count)
+		if err != nil {
+			logger.Error.Printf("unexpected error: %s", err.Error())
+			continue
+		}
+		if count > 0 {
+			logger.Info.Printf("%s: removed %d expired sessions", instance.InstanceID, count)
+		} else {
+			logger.Debug.Printf("%s: removed %d expired sessions", instance.InstanceID, count)
+		}
+	}
+}
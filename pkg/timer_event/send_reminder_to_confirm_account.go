@@ -14,9 +14,9 @@ import (
 )
 
 // CleanUpUnverifiedUsers handles the deletion of unverified accounts after a threshold delay
-func (s *UserManagementTimerService) ReminderToConfirmAccount() {
+func (s *UserManagementTimerService) ReminderToConfirmAccount(ctx context.Context) {
 	logger.Debug.Println("Check if reminders to confirm accounts need to be sent out.")
-	instances, err := s.globalDBService.GetAllInstances()
+	instances, err := s.globalDBService.GetAllInstances(ctx)
 	if err != nil {
 		log.Printf("unexpected error: %s", err.Error())
 	}
@@ -33,9 +33,9 @@ func (s *UserManagementTimerService) ReminderToConfirmAccount() {
 				"type":  models.ACCOUNT_TYPE_EMAIL,
 				"email": user.Account.AccountID,
 			},
-			Expiration: tokens.GetExpirationTime(time.Hour * 24 * 30),
+			Expiration: tokens.GetExpirationTime(time.Hour*24*30, s.clock.Now()),
 		}
-		tempToken, err := s.globalDBService.AddTempToken(tempTokenInfos)
+		tempToken, err := s.globalDBService.AddTempToken(ctx, tempTokenInfos)
 		if err != nil {
 			logger.Error.Printf("unexpected error: %s", err.Error())
 			return errors.New("failed to create verification token")
@@ -62,8 +62,7 @@ func (s *UserManagementTimerService) ReminderToConfirmAccount() {
 
 	for _, instance := range instances {
 		count := 0
-		ctx := context.Background()
-		err := s.userDBService.SendReminderToConfirmAccountLoop(ctx, instance.InstanceID, time.Now().Unix()-sendReminderToConfirmAfter, sendReminderToUser, &count)
+		err := s.userDBService.SendReminderToConfirmAccountLoop(ctx, instance.InstanceID, s.clock.Now().Unix()-sendReminderToConfirmAfter, sendReminderToUser, &count)
 		if err != nil {
 			log.Printf("unexpected error: %s", err.Error())
 			continue
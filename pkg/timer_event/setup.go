@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
 	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
@@ -12,29 +13,36 @@ import (
 
 // UserManagementTimerService handles background times for user management (cleanup for example).
 type UserManagementTimerService struct {
-	globalDBService       *globaldb.GlobalDBService
-	userDBService         *userdb.UserDBService
+	globalDBService       globaldb.GlobalStore
+	userDBService         userdb.UserStore
 	clients               *models.APIClients
+	clock                 clock.Clock
 	TimerEventFrequency   int64 // how often the timer event should be performed (only from one instance of the service) - seconds
 	CleanUpTimeThreshold  int64 // if user account not verified, remove user after this many seconds
 	ReminderTimeThreshold int64 // if user account not verified, send a reminder email to the user after this many seconds
+
+	RefreshTokens models.RefreshTokenConfig // sessions exceeding its lifetimes are removed
 }
 
 func NewUserManagmentTimerService(
 	frequency int64,
-	globalDBService *globaldb.GlobalDBService,
-	userDBService *userdb.UserDBService,
+	globalDBService globaldb.GlobalStore,
+	userDBService userdb.UserStore,
 	clients *models.APIClients,
 	cleanUpTimeThreshold int64,
 	reminderTimeThreshold int64,
+	refreshTokens models.RefreshTokenConfig,
+	clk clock.Clock,
 ) *UserManagementTimerService {
 	return &UserManagementTimerService{
 		globalDBService:       globalDBService,
 		userDBService:         userDBService,
 		TimerEventFrequency:   frequency,
 		clients:               clients,
+		clock:                 clk,
 		CleanUpTimeThreshold:  cleanUpTimeThreshold,
 		ReminderTimeThreshold: reminderTimeThreshold,
+		RefreshTokens:         refreshTokens,
 	}
 }
 
@@ -47,8 +55,10 @@ func (s *UserManagementTimerService) startTimerThread(ctx context.Context, timeC
 	for {
 		select {
 		case <-time.After(time.Duration(timeCheckInterval) * time.Second):
-			go s.CleanUpUnverifiedUsers()
-			go s.ReminderToConfirmAccount()
+			go s.CleanUpUnverifiedUsers(ctx)
+			go s.ReminderToConfirmAccount(ctx)
+			go s.PruneExpiredSessions(ctx)
+			go s.CleanUpRevokedTokens(ctx)
 		case <-ctx.Done():
 			return
 		}
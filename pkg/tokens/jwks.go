@@ -0,0 +1,63 @@
+package tokens
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/influenzanet/user-management-service/pkg/api"
+)
+
+// JWK is a public key in the format of RFC 7517, only the fields of its key type are set
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// ToAPI converts the key to API format
+func (k JWK) ToAPI() *api.JSONWebKey {
+	return &api.JSONWebKey{
+		Kty: k.Kty,
+		Use: k.Use,
+		Alg: k.Alg,
+		Kid: k.Kid,
+		N:   k.N,
+		E:   k.E,
+		Crv: k.Crv,
+		X:   k.X,
+		Y:   k.Y,
+	}
+}
+
+// JWKS returns the public keys access tokens can be verified with. HS256 keys are not included, as their secret must
+// not be published.
+func JWKS() []JWK {
+	keys := []JWK{}
+	if keyring != nil {
+		for _, key := range keyring.PublicKeys() {
+			keys = append(keys, key.JWK())
+		}
+	}
+	return keys
+}
+
+// JWKSHandler serves the public keys as JSON Web Key Set, e.g. on /.well-known/jwks.json
+func JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_ = json.NewEncoder(w).Encode(struct {
+			Keys []JWK `json:"keys"`
+		}{JWKS()})
+	})
+}
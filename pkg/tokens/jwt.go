@@ -16,8 +16,26 @@ import (
 var (
 	secretKey    []byte
 	secretKeyEnc string
+
+	keyring *Keyring
 )
 
+// UseKeyring makes new tokens signed with the current key of the keyring instead of HS256 with JWT_TOKEN_KEY. Tokens
+// with a kid header are verified with the key of the keyring, tokens without are only accepted if they are signed
+// with JWT_TOKEN_KEY. Must be called before tokens are generated, nil switches back to JWT_TOKEN_KEY.
+func UseKeyring(k *Keyring) {
+	keyring = k
+}
+
+// UseSigningKey is a shortcut for UseKeyring with only the key
+func UseSigningKey(key *SigningKey) {
+	if key == nil {
+		keyring = nil
+		return
+	}
+	keyring = &Keyring{keys: map[string]*SigningKey{key.ID: key}, currentKey: key}
+}
+
 // UserClaims - Information a token enocodes
 type UserClaims struct {
 	ID               string            `json:"id,omitempty"`
@@ -28,6 +46,52 @@ type UserClaims struct {
 	TempTokenInfos   *models.TempToken `json:"temptoken,omitempty"`
 	OtherProfileIDs  []string          `json:"other_profile_ids,omitempty"`
 	jwt.StandardClaims
+
+	// reference time and expected issuer and audience for the validation, not part of the token
+	validationTime time.Time
+	validationConf models.AccessTokenConfig
+}
+
+// Valid checks the time based claims against the validation time (same checks as jwt.StandardClaims, which always
+// uses the system time), the expected issuer and audience, and that the subject is the user
+func (c UserClaims) Valid() error {
+	validationTime := c.validationTime
+	if validationTime.IsZero() {
+		validationTime = jwt.TimeFunc()
+	}
+	vErr := new(jwt.ValidationError)
+	now := validationTime.Unix()
+
+	if !c.VerifyExpiresAt(now, false) {
+		delta := time.Unix(now, 0).Sub(time.Unix(c.ExpiresAt, 0))
+		vErr.Inner = fmt.Errorf("token is expired by %v", delta)
+		vErr.Errors |= jwt.ValidationErrorExpired
+	}
+	if !c.VerifyIssuedAt(now, false) {
+		vErr.Inner = fmt.Errorf("token used before issued")
+		vErr.Errors |= jwt.ValidationErrorIssuedAt
+	}
+	if !c.VerifyNotBefore(now, false) {
+		vErr.Inner = fmt.Errorf("token is not valid yet")
+		vErr.Errors |= jwt.ValidationErrorNotValidYet
+	}
+	if issuer := c.validationConf.Issuer; issuer != "" && !c.VerifyIssuer(issuer, true) {
+		vErr.Inner = fmt.Errorf("token has wrong issuer")
+		vErr.Errors |= jwt.ValidationErrorIssuer
+	}
+	if audience := c.validationConf.Audience; audience != "" && !c.VerifyAudience(audience, true) {
+		vErr.Inner = fmt.Errorf("token has wrong audience")
+		vErr.Errors |= jwt.ValidationErrorAudience
+	}
+	if c.Subject != "" && c.Subject != c.ID {
+		vErr.Inner = fmt.Errorf("token subject does not match the user")
+		vErr.Errors |= jwt.ValidationErrorClaimsInvalid
+	}
+
+	if vErr.Errors == 0 {
+		return nil
+	}
+	return vErr
 }
 
 func getSecretKey() (newSecretKey []byte, err error) {
@@ -47,8 +111,14 @@ func getSecretKey() (newSecretKey []byte, err error) {
 	return
 }
 
-// GenerateNewToken create and signes a new token
-func GenerateNewToken(userID string, accountConfirmed bool, profileID string, userRoles []string, instanceID string, experiresIn time.Duration, username string, tempTokenInfos *models.TempToken, otherProfileIDs []string) (string, error) {
+// GenerateNewToken create and signes a new token, issued at the given time. Every token gets a unique ID (jti), so
+// that it can be revoked before it expires. iss and aud are taken from conf.
+func GenerateNewToken(conf models.AccessTokenConfig, userID string, accountConfirmed bool, profileID string, userRoles []string, instanceID string, experiresIn time.Duration, username string, tempTokenInfos *models.TempToken, otherProfileIDs []string, issuedAt time.Time) (string, error) {
+	tokenID, err := GenerateUniqueTokenString()
+	if err != nil {
+		return "", err
+	}
+
 	payload := map[string]string{}
 
 	if len(userRoles) > 0 {
@@ -68,16 +138,29 @@ func GenerateNewToken(userID string, accountConfirmed bool, profileID string, us
 		tempTokenInfos,
 		otherProfileIDs,
 		jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(experiresIn).Unix(),
-			IssuedAt:  time.Now().Unix(),
+			Id:        tokenID,
+			Subject:   userID,
+			Issuer:    conf.Issuer,
+			Audience:  conf.Audience,
+			ExpiresAt: issuedAt.Add(experiresIn).Unix(),
+			IssuedAt:  issuedAt.Unix(),
+			NotBefore: issuedAt.Unix(),
 		},
+		time.Time{},
+		models.AccessTokenConfig{},
+	}
+
+	if keyring != nil {
+		key := keyring.CurrentKey()
+		token := jwt.NewWithClaims(key.method, claims)
+		token.Header["kid"] = key.ID
+		return token.SignedString(key.signKey)
 	}
 
 	// Create the token
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	_, err := getSecretKey()
-	if err != nil {
+	if _, err := getSecretKey(); err != nil {
 		return "", err
 	}
 
@@ -86,19 +169,10 @@ func GenerateNewToken(userID string, accountConfirmed bool, profileID string, us
 	return tokenString, err
 }
 
-// ValidateToken parses and validates the token string
-func ValidateToken(tokenString string) (claims *UserClaims, valid bool, err error) {
-	_, err = getSecretKey()
-	if err != nil {
-		return nil, false, err
-	}
-
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return secretKey, nil
-	})
+// ValidateToken parses and validates the token string, time based claims are checked against `now` and iss and aud
+// against conf
+func ValidateToken(conf models.AccessTokenConfig, tokenString string, now time.Time) (claims *UserClaims, valid bool, err error) {
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{validationTime: now, validationConf: conf}, verificationKey)
 	if token == nil {
 		return
 	}
@@ -106,3 +180,30 @@ func ValidateToken(tokenString string) (claims *UserClaims, valid bool, err erro
 	valid = valid && token.Valid
 	return
 }
+
+// verificationKey returns the key for the token. Tokens with a kid header need the key from the keyring and its
+// signing method, tokens without are verified with JWT_TOKEN_KEY. With a keyring, JWT_TOKEN_KEY must be set to accept
+// them, so that they cannot be forged with an empty secret.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	if keyID, ok := token.Header["kid"].(string); ok && keyring != nil {
+		key, err := keyring.Key(keyID)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Method() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.verifyKey, nil
+	}
+
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	if _, err := getSecretKey(); err != nil {
+		return nil, err
+	}
+	if keyring != nil && len(secretKey) == 0 {
+		return nil, errors.New("tokens without key id are not accepted without JWT_TOKEN_KEY")
+	}
+	return secretKey, nil
+}
@@ -1,6 +1,14 @@
 package tokens
 
-import "testing"
+import (
+	b64 "encoding/base64"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
 
 func TestGetRolesFromPayload(t *testing.T) {
 	t.Run("with empty payload", func(t *testing.T) {
@@ -40,3 +48,107 @@ func TestGetRolesFromPayload(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateTokenWithTime(t *testing.T) {
+	os.Setenv("JWT_TOKEN_KEY", b64.StdEncoding.EncodeToString([]byte("testkey-testkey-testkey-testkey-testkey")))
+	issuedAt := time.Unix(1600000000, 0)
+	token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, issuedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("valid at issue time", func(t *testing.T) {
+		claims, valid, err := ValidateToken(models.AccessTokenConfig{}, token, issuedAt.Add(time.Minute))
+		if err != nil || !valid {
+			t.Errorf("token should be valid: %v", err)
+			return
+		}
+		if claims.ID != "uid" || claims.InstanceID != "inst" {
+			t.Errorf("unexpected claims: %v", claims)
+		}
+	})
+
+	t.Run("expired later", func(t *testing.T) {
+		claims, valid, err := ValidateToken(models.AccessTokenConfig{}, token, issuedAt.Add(time.Minute*11))
+		if err == nil || valid {
+			t.Error("token should be expired")
+			return
+		}
+		if !strings.Contains(err.Error(), "token is expired by") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if claims.ID != "uid" {
+			t.Errorf("claims should be parsed: %v", claims)
+		}
+	})
+
+	t.Run("used before issued", func(t *testing.T) {
+		_, valid, err := ValidateToken(models.AccessTokenConfig{}, token, issuedAt.Add(-time.Minute))
+		if err == nil || valid {
+			t.Error("token should not be valid yet")
+		}
+	})
+}
+
+func TestTokenID(t *testing.T) {
+	os.Setenv("JWT_TOKEN_KEY", b64.StdEncoding.EncodeToString([]byte("testkey-testkey-testkey-testkey-testkey")))
+	issuedAt := time.Unix(1600000000, 0)
+	ids := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{}, "inst", time.Minute*10, "", nil, []string{}, issuedAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		claims, _, err := ValidateToken(models.AccessTokenConfig{}, token, issuedAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if claims.Id == "" || ids[claims.Id] {
+			t.Errorf("token ID should be set and unique: %s", claims.Id)
+		}
+		ids[claims.Id] = true
+	}
+}
+
+func TestIssuerAndAudience(t *testing.T) {
+	os.Setenv("JWT_TOKEN_KEY", b64.StdEncoding.EncodeToString([]byte("testkey-testkey-testkey-testkey-testkey")))
+	issuedAt := time.Unix(1600000000, 0)
+
+	conf := models.AccessTokenConfig{Issuer: "https://deployment-a.example.org", Audience: "participant-app"}
+	token, err := GenerateNewToken(conf, "uid", true, "pid", []string{}, "inst", time.Minute*10, "", nil, []string{}, issuedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("registered claims are set", func(t *testing.T) {
+		claims, valid, err := ValidateToken(conf, token, issuedAt)
+		if err != nil || !valid {
+			t.Errorf("token should be valid: %v", err)
+			return
+		}
+		if claims.Subject != "uid" || claims.Issuer != "https://deployment-a.example.org" || claims.Audience != "participant-app" || claims.NotBefore != issuedAt.Unix() {
+			t.Errorf("unexpected claims: %v", claims)
+		}
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		other := models.AccessTokenConfig{Issuer: "https://deployment-b.example.org", Audience: "participant-app"}
+		if _, valid, err := ValidateToken(other, token, issuedAt); err == nil || valid {
+			t.Error("token of other deployment should be rejected")
+		}
+	})
+
+	t.Run("other audience", func(t *testing.T) {
+		other := models.AccessTokenConfig{Issuer: "https://deployment-a.example.org", Audience: "management-app"}
+		if _, valid, err := ValidateToken(other, token, issuedAt); err == nil || valid {
+			t.Error("token for other client should be rejected")
+		}
+	})
+
+	t.Run("token without claims", func(t *testing.T) {
+		oldToken, _ := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{}, "inst", time.Minute*10, "", nil, []string{}, issuedAt)
+		if _, valid, err := ValidateToken(conf, oldToken, issuedAt); err == nil || valid {
+			t.Error("token without issuer and audience should be rejected")
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package tokens
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PEM block type and header for keys in a keyring file
+const (
+	pemTypeHMACSecret = "HMAC SECRET"
+	pemHeaderKeyID    = "Kid"
+)
+
+// ErrUnknownKeyID when a token was signed with a key that is not in the keyring (anymore)
+var ErrUnknownKeyID = errors.New("token was signed with an unknown key")
+
+// Keyring holds the keys access tokens are verified with, the current key signs new tokens. Keys are rotated by adding
+// the new key, switching the current key to it and removing the old key once all tokens signed with it expired.
+type Keyring struct {
+	keys       map[string]*SigningKey
+	currentKey *SigningKey
+}
+
+// NewKeyring creates a keyring that signs with the key currentKeyID. currentKeyID can be empty if only one key can sign.
+func NewKeyring(keys []*SigningKey, currentKeyID string) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no keys found")
+	}
+	k := &Keyring{keys: map[string]*SigningKey{}}
+	signers := []string{}
+	for _, key := range keys {
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		k.keys[key.ID] = key
+		if key.CanSign() {
+			signers = append(signers, key.ID)
+		}
+	}
+	if currentKeyID == "" && len(signers) == 1 {
+		currentKeyID = signers[0]
+	}
+	current, ok := k.keys[currentKeyID]
+	if !ok {
+		return nil, fmt.Errorf("current key %q not found", currentKeyID)
+	}
+	if !current.CanSign() {
+		return nil, fmt.Errorf("current key %q has no private key", currentKeyID)
+	}
+	k.currentKey = current
+	return k, nil
+}
+
+// LoadKeyringFromDir reads the keys from the *.pem files in dir. A file contains one key, its ID is the Kid header of
+// the PEM block or else the file name without extension.
+func LoadKeyringFromDir(dir string, currentKeyID string) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	keys := []*SigningKey{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%s: no PEM encoded key found", path)
+		}
+		key, err := parseKeyringBlock(block, strings.TrimSuffix(filepath.Base(path), ".pem"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeyring(keys, currentKeyID)
+}
+
+// LoadKeyringFromFile reads the keys from the PEM blocks in the file. Keys without Kid header get an ID derived from
+// the public key, HMAC secrets need the header.
+func LoadKeyringFromFile(path string, currentKeyID string) (*Keyring, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*SigningKey{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		key, err := parseKeyringBlock(block, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %d: %v", path, len(keys)+1, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeyring(keys, currentKeyID)
+}
+
+// parseKeyringBlock reads a private key, a public key (only for verification) or an HS256 secret. The signing method
+// of asymmetric keys follows from the key type.
+func parseKeyringBlock(block *pem.Block, defaultKeyID string) (*SigningKey, error) {
+	keyID := block.Headers[pemHeaderKeyID]
+	if keyID == "" {
+		keyID = defaultKeyID
+	}
+	if block.Type == pemTypeHMACSecret {
+		return NewHMACKey(keyID, block.Bytes)
+	}
+
+	var key *SigningKey
+	if strings.HasSuffix(block.Type, "PUBLIC KEY") {
+		var publicKey interface{}
+		var err error
+		if block.Type == "RSA PUBLIC KEY" {
+			publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		} else {
+			publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, err
+		}
+		method, err := signingMethodForKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = NewVerificationKey(method, publicKey); err != nil {
+			return nil, err
+		}
+	} else {
+		privateKey, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		method, err := signingMethodForKey(privateKey.Public())
+		if err != nil {
+			return nil, err
+		}
+		if key, err = NewSigningKey(method, privateKey); err != nil {
+			return nil, err
+		}
+	}
+	if keyID != "" {
+		key.ID = keyID
+	}
+	return key, nil
+}
+
+// CurrentKey returns the key new tokens are signed with
+func (k *Keyring) CurrentKey() *SigningKey {
+	return k.currentKey
+}
+
+// Key returns the key with the ID
+func (k *Keyring) Key(keyID string) (*SigningKey, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// PublicKeys returns the asymmetric keys sorted by ID, retired keys are included until they are removed
+func (k *Keyring) PublicKeys() []*SigningKey {
+	keys := []*SigningKey{}
+	for _, key := range k.keys {
+		if key.IsPublic() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
@@ -0,0 +1,155 @@
+package tokens
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func writeKeyFile(t *testing.T, path string, blocks ...*pem.Block) {
+	data := []byte{}
+	for _, block := range blocks {
+		data = append(data, pem.EncodeToMemory(block)...)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	defer UseKeyring(nil)
+	dir, err := ioutil.TempDir("", "jwt-keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	oldDER, _ := x509.MarshalECPrivateKey(oldKey)
+	oldPublicDER, _ := x509.MarshalPKIXPublicKey(oldKey.Public())
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	newDER, _ := x509.MarshalPKCS8PrivateKey(newKey)
+
+	generate := func() string {
+		token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return token
+	}
+	load := func(currentKeyID string) {
+		keyring, err := LoadKeyringFromDir(dir, currentKeyID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		UseKeyring(keyring)
+	}
+
+	writeKeyFile(t, filepath.Join(dir, "old.pem"), &pem.Block{Type: "EC PRIVATE KEY", Bytes: oldDER})
+	load("")
+	oldToken := generate()
+
+	t.Run("new key is published before it is used", func(t *testing.T) {
+		writeKeyFile(t, filepath.Join(dir, "new.pem"), &pem.Block{Type: "PRIVATE KEY", Bytes: newDER})
+		if _, err := LoadKeyringFromDir(dir, ""); err == nil {
+			t.Error("current key must be chosen if several keys can sign")
+		}
+		load("old")
+		if jwks := JWKS(); len(jwks) != 2 || jwks[0].Kid != "new" || jwks[1].Kid != "old" {
+			t.Errorf("unexpected JWKS: %v", jwks)
+		}
+	})
+
+	t.Run("switch to new key", func(t *testing.T) {
+		load("new")
+		newToken := generate()
+		for _, token := range []string{oldToken, newToken} {
+			if _, valid, err := ValidateToken(models.AccessTokenConfig{}, token, time.Now()); !valid {
+				t.Errorf("token should be valid: %v", err)
+			}
+		}
+	})
+
+	t.Run("retire old key", func(t *testing.T) {
+		// only the public key is kept until the tokens signed with it expired
+		writeKeyFile(t, filepath.Join(dir, "old.pem"), &pem.Block{Type: "PUBLIC KEY", Bytes: oldPublicDER})
+		if _, err := LoadKeyringFromDir(dir, "old"); err == nil {
+			t.Error("key without private key cannot sign")
+		}
+		load("new")
+		if _, valid, err := ValidateToken(models.AccessTokenConfig{}, oldToken, time.Now()); !valid {
+			t.Errorf("token should be valid: %v", err)
+		}
+
+		os.Remove(filepath.Join(dir, "old.pem"))
+		load("new")
+		if _, valid, _ := ValidateToken(models.AccessTokenConfig{}, oldToken, time.Now()); valid {
+			t.Error("token of removed key should be rejected")
+		}
+	})
+}
+
+func TestLoadKeyringFromFile(t *testing.T) {
+	defer UseKeyring(nil)
+	f, err := ioutil.TempFile("", "jwt-keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	secret := []byte("hmac-secret-hmac-secret-hmac-secret")
+
+	t.Run("HMAC secret without key ID", func(t *testing.T) {
+		writeKeyFile(t, f.Name(), &pem.Block{Type: pemTypeHMACSecret, Bytes: secret})
+		if _, err := LoadKeyringFromFile(f.Name(), ""); err == nil {
+			t.Error("error expected")
+		}
+	})
+
+	t.Run("HMAC and EC key", func(t *testing.T) {
+		writeKeyFile(t, f.Name(),
+			&pem.Block{Type: pemTypeHMACSecret, Headers: map[string]string{pemHeaderKeyID: "hs-2024"}, Bytes: secret},
+			&pem.Block{Type: "EC PRIVATE KEY", Headers: map[string]string{pemHeaderKeyID: "ec-2025"}, Bytes: ecDER},
+		)
+		keyring, err := LoadKeyringFromFile(f.Name(), "hs-2024")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		UseKeyring(keyring)
+		token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, time.Now())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if _, valid, err := ValidateToken(models.AccessTokenConfig{}, token, time.Now()); !valid {
+			t.Errorf("token should be valid: %v", err)
+		}
+		if jwks := JWKS(); len(jwks) != 1 || jwks[0].Kid != "ec-2025" {
+			t.Errorf("HMAC secret must not be published: %v", jwks)
+		}
+	})
+
+	t.Run("signing method must match the key", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, UserClaims{ID: "uid"})
+		token.Header["kid"] = "ec-2025"
+		tokenString, _ := token.SignedString(secret)
+		if _, valid, _ := ValidateToken(models.AccessTokenConfig{}, tokenString, time.Now()); valid {
+			t.Error("token should be rejected")
+		}
+	})
+}
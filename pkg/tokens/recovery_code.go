@@ -0,0 +1,31 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// recoveryCodeCharSet has no 0, 1, I and O, which are easily confused. With 32 characters each random byte maps to
+// a character without bias.
+const recoveryCodeCharSet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+const recoveryCodeLength = 10
+
+// GenerateRecoveryCode returns a random code formatted as XXXXX-XXXXX
+func GenerateRecoveryCode() (string, error) {
+	buffer := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	for i := range buffer {
+		buffer[i] = recoveryCodeCharSet[int(buffer[i])%len(recoveryCodeCharSet)]
+	}
+	half := recoveryCodeLength / 2
+	return string(buffer[:half]) + "-" + string(buffer[half:]), nil
+}
+
+// NormalizeRecoveryCode removes the formatting of a code entered by the user, recovery codes are hashed in this form
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
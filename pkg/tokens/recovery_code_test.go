@@ -0,0 +1,33 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	code, err := GenerateRecoveryCode()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if len(code) != 11 || code[5] != '-' {
+		t.Errorf("unexpected format: %s", code)
+	}
+	for _, c := range strings.Replace(code, "-", "", 1) {
+		if !strings.ContainsRune(recoveryCodeCharSet, c) {
+			t.Errorf("unexpected character in %s", code)
+		}
+	}
+
+	other, _ := GenerateRecoveryCode()
+	if other == code {
+		t.Errorf("codes should be random: %s", code)
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	if c := NormalizeRecoveryCode(" abcde-FGH23 "); c != "ABCDEFGH23" {
+		t.Errorf("unexpected code: %s", c)
+	}
+}
@@ -0,0 +1,20 @@
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// HashRefreshToken returns the hash a refresh token is stored as: HMAC-SHA256 with the key, or plain SHA-256 without
+// key. Refresh tokens are random, so the hash can be used to look them up and a DB dump doesn't contain usable tokens.
+// The key additionally protects against someone who can write to the DB and wants to plant a known token.
+func HashRefreshToken(key []byte, token string) string {
+	if len(key) == 0 {
+		hash := sha256.Sum256([]byte(token))
+		return base64.RawURLEncoding.EncodeToString(hash[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+)
+
+func TestHashRefreshToken(t *testing.T) {
+	token, err := GenerateUniqueTokenString()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	key := []byte("test-key")
+
+	hash := HashRefreshToken(key, token)
+	if hash == token || hash != HashRefreshToken(key, token) {
+		t.Errorf("unexpected hash: %s", hash)
+	}
+	if hash == HashRefreshToken([]byte("other-key"), token) {
+		t.Error("hash should depend on the key")
+	}
+
+	sha := sha256.Sum256([]byte(token))
+	if HashRefreshToken(nil, token) != base64.RawURLEncoding.EncodeToString(sha[:]) {
+		t.Error("plain SHA-256 expected without key")
+	}
+}
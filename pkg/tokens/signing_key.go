@@ -0,0 +1,193 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// Signing methods for access tokens. HS256 uses a shared secret, the others a private key whose public key is
+// published as JWK.
+const (
+	SIGNING_METHOD_HS256 = "HS256"
+	SIGNING_METHOD_RS256 = "RS256"
+	SIGNING_METHOD_ES256 = "ES256"
+	SIGNING_METHOD_EDDSA = "EdDSA"
+)
+
+const minHMACSecretLength = 32
+
+// SigningKey is a key for signing and verifying access tokens. Keys created from a public key can only verify.
+type SigningKey struct {
+	ID        string // kid header of the tokens
+	method    jwt.SigningMethod
+	signKey   interface{} // crypto.Signer or HMAC secret, nil if the key can only verify
+	verifyKey interface{} // crypto.PublicKey or HMAC secret
+}
+
+// ParseSigningKey reads a PEM encoded private key (PKCS#1, PKCS#8 or SEC 1) for the signing method
+func ParseSigningKey(method string, pemData []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+	signer, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigningKey(method, signer)
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported key type")
+	}
+	return signer, nil
+}
+
+// NewSigningKey checks that the key can be used with the signing method. The key ID is derived from the public key.
+func NewSigningKey(method string, privateKey crypto.Signer) (*SigningKey, error) {
+	k, err := NewVerificationKey(method, privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	k.signKey = privateKey
+	return k, nil
+}
+
+// NewVerificationKey creates a key that only verifies tokens, e.g. for a retired key whose private key was deleted
+func NewVerificationKey(method string, publicKey crypto.PublicKey) (*SigningKey, error) {
+	k := &SigningKey{verifyKey: publicKey}
+	switch method {
+	case SIGNING_METHOD_RS256:
+		if key, ok := publicKey.(*rsa.PublicKey); !ok || key.N.BitLen() < 2048 {
+			return nil, errors.New("RS256 needs an RSA key with at least 2048 bits")
+		}
+		k.method = jwt.SigningMethodRS256
+	case SIGNING_METHOD_ES256:
+		if key, ok := publicKey.(*ecdsa.PublicKey); !ok || key.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 needs an EC key on the P-256 curve")
+		}
+		k.method = jwt.SigningMethodES256
+	case SIGNING_METHOD_EDDSA:
+		if _, ok := publicKey.(ed25519.PublicKey); !ok {
+			return nil, errors.New("EdDSA needs an Ed25519 key")
+		}
+		k.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported signing method %q", method)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(der)
+	k.ID = b64.RawURLEncoding.EncodeToString(hash[:12])
+	return k, nil
+}
+
+// NewHMACKey creates an HS256 key. Its secret must not be published, so a key ID has to be chosen.
+func NewHMACKey(id string, secret []byte) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.New("HMAC keys need a key ID")
+	}
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("HMAC secret must have at least %d bytes", minHMACSecretLength)
+	}
+	return &SigningKey{
+		ID:        id,
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}, nil
+}
+
+// signingMethodForKey returns the method an asymmetric key is used with
+func signingMethodForKey(publicKey crypto.PublicKey) (string, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return SIGNING_METHOD_RS256, nil
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() {
+			return SIGNING_METHOD_ES256, nil
+		}
+	case ed25519.PublicKey:
+		return SIGNING_METHOD_EDDSA, nil
+	}
+	return "", errors.New("unsupported key type")
+}
+
+// Method returns the name of the signing method, as used in the alg header
+func (k *SigningKey) Method() string {
+	return k.method.Alg()
+}
+
+// CanSign is false for keys that only verify tokens
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+// IsPublic is true for asymmetric keys, whose public key can be published
+func (k *SigningKey) IsPublic() bool {
+	return k.method != jwt.SigningMethodHS256
+}
+
+// PublicKey returns the key to verify tokens signed with k, nil for HS256
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	if !k.IsPublic() {
+		return nil
+	}
+	return k.verifyKey
+}
+
+// JWK returns the public key in the format of RFC 7517
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{
+		Use: "sig",
+		Alg: k.Method(),
+		Kid: k.ID,
+	}
+	switch pub := k.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = b64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
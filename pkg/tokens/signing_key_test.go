@@ -0,0 +1,118 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func TestSigningKeys(t *testing.T) {
+	os.Setenv("JWT_TOKEN_KEY", b64.StdEncoding.EncodeToString([]byte("testkey-testkey-testkey-testkey-testkey")))
+	defer UseSigningKey(nil)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	hs256Token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		method string
+		key    crypto.Signer
+		kty    string
+	}{
+		{SIGNING_METHOD_RS256, rsaKey, "RSA"},
+		{SIGNING_METHOD_ES256, ecKey, "EC"},
+		{SIGNING_METHOD_EDDSA, edKey, "OKP"},
+	} {
+		t.Run(test.method, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := ParseSigningKey(test.method, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			UseSigningKey(key)
+
+			token, err := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, now)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			claims, valid, err := ValidateToken(models.AccessTokenConfig{}, token, now)
+			if err != nil || !valid || claims.ID != "uid" {
+				t.Errorf("token should be valid: %v %v", claims, err)
+			}
+			if _, valid, _ := ValidateToken(models.AccessTokenConfig{}, hs256Token, now); !valid {
+				t.Error("HS256 token should still be valid")
+			}
+
+			jwks := JWKS()
+			if len(jwks) != 1 || jwks[0].Kty != test.kty || jwks[0].Alg != test.method || jwks[0].Kid != key.ID || jwks[0].X == "" && jwks[0].N == "" {
+				t.Errorf("unexpected JWKS: %v", jwks)
+			}
+		})
+	}
+
+	t.Run("wrong key type for method", func(t *testing.T) {
+		if _, err := NewSigningKey(SIGNING_METHOD_RS256, ecKey); err == nil {
+			t.Error("error expected")
+		}
+		if _, err := NewSigningKey(SIGNING_METHOD_HS256, ecKey); err == nil {
+			t.Error("error expected")
+		}
+	})
+
+	t.Run("token signed with other key", func(t *testing.T) {
+		otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		key, _ := NewSigningKey(SIGNING_METHOD_ES256, otherKey)
+		UseSigningKey(key)
+		token, _ := GenerateNewToken(models.AccessTokenConfig{}, "uid", true, "pid", []string{"PARTICIPANT"}, "inst", time.Minute*10, "", nil, []string{}, now)
+
+		key, _ = NewSigningKey(SIGNING_METHOD_ES256, ecKey)
+		UseSigningKey(key)
+		if _, valid, _ := ValidateToken(models.AccessTokenConfig{}, token, now); valid {
+			t.Error("token should be rejected")
+		}
+	})
+
+	t.Run("JWKS handler", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		JWKSHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+		var body struct {
+			Keys []JWK `json:"keys"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || len(body.Keys) != 1 || body.Keys[0].Crv != "P-256" {
+			t.Errorf("unexpected response: %v %v", body, err)
+		}
+	})
+}
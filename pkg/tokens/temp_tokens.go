@@ -29,12 +29,12 @@ func GenerateUniqueTokenString() (string, error) {
 	return tokenStr, nil
 }
 
-func GetExpirationTime(validityPeriod time.Duration) int64 {
-	return time.Now().Add(validityPeriod).Unix()
+func GetExpirationTime(validityPeriod time.Duration, now time.Time) int64 {
+	return now.Add(validityPeriod).Unix()
 }
 
-func ReachedExpirationTime(t int64) bool {
-	return time.Now().After(time.Unix(t, 0))
+func ReachedExpirationTime(t int64, now time.Time) bool {
+	return now.After(time.Unix(t, 0))
 }
 
 func GetRolesFromPayload(payload map[string]string) []string {
@@ -31,7 +31,7 @@ func TestGenerateUniqueTokenString(t *testing.T) {
 
 func TestGetExpirationTime(t *testing.T) {
 	t.Run("with negative days", func(t *testing.T) {
-		resUnix := GetExpirationTime(time.Hour * 24 * -5)
+		resUnix := GetExpirationTime(time.Hour*24*-5, time.Now())
 		resTime := time.Unix(resUnix, 0)
 		expected := time.Now().AddDate(0, 0, -5)
 		if resTime.Year() != expected.Year() || resTime.Month() != expected.Month() || resTime.Day() != expected.Day() {
@@ -41,7 +41,7 @@ func TestGetExpirationTime(t *testing.T) {
 	})
 
 	t.Run("with zero days", func(t *testing.T) {
-		resUnix := GetExpirationTime(time.Hour * 24 * 0)
+		resUnix := GetExpirationTime(time.Hour*24*0, time.Now())
 		resTime := time.Unix(resUnix, 0)
 		expected := time.Now()
 		if resTime.Year() != expected.Year() || resTime.Month() != expected.Month() || resTime.Day() != expected.Day() {
@@ -51,7 +51,7 @@ func TestGetExpirationTime(t *testing.T) {
 	})
 
 	t.Run("with positive days", func(t *testing.T) {
-		resUnix := GetExpirationTime(time.Hour * 24 * 5)
+		resUnix := GetExpirationTime(time.Hour*24*5, time.Now())
 		resTime := time.Unix(resUnix, 0)
 		expected := time.Now().AddDate(0, 0, 5)
 		if resTime.Year() != expected.Year() || resTime.Month() != expected.Month() || resTime.Day() != expected.Day() {
@@ -63,20 +63,29 @@ func TestGetExpirationTime(t *testing.T) {
 
 func TestReachedExpirationTime(t *testing.T) {
 	t.Run("before expiration", func(t *testing.T) {
-		exp := GetExpirationTime(time.Hour * 1)
-		isExp := ReachedExpirationTime(exp)
+		exp := GetExpirationTime(time.Hour*1, time.Now())
+		isExp := ReachedExpirationTime(exp, time.Now())
 		if isExp {
 			t.Error("expiration should not be reached yet")
 		}
 	})
 
 	t.Run("after expiration", func(t *testing.T) {
-		exp := GetExpirationTime(time.Hour * -1)
-		isExp := ReachedExpirationTime(exp)
+		exp := GetExpirationTime(time.Hour*-1, time.Now())
+		isExp := ReachedExpirationTime(exp, time.Now())
 		if !isExp {
 			t.Error("should be expired now")
 		}
 	})
-}
-
 
+	t.Run("relative to given time", func(t *testing.T) {
+		now := time.Now()
+		exp := GetExpirationTime(time.Hour*1, now)
+		if ReachedExpirationTime(exp, now.Add(59*time.Minute)) {
+			t.Error("expiration should not be reached yet")
+		}
+		if !ReachedExpirationTime(exp, now.Add(61*time.Minute)) {
+			t.Error("should be expired")
+		}
+	})
+}
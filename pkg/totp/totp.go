@@ -0,0 +1,117 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as used by authenticator apps: HMAC-SHA1,
+// 6 digits and a time step of 30 seconds, which are the defaults all common apps support.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the length of a time step in seconds
+	Period = 30
+	// Digits is the length of a code
+	Digits = 6
+	// modulo is 10^Digits
+	modulo = 1000000
+	// secretLength in bytes, 160 bits as recommended by RFC 4226
+	secretLength = 20
+	// skew is the number of time steps before and after the current one that are accepted to allow for clock drift
+	skew = 1
+)
+
+// ErrInvalidCode is returned if the code does not match the secret at the given time
+var ErrInvalidCode = errors.New("invalid code")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret creates a random secret, base32 encoded as authenticator apps expect it
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	return encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+}
+
+// step returns the time step of t
+func step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+func code(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
+
+// GenerateCode returns the code for the secret at time t
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, step(t)), nil
+}
+
+// Validate checks the code against the secret at time t, also accepting the codes of the adjacent time steps. It
+// returns the time step the code belongs to: callers store it and pass it as lastUsedStep next time, so that a code
+// cannot be used twice.
+func Validate(secret string, input string, t time.Time, lastUsedStep int64) (int64, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, err
+	}
+	input = strings.ReplaceAll(input, " ", "")
+	if len(input) != Digits {
+		return 0, ErrInvalidCode
+	}
+
+	current := step(t)
+	for s := current - skew; s <= current+skew; s++ {
+		if s <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(code(key, s)), []byte(input)) == 1 {
+			return s, nil
+		}
+	}
+	return 0, ErrInvalidCode
+}
+
+// KeyURI returns the otpauth:// URI that authenticator apps read from a QR code
+func KeyURI(issuer string, accountName string, secret string) string {
+	label := url.PathEscape(accountName)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+	params := url.Values{}
+	params.Set("secret", secret)
+	if issuer != "" {
+		params.Set("issuer", issuer)
+	}
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(Period))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
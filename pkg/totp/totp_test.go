@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// secret of the RFC 6238 test vectors for SHA1
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	for _, tc := range []struct {
+		time int64
+		code string
+	}{
+		// last 6 digits of the 8 digit codes in RFC 6238 appendix B
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		c, err := GenerateCode(rfcSecret, time.Unix(tc.time, 0))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if c != tc.code {
+			t.Errorf("unexpected code at %d: %s instead of %s", tc.time, c, tc.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1600000000, 0)
+	c, _ := GenerateCode(secret, now)
+
+	t.Run("current code", func(t *testing.T) {
+		s, err := Validate(secret, c, now, 0)
+		if err != nil || s != now.Unix()/Period {
+			t.Errorf("unexpected result: %d, %v", s, err)
+		}
+	})
+
+	t.Run("clock drift of one step", func(t *testing.T) {
+		if _, err := Validate(secret, c, now.Add(Period*time.Second), 0); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := Validate(secret, c, now.Add(-Period*time.Second), 0); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		if _, err := Validate(secret, c, now.Add(3*Period*time.Second), 0); err != ErrInvalidCode {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("code used before", func(t *testing.T) {
+		if _, err := Validate(secret, c, now, now.Unix()/Period); err != ErrInvalidCode {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("wrong format", func(t *testing.T) {
+		for _, input := range []string{"", "12345", "1234567", "abcdef"} {
+			if _, err := Validate(secret, input, now, 0); err == nil {
+				t.Errorf("code %q should not be accepted", input)
+			}
+		}
+		if _, err := Validate(secret, c[:3]+" "+c[3:], now, 0); err != nil {
+			t.Errorf("spaces should be ignored: %v", err)
+		}
+	})
+
+	t.Run("lower case secret", func(t *testing.T) {
+		if _, err := Validate(strings.ToLower(secret), c, now, 0); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestKeyURI(t *testing.T) {
+	uri := KeyURI("Influenzanet", "test@test.com", "ABC")
+	if uri != "otpauth://totp/Influenzanet:test@test.com?algorithm=SHA1&digits=6&issuer=Influenzanet&period=30&secret=ABC" {
+		t.Errorf("unexpected uri: %s", uri)
+	}
+}
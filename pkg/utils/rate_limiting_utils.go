@@ -1,10 +1,9 @@
 package utils
 
-import "time"
-
-func HasMoreAttemptsRecently(attempts []int64, moreThan int, intervalSeconds int64) bool {
+// HasMoreAttemptsRecently checks if there are more than `moreThan` attempts in the `intervalSeconds` before `now` (unix timestamp)
+func HasMoreAttemptsRecently(attempts []int64, moreThan int, intervalSeconds int64, now int64) bool {
 	counter := 0
-	startTime := time.Now().Unix() - intervalSeconds
+	startTime := now - intervalSeconds
 	for _, ts := range attempts {
 		if ts > startTime {
 			counter += 1
@@ -13,9 +12,10 @@ func HasMoreAttemptsRecently(attempts []int64, moreThan int, intervalSeconds int
 	return counter > moreThan
 }
 
-func RemoveAttemptsOlderThan(attempts []int64, olderThanSeconds int64) []int64 {
+// RemoveAttemptsOlderThan drops attempts older than `olderThanSeconds` before `now` (unix timestamp)
+func RemoveAttemptsOlderThan(attempts []int64, olderThanSeconds int64, now int64) []int64 {
 	updated := []int64{}
-	threshold := time.Now().Unix() - olderThanSeconds
+	threshold := now - olderThanSeconds
 	for _, v := range attempts {
 		if v >= threshold {
 			updated = append(updated, v)
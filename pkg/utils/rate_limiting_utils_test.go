@@ -6,26 +6,45 @@ import (
 )
 
 func TestHasMoreAttemptsRecently(t *testing.T) {
+	now := time.Now().Unix()
+
 	t.Run("with a empty array", func(t *testing.T) {
-		if HasMoreAttemptsRecently([]int64{}, 5, 100) {
+		if HasMoreAttemptsRecently([]int64{}, 5, 100, now) {
 			t.Error("should be false")
 		}
 	})
 
 	t.Run("with less than threshold", func(t *testing.T) {
 		if HasMoreAttemptsRecently([]int64{
-			time.Now().Unix() - 105, time.Now().Unix() - 65, time.Now().Unix() - 55, time.Now().Unix() - 45, time.Now().Unix() - 35, time.Now().Unix() - 25,
-		}, 5, 100) {
+			now - 105, now - 65, now - 55, now - 45, now - 35, now - 25,
+		}, 5, 100, now) {
 			t.Error("should be false")
 		}
 	})
 
 	t.Run("with more than threshold", func(t *testing.T) {
 		if !HasMoreAttemptsRecently([]int64{
-			time.Now().Unix() - 95, time.Now().Unix() - 65, time.Now().Unix() - 55, time.Now().Unix() - 45, time.Now().Unix() - 35, time.Now().Unix() - 25,
-		}, 5, 100) {
+			now - 95, now - 65, now - 55, now - 45, now - 35, now - 25,
+		}, 5, 100, now) {
 			t.Error("should be true")
 		}
 	})
 
+	t.Run("relative to given time", func(t *testing.T) {
+		attempts := []int64{now - 95, now - 65, now - 55, now - 45, now - 35, now - 25}
+		if HasMoreAttemptsRecently(attempts, 5, 100, now+10) {
+			t.Error("should be false")
+		}
+	})
+}
+
+func TestRemoveAttemptsOlderThan(t *testing.T) {
+	now := time.Now().Unix()
+	attempts := []int64{now - 100, now - 50, now - 10}
+	if res := RemoveAttemptsOlderThan(attempts, 60, now); len(res) != 2 {
+		t.Errorf("unexpected result: %v", res)
+	}
+	if res := RemoveAttemptsOlderThan(attempts, 60, now+100); len(res) != 0 {
+		t.Errorf("unexpected result: %v", res)
+	}
 }
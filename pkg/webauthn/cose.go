@@ -0,0 +1,131 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE algorithm identifiers
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// supportedAlgorithms in order of preference
+var supportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+const (
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// coseKey holds the parameters of a COSE_Key. The meaning of the negative labels depends on the key type, so they are
+// decoded later.
+type coseKey struct {
+	Kty    int64           `cbor:"1,keyasint"`
+	Alg    int64           `cbor:"3,keyasint"`
+	Param1 cbor.RawMessage `cbor:"-1,keyasint"`
+	Param2 cbor.RawMessage `cbor:"-2,keyasint"`
+	Param3 cbor.RawMessage `cbor:"-3,keyasint"`
+}
+
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+func parsePublicKey(data []byte) (publicKey, error) {
+	var k coseKey
+	if err := cbor.Unmarshal(data, &k); err != nil {
+		return publicKey{}, fmt.Errorf("public key: %v", err)
+	}
+
+	switch {
+	case k.Kty == coseKtyEC2 && k.Alg == AlgES256:
+		var crv int64
+		var x, y []byte
+		if err := decodeParams(&k, &crv, &x, &y); err != nil {
+			return publicKey{}, err
+		}
+		if crv != coseCrvP256 || len(x) != 32 || len(y) != 32 {
+			return publicKey{}, errors.New("public key: invalid EC2 parameters")
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return publicKey{}, errors.New("public key: point not on curve")
+		}
+		return publicKey{alg: k.Alg, key: key}, nil
+	case k.Kty == coseKtyOKP && k.Alg == AlgEdDSA:
+		var crv int64
+		var x []byte
+		if err := decodeParams(&k, &crv, &x, nil); err != nil {
+			return publicKey{}, err
+		}
+		if crv != coseCrvEd25519 || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, errors.New("public key: invalid OKP parameters")
+		}
+		return publicKey{alg: k.Alg, key: ed25519.PublicKey(x)}, nil
+	case k.Kty == coseKtyRSA && k.Alg == AlgRS256:
+		var n, e []byte
+		if err := decodeParams(&k, &n, &e, nil); err != nil {
+			return publicKey{}, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return publicKey{}, errors.New("public key: invalid RSA parameters")
+		}
+		return publicKey{alg: k.Alg, key: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}}, nil
+	}
+	return publicKey{}, fmt.Errorf("public key: unsupported key type %d with algorithm %d", k.Kty, k.Alg)
+}
+
+// decodeParams decodes the parameters -1, -2 and -3 into the given values, nil values are skipped
+func decodeParams(k *coseKey, values ...interface{}) error {
+	raw := []cbor.RawMessage{k.Param1, k.Param2, k.Param3}
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if len(raw[i]) == 0 {
+			return fmt.Errorf("public key: missing parameter %d", -(i + 1))
+		}
+		if err := cbor.Unmarshal(raw[i], v); err != nil {
+			return fmt.Errorf("public key: parameter %d: %v", -(i + 1), err)
+		}
+	}
+	return nil
+}
+
+func (k publicKey) verify(data []byte, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	}
+	return errors.New("unsupported key")
+}
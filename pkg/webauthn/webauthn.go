@@ -0,0 +1,388 @@
+// Package webauthn implements the relying party side of the Web Authentication API (passkeys). It creates the options
+// for the registration and authentication ceremonies and verifies the responses of the browser.
+//
+// Attestation is not requested, so attestation statements are not verified: any authenticator can be registered.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// ChallengeLength in bytes
+	ChallengeLength = 32
+	// Timeout of the ceremonies in milliseconds, passed to the browser
+	Timeout = 5 * 60 * 1000
+
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+
+	ceremonyCreate = "webauthn.create"
+	ceremonyGet    = "webauthn.get"
+)
+
+var (
+	// ErrVerification is returned if a response does not match the ceremony, the relying party or the credential
+	ErrVerification = errors.New("webauthn verification failed")
+	// ErrSignCount is returned if the signature counter did not increase, which is a sign of a cloned authenticator
+	ErrSignCount = errors.New("signature counter did not increase")
+)
+
+// RelyingParty identifies the web application the passkeys are bound to
+type RelyingParty struct {
+	// ID is the domain of the web application, e.g. example.com
+	ID string
+	// Name is shown by the browser during the ceremonies
+	Name string
+	// Origins the browser may report, e.g. https://www.example.com
+	Origins []string
+}
+
+// Enabled is true if the relying party is configured
+func (rp RelyingParty) Enabled() bool {
+	return rp.ID != "" && len(rp.Origins) > 0
+}
+
+// URLEncodedBytes are base64url encoded in JSON, as in the JSON serialization of the Web Authentication API
+type URLEncodedBytes []byte
+
+func (b URLEncodedBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *URLEncodedBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := DecodeID(s)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// EncodeID returns the base64url representation used for credential IDs and challenges
+func EncodeID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// DecodeID decodes base64url, with or without padding
+func DecodeID(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// NewChallenge creates a random challenge for a ceremony
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, ChallengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// CredentialDescriptor references a registered credential
+type CredentialDescriptor struct {
+	Type       string          `json:"type"`
+	ID         URLEncodedBytes `json:"id"`
+	Transports []string        `json:"transports,omitempty"`
+}
+
+// NewCredentialDescriptor returns the descriptor of a public key credential
+func NewCredentialDescriptor(id []byte, transports []string) CredentialDescriptor {
+	return CredentialDescriptor{Type: "public-key", ID: id, Transports: transports}
+}
+
+type rpEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity describes the account a credential is created for
+type UserEntity struct {
+	// ID is returned as user handle when logging in with the credential
+	ID          URLEncodedBytes `json:"id"`
+	Name        string          `json:"name"`
+	DisplayName string          `json:"displayName"`
+}
+
+type credentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type authenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are passed to navigator.credentials.create() (as publicKey, after decoding the base64url fields)
+type CreationOptions struct {
+	RP                     rpEntity               `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Challenge              URLEncodedBytes        `json:"challenge"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are passed to navigator.credentials.get() (as publicKey, after decoding the base64url fields)
+type RequestOptions struct {
+	Challenge        URLEncodedBytes        `json:"challenge"`
+	Timeout          int                    `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// CreationOptions for registering a new credential. Credentials of the user that are already registered should be
+// excluded, so that an authenticator is not registered twice.
+func (rp RelyingParty) CreationOptions(challenge []byte, user UserEntity, exclude []CredentialDescriptor) CreationOptions {
+	params := []credentialParameter{}
+	for _, alg := range supportedAlgorithms {
+		params = append(params, credentialParameter{Type: "public-key", Alg: alg})
+	}
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+	return CreationOptions{
+		RP:                     rpEntity{ID: rp.ID, Name: rp.Name},
+		User:                   user,
+		Challenge:              challenge,
+		PubKeyCredParams:       params,
+		Timeout:                Timeout,
+		ExcludeCredentials:     exclude,
+		AuthenticatorSelection: authenticatorSelection{ResidentKey: "preferred", UserVerification: "preferred"},
+		Attestation:            "none",
+	}
+}
+
+// RequestOptions for logging in. Without allowed credentials the browser offers the discoverable credentials (passkeys)
+// of the relying party.
+func (rp RelyingParty) RequestOptions(challenge []byte, allow []CredentialDescriptor) RequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout,
+		RPID:             rp.ID,
+		AllowCredentials: allow,
+		UserVerification: "preferred",
+	}
+}
+
+// RegistrationResponse is the JSON serialization of the PublicKeyCredential returned by navigator.credentials.create()
+type RegistrationResponse struct {
+	ID       string          `json:"id"`
+	RawID    URLEncodedBytes `json:"rawId"`
+	Type     string          `json:"type"`
+	Response struct {
+		ClientDataJSON    URLEncodedBytes `json:"clientDataJSON"`
+		AttestationObject URLEncodedBytes `json:"attestationObject"`
+		Transports        []string        `json:"transports"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the JSON serialization of the PublicKeyCredential returned by navigator.credentials.get()
+type AssertionResponse struct {
+	ID       string          `json:"id"`
+	RawID    URLEncodedBytes `json:"rawId"`
+	Type     string          `json:"type"`
+	Response struct {
+		ClientDataJSON    URLEncodedBytes `json:"clientDataJSON"`
+		AuthenticatorData URLEncodedBytes `json:"authenticatorData"`
+		Signature         URLEncodedBytes `json:"signature"`
+		UserHandle        URLEncodedBytes `json:"userHandle"`
+	} `json:"response"`
+}
+
+// ParseRegistrationResponse decodes the credential sent by the client
+func ParseRegistrationResponse(data string) (RegistrationResponse, error) {
+	var resp RegistrationResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		return resp, err
+	}
+	if resp.Type != "public-key" || len(resp.RawID) == 0 {
+		return resp, errors.New("invalid credential")
+	}
+	return resp, nil
+}
+
+// ParseAssertionResponse decodes the credential sent by the client
+func ParseAssertionResponse(data string) (AssertionResponse, error) {
+	var resp AssertionResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		return resp, err
+	}
+	if resp.Type != "public-key" || len(resp.RawID) == 0 {
+		return resp, errors.New("invalid credential")
+	}
+	return resp, nil
+}
+
+// Credential is a verified new credential
+type Credential struct {
+	ID []byte
+	// PublicKey is COSE encoded
+	PublicKey  []byte
+	SignCount  uint32
+	Transports []string
+}
+
+type clientData struct {
+	Type      string          `json:"type"`
+	Challenge URLEncodedBytes `json:"challenge"`
+	Origin    string          `json:"origin"`
+}
+
+func (rp RelyingParty) verifyClientData(raw []byte, ceremony string, challenge []byte) error {
+	var cd clientData
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return fmt.Errorf("%w: client data: %v", ErrVerification, err)
+	}
+	if cd.Type != ceremony {
+		return fmt.Errorf("%w: unexpected ceremony %s", ErrVerification, cd.Type)
+	}
+	if subtle.ConstantTimeCompare(cd.Challenge, challenge) != 1 {
+		return fmt.Errorf("%w: challenge does not match", ErrVerification)
+	}
+	for _, o := range rp.Origins {
+		if cd.Origin == o {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unexpected origin %s", ErrVerification, cd.Origin)
+}
+
+type authenticatorData struct {
+	flags     byte
+	signCount uint32
+	// set if flagAttestedData is set
+	credentialID []byte
+	publicKey    []byte
+}
+
+func (rp RelyingParty) parseAuthenticatorData(data []byte) (authenticatorData, error) {
+	ad := authenticatorData{}
+	if len(data) < 37 {
+		return ad, fmt.Errorf("%w: authenticator data too short", ErrVerification)
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return ad, fmt.Errorf("%w: relying party does not match", ErrVerification)
+	}
+	ad.flags = data[32]
+	ad.signCount = binary.BigEndian.Uint32(data[33:37])
+	if ad.flags&flagUserPresent == 0 {
+		return ad, fmt.Errorf("%w: user not present", ErrVerification)
+	}
+	if ad.flags&flagAttestedData == 0 {
+		return ad, nil
+	}
+
+	// attested credential data: AAGUID (16), credential ID length (2), credential ID, COSE public key
+	rest := data[37:]
+	if len(rest) < 18 {
+		return ad, fmt.Errorf("%w: attested credential data too short", ErrVerification)
+	}
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLen {
+		return ad, fmt.Errorf("%w: attested credential data too short", ErrVerification)
+	}
+	ad.credentialID = rest[:idLen]
+
+	// the public key can be followed by extensions, so its length is only known after decoding it
+	dec := cbor.NewDecoder(bytes.NewReader(rest[idLen:]))
+	var key cbor.RawMessage
+	if err := dec.Decode(&key); err != nil {
+		return ad, fmt.Errorf("%w: public key: %v", ErrVerification, err)
+	}
+	ad.publicKey = key
+	return ad, nil
+}
+
+type attestationObject struct {
+	Fmt      string          `cbor:"fmt"`
+	AttStmt  cbor.RawMessage `cbor:"attStmt"`
+	AuthData []byte          `cbor:"authData"`
+}
+
+// VerifyRegistration checks the response of the registration ceremony started with the challenge and returns the new
+// credential
+func (rp RelyingParty) VerifyRegistration(challenge []byte, resp RegistrationResponse) (Credential, error) {
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, ceremonyCreate, challenge); err != nil {
+		return Credential{}, err
+	}
+	var att attestationObject
+	if err := cbor.Unmarshal(resp.Response.AttestationObject, &att); err != nil {
+		return Credential{}, fmt.Errorf("%w: attestation object: %v", ErrVerification, err)
+	}
+	ad, err := rp.parseAuthenticatorData(att.AuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+	if ad.credentialID == nil {
+		return Credential{}, fmt.Errorf("%w: no attested credential data", ErrVerification)
+	}
+	if !bytes.Equal(ad.credentialID, resp.RawID) {
+		return Credential{}, fmt.Errorf("%w: credential ID does not match", ErrVerification)
+	}
+	if _, err := parsePublicKey(ad.publicKey); err != nil {
+		return Credential{}, fmt.Errorf("%w: %v", ErrVerification, err)
+	}
+	return Credential{
+		ID:         ad.credentialID,
+		PublicKey:  ad.publicKey,
+		SignCount:  ad.signCount,
+		Transports: resp.Response.Transports,
+	}, nil
+}
+
+// VerifyAssertion checks the response of the authentication ceremony started with the challenge against the stored
+// credential and returns the new signature counter. If requireUserVerification is set, the authenticator must have
+// verified the user (PIN, biometrics), which is needed if the passkey is the only factor.
+func (rp RelyingParty) VerifyAssertion(challenge []byte, resp AssertionResponse, publicKey []byte, signCount uint32, requireUserVerification bool) (uint32, error) {
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, ceremonyGet, challenge); err != nil {
+		return 0, err
+	}
+	ad, err := rp.parseAuthenticatorData(resp.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	if requireUserVerification && ad.flags&flagUserVerified == 0 {
+		return 0, fmt.Errorf("%w: user not verified", ErrVerification)
+	}
+
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(resp.Response.ClientDataJSON)
+	signed := append(append([]byte{}, resp.Response.AuthenticatorData...), clientDataHash[:]...)
+	if err := key.verify(signed, resp.Response.Signature); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrVerification, err)
+	}
+
+	// authenticators without counter always send 0
+	if (ad.signCount != 0 || signCount != 0) && ad.signCount <= signCount {
+		return 0, ErrSignCount
+	}
+	return ad.signCount, nil
+}
@@ -0,0 +1,139 @@
+package webauthn_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
+	"github.com/influenzanet/user-management-service/pkg/webauthn/webauthntest"
+)
+
+var testRP = webauthn.RelyingParty{ID: "example.com", Name: "Example", Origins: []string{"https://www.example.com"}}
+
+func register(t *testing.T, a *webauthntest.Authenticator, challenge []byte) webauthn.RegistrationResponse {
+	opts, _ := json.Marshal(testRP.CreationOptions(challenge, webauthn.UserEntity{ID: []byte("user1"), Name: "user@example.com"}, nil))
+	cred, err := a.Register(string(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := webauthn.ParseRegistrationResponse(cred)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func login(t *testing.T, a *webauthntest.Authenticator, challenge []byte) webauthn.AssertionResponse {
+	opts, _ := json.Marshal(testRP.RequestOptions(challenge, nil))
+	cred, err := a.Login(string(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := webauthn.ParseAssertionResponse(cred)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestRegistration(t *testing.T) {
+	challenge, _ := webauthn.NewChallenge()
+
+	t.Run("valid response", func(t *testing.T) {
+		a, _ := webauthntest.New(testRP.ID, testRP.Origins[0])
+		cred, err := testRP.VerifyRegistration(challenge, register(t, a, challenge))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if string(cred.ID) != string(a.CredentialID) || len(cred.PublicKey) == 0 || cred.Transports[0] != "internal" {
+			t.Errorf("unexpected credential: %v", cred)
+		}
+	})
+
+	t.Run("other challenge", func(t *testing.T) {
+		a, _ := webauthntest.New(testRP.ID, testRP.Origins[0])
+		other, _ := webauthn.NewChallenge()
+		if _, err := testRP.VerifyRegistration(challenge, register(t, a, other)); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("other origin", func(t *testing.T) {
+		a, _ := webauthntest.New(testRP.ID, "https://evil.example.org")
+		if _, err := testRP.VerifyRegistration(challenge, register(t, a, challenge)); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("other relying party", func(t *testing.T) {
+		a, _ := webauthntest.New("evil.example.org", testRP.Origins[0])
+		if _, err := testRP.VerifyRegistration(challenge, register(t, a, challenge)); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAssertion(t *testing.T) {
+	a, _ := webauthntest.New(testRP.ID, testRP.Origins[0])
+	challenge, _ := webauthn.NewChallenge()
+	cred, err := testRP.VerifyRegistration(challenge, register(t, a, challenge))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid response", func(t *testing.T) {
+		challenge, _ := webauthn.NewChallenge()
+		signCount, err := testRP.VerifyAssertion(challenge, login(t, a, challenge), cred.PublicKey, cred.SignCount, true)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if signCount != a.SignCount {
+			t.Errorf("unexpected sign count: %d", signCount)
+		}
+		cred.SignCount = signCount
+	})
+
+	t.Run("sign count did not increase", func(t *testing.T) {
+		challenge, _ := webauthn.NewChallenge()
+		resp := login(t, a, challenge)
+		if _, err := testRP.VerifyAssertion(challenge, resp, cred.PublicKey, cred.SignCount+1, true); err != webauthn.ErrSignCount {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("other challenge", func(t *testing.T) {
+		challenge, _ := webauthn.NewChallenge()
+		other, _ := webauthn.NewChallenge()
+		if _, err := testRP.VerifyAssertion(challenge, login(t, a, other), cred.PublicKey, cred.SignCount, true); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		other, _ := webauthntest.New(testRP.ID, testRP.Origins[0])
+		otherCred, err := testRP.VerifyRegistration(challenge, register(t, other, challenge))
+		if err != nil {
+			t.Fatal(err)
+		}
+		challenge, _ := webauthn.NewChallenge()
+		if _, err := testRP.VerifyAssertion(challenge, login(t, a, challenge), otherCred.PublicKey, 0, true); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("user verification required", func(t *testing.T) {
+		a.UserVerified = false
+		defer func() { a.UserVerified = true }()
+		challenge, _ := webauthn.NewChallenge()
+		resp := login(t, a, challenge)
+		if _, err := testRP.VerifyAssertion(challenge, resp, cred.PublicKey, 0, true); !errors.Is(err, webauthn.ErrVerification) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := testRP.VerifyAssertion(challenge, resp, cred.PublicKey, 0, false); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
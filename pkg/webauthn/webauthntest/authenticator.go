@@ -0,0 +1,148 @@
+// Package webauthntest provides a software authenticator that answers the ceremonies like a browser would, for tests
+// of the registration and login flows.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/influenzanet/user-management-service/pkg/webauthn"
+)
+
+// Authenticator holds a single ES256 credential
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// UserVerified is reported in the flags of the authenticator data
+	UserVerified bool
+
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+	key          *ecdsa.PrivateKey
+}
+
+// New creates an authenticator with a fresh key pair
+func New(rpID string, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, UserVerified: true, CredentialID: id, key: key}, nil
+}
+
+func (a *Authenticator) clientData(ceremony string, challenge []byte) []byte {
+	cd, _ := json.Marshal(map[string]interface{}{
+		"type":      ceremony,
+		"challenge": webauthn.EncodeID(challenge),
+		"origin":    a.Origin,
+	})
+	return cd
+}
+
+func (a *Authenticator) authData(attestedData []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	flags := byte(0x01)
+	if a.UserVerified {
+		flags |= 0x04
+	}
+	if attestedData != nil {
+		flags |= 0x40
+	}
+	data := append(rpIDHash[:], flags)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], a.SignCount)
+	return append(data, attestedData...)
+}
+
+func (a *Authenticator) publicKey() []byte {
+	key, _ := cbor.Marshal(map[int]interface{}{
+		1:  2,
+		3:  webauthn.AlgES256,
+		-1: 1,
+		-2: padded(a.key.X.Bytes()),
+		-3: padded(a.key.Y.Bytes()),
+	})
+	return key
+}
+
+func padded(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+// Register answers the creation options (as returned to the client) and returns the credential as JSON
+func (a *Authenticator) Register(options string) (string, error) {
+	var opts webauthn.CreationOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return "", err
+	}
+	a.UserHandle = opts.User.ID
+
+	attested := make([]byte, 16, 18)
+	attested = append(attested, byte(len(a.CredentialID)>>8), byte(len(a.CredentialID)))
+	attested = append(attested, a.CredentialID...)
+	attested = append(attested, a.publicKey()...)
+
+	att, err := cbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(attested),
+	})
+	if err != nil {
+		return "", err
+	}
+	resp := map[string]interface{}{
+		"id":    webauthn.EncodeID(a.CredentialID),
+		"rawId": webauthn.EncodeID(a.CredentialID),
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    webauthn.EncodeID(a.clientData("webauthn.create", opts.Challenge)),
+			"attestationObject": webauthn.EncodeID(att),
+			"transports":        []string{"internal"},
+		},
+	}
+	b, err := json.Marshal(resp)
+	return string(b), err
+}
+
+// Login answers the request options (as returned to the client) and returns the credential as JSON. The signature
+// counter is incremented.
+func (a *Authenticator) Login(options string) (string, error) {
+	var opts webauthn.RequestOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return "", err
+	}
+	a.SignCount++
+
+	authData := a.authData(nil)
+	cd := a.clientData("webauthn.get", opts.Challenge)
+	cdHash := sha256.Sum256(cd)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), cdHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	resp := map[string]interface{}{
+		"id":    webauthn.EncodeID(a.CredentialID),
+		"rawId": webauthn.EncodeID(a.CredentialID),
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    webauthn.EncodeID(cd),
+			"authenticatorData": webauthn.EncodeID(authData),
+			"signature":         webauthn.EncodeID(sig),
+			"userHandle":        webauthn.EncodeID(a.UserHandle),
+		},
+	}
+	b, err := json.Marshal(resp)
+	return string(b), err
+}
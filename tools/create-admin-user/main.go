@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -21,7 +22,9 @@ import (
 	"github.com/influenzanet/user-management-service/pkg/pwhash"
 	"github.com/influenzanet/user-management-service/pkg/utils"
 
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
 )
 
 type UserRequest struct {
@@ -55,7 +58,7 @@ func reqfromCLI() (UserRequest, error) {
 
 func init() {
 	conf := getDBConfig()
-	userDBService = userdb.NewUserDBService(conf)
+	userDBService = userdb.NewUserDBService(conf, clock.Real, getFieldEncryption())
 }
 
 func main() {
@@ -111,14 +114,14 @@ func main() {
 			CreatedAt: time.Now().Unix(),
 		},
 	}
-	newUser.AddNewEmail(req.email, true)
+	newUser.AddNewEmail(req.email, true, time.Now().Unix())
 	newUser.ContactPreferences.SubscribedToNewsletter = true
 	newUser.ContactPreferences.SendNewsletterTo = []string{newUser.ContactInfos[0].ID.Hex()}
 	newUser.ContactPreferences.SubscribedToWeekly = true
 	newUser.ContactPreferences.ReceiveWeeklyMessageDayOfWeek = int32(rand.Intn(7))
 
 	instanceID := req.instanceID
-	id, err := userDBService.AddUser(instanceID, newUser)
+	id, err := userDBService.AddUser(context.Background(), instanceID, newUser)
 	if err != nil {
 		logger.Error.Fatal(err.Error())
 	}
@@ -194,3 +197,16 @@ func getDBConfig() models.DBConfig {
 		DBNamePrefix:    DBNamePrefix,
 	}
 }
+
+// getFieldEncryption reads the keys for encrypting personal data from the environment, nil if not configured
+func getFieldEncryption() *fieldcrypt.Keyring {
+	keyring, err := fieldcrypt.ParseKeyring(
+		os.Getenv("FIELD_ENCRYPTION_KEYS"),
+		os.Getenv("FIELD_ENCRYPTION_CURRENT_KEY"),
+		os.Getenv("FIELD_ENCRYPTION_INDEX_KEY"),
+	)
+	if err != nil {
+		logger.Error.Fatal("field encryption: " + err.Error())
+	}
+	return keyring
+}
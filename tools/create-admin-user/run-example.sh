@@ -8,5 +8,10 @@ export DB_IDLE_CONN_TIMEOUT=45
 export DB_MAX_POOL_SIZE=8
 export DB_DB_NAME_PREFIX="<db name prefix if any used>"
 
+# only needed if field encryption is enabled
+export FIELD_ENCRYPTION_KEYS="<key-id>:<base64 key>"
+export FIELD_ENCRYPTION_CURRENT_KEY="<key-id>"
+export FIELD_ENCRYPTION_INDEX_KEY="<base64 key>"
+
 
 go run main.go "$@"
\ No newline at end of file
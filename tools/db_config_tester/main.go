@@ -11,6 +11,7 @@ import (
 
 	"github.com/coneno/logger"
 	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/user-management-service/pkg/clock"
 	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
 	"github.com/influenzanet/user-management-service/pkg/models"
 	"github.com/influenzanet/user-management-service/pkg/tokens"
@@ -30,7 +31,7 @@ var benchmarkTimes []int64
 
 func init() {
 	conf := getDBConfig()
-	userDB = userdb.NewUserDBService(conf)
+	userDB = userdb.NewUserDBService(conf, clock.Real, nil)
 }
 
 func main() {
@@ -51,8 +52,9 @@ func parseFlags() {
 }
 
 func generateUsers(usersToGenerate int) {
+	ctx := context.Background()
 	for a := 0; a < usersToGenerate; a++ {
-		_, err := userDB.AddUser(INSTANCE_ID, generateRandomUser())
+		_, err := userDB.AddUser(ctx, INSTANCE_ID, generateRandomUser())
 		if err != nil {
 			logger.Error.Fatal(err)
 		}
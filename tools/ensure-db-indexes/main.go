@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/dbs/indexes"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func main() {
+	instanceF := flag.String("instance", "", "Check only the user DB of this instance. If empty, all instances from the global DB are checked.")
+	checkOnly := flag.Bool("check", false, "Only report missing or differing indexes, without creating them.")
+	flag.Parse()
+	ctx := context.Background()
+
+	userDBService := userdb.NewUserDBService(getDBConfig("USER"), clock.Real, nil)
+	globalDBService := globaldb.NewGlobalDBService(getDBConfig("GLOBAL"), nil)
+
+	reports, err := globalDBService.EnsureIndexes(ctx, *checkOnly)
+	if err != nil {
+		logger.Error.Fatal(err.Error())
+	}
+
+	instanceIDs := []string{*instanceF}
+	if *instanceF == "" {
+		instances, err := globalDBService.GetAllInstances(ctx)
+		if err != nil {
+			logger.Error.Fatal(err.Error())
+		}
+		instanceIDs = []string{}
+		for _, instance := range instances {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+	}
+	for _, instanceID := range instanceIDs {
+		r, err := userDBService.EnsureIndexes(ctx, instanceID, *checkOnly)
+		if err != nil {
+			logger.Error.Fatal(err.Error())
+		}
+		reports = append(reports, r)
+	}
+
+	drift := false
+	for _, r := range reports {
+		printReport(r, *checkOnly)
+		drift = drift || r.HasDrift()
+	}
+	if drift && *checkOnly {
+		os.Exit(1)
+	}
+}
+
+func printReport(r indexes.Report, checkOnly bool) {
+	if !r.HasDrift() {
+		fmt.Printf("%s: ok\n", r.Collection)
+		return
+	}
+	missing := "created"
+	if checkOnly {
+		missing = "missing"
+	}
+	fmt.Printf("%s: %d %s, %d with different options %v, %d unknown %v\n",
+		r.Collection, len(r.Missing), missing, len(r.Mismatched), r.Mismatched, len(r.Unknown), r.Unknown)
+}
+
+// getDBConfig reads the config of the USER or GLOBAL DB from the environment
+func getDBConfig(db string) models.DBConfig {
+	connStr := os.Getenv(db + "_DB_CONNECTION_STR")
+	username := os.Getenv(db + "_DB_USERNAME")
+	password := os.Getenv(db + "_DB_PASSWORD")
+	prefix := os.Getenv(db + "_DB_CONNECTION_PREFIX") // Used in test mode
+	URI := fmt.Sprintf(`mongodb%s://%s:%s@%s`, prefix, username, password, connStr)
+	if username == "" || password == "" {
+		URI = fmt.Sprintf(`mongodb%s://%s`, prefix, connStr)
+	}
+
+	var err error
+	Timeout, err := strconv.Atoi(os.Getenv("DB_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_TIMEOUT: " + err.Error())
+	}
+	IdleConnTimeout, err := strconv.Atoi(os.Getenv("DB_IDLE_CONN_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_IDLE_CONN_TIMEOUT" + err.Error())
+	}
+	mps, err := strconv.Atoi(os.Getenv("DB_MAX_POOL_SIZE"))
+	MaxPoolSize := uint64(mps)
+	if err != nil {
+		logger.Error.Fatal("DB_MAX_POOL_SIZE: " + err.Error())
+	}
+
+	noCursorTimeout := os.Getenv("USE_NO_CURSOR_TIMEOUT") == "true"
+
+	DBNamePrefix := os.Getenv("DB_DB_NAME_PREFIX")
+
+	return models.DBConfig{
+		URI:             URI,
+		Timeout:         Timeout,
+		IdleConnTimeout: IdleConnTimeout,
+		NoCursorTimeout: noCursorTimeout,
+		MaxPoolSize:     MaxPoolSize,
+		DBNamePrefix:    DBNamePrefix,
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func main() {
+	instanceF := flag.String("instance", "", "Re-encrypt only the users of this instance ID. If empty, all instances from the global DB are processed.")
+	dryRun := flag.Bool("dry-run", false, "Only count the documents that would be re-encrypted, without writing to the DB.")
+	flag.Parse()
+
+	keyring := getFieldEncryption()
+	if keyring == nil {
+		logger.Error.Fatal("FIELD_ENCRYPTION_KEYS is not set, nothing to encrypt")
+	}
+	ctx := context.Background()
+
+	userDBService := userdb.NewUserDBService(getDBConfig("USER"), clock.Real, keyring)
+	globalDBService := globaldb.NewGlobalDBService(getDBConfig("GLOBAL"), keyring)
+
+	count, err := globalDBService.ReencryptTempTokens(ctx, *dryRun)
+	if err != nil {
+		logger.Error.Fatal(err.Error())
+	}
+	fmt.Printf("temp tokens: %d re-encrypted\n", count)
+
+	instanceIDs := []string{*instanceF}
+	if *instanceF == "" {
+		instances, err := globalDBService.GetAllInstances(ctx)
+		if err != nil {
+			logger.Error.Fatal(err.Error())
+		}
+		instanceIDs = []string{}
+		for _, instance := range instances {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+	}
+	for _, instanceID := range instanceIDs {
+		count, err := userDBService.ReencryptUsers(ctx, instanceID, *dryRun)
+		if err != nil {
+			logger.Error.Fatal(instanceID + ": " + err.Error())
+		}
+		fmt.Printf("%s: %d users re-encrypted\n", instanceID, count)
+	}
+	if *dryRun {
+		fmt.Println("dry-run: no changes were written")
+	}
+}
+
+// getDBConfig reads the config of the USER or GLOBAL DB from the environment
+func getDBConfig(db string) models.DBConfig {
+	connStr := os.Getenv(db + "_DB_CONNECTION_STR")
+	username := os.Getenv(db + "_DB_USERNAME")
+	password := os.Getenv(db + "_DB_PASSWORD")
+	prefix := os.Getenv(db + "_DB_CONNECTION_PREFIX") // Used in test mode
+	URI := fmt.Sprintf(`mongodb%s://%s:%s@%s`, prefix, username, password, connStr)
+	if username == "" || password == "" {
+		URI = fmt.Sprintf(`mongodb%s://%s`, prefix, connStr)
+	}
+
+	var err error
+	Timeout, err := strconv.Atoi(os.Getenv("DB_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_TIMEOUT: " + err.Error())
+	}
+	IdleConnTimeout, err := strconv.Atoi(os.Getenv("DB_IDLE_CONN_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_IDLE_CONN_TIMEOUT" + err.Error())
+	}
+	mps, err := strconv.Atoi(os.Getenv("DB_MAX_POOL_SIZE"))
+	MaxPoolSize := uint64(mps)
+	if err != nil {
+		logger.Error.Fatal("DB_MAX_POOL_SIZE: " + err.Error())
+	}
+
+	noCursorTimeout := os.Getenv("USE_NO_CURSOR_TIMEOUT") == "true"
+
+	DBNamePrefix := os.Getenv("DB_DB_NAME_PREFIX")
+
+	return models.DBConfig{
+		URI:             URI,
+		Timeout:         Timeout,
+		IdleConnTimeout: IdleConnTimeout,
+		NoCursorTimeout: noCursorTimeout,
+		MaxPoolSize:     MaxPoolSize,
+		DBNamePrefix:    DBNamePrefix,
+	}
+}
+
+// getFieldEncryption reads the keys for encrypting personal data from the environment, nil if not configured
+func getFieldEncryption() *fieldcrypt.Keyring {
+	keyring, err := fieldcrypt.ParseKeyring(
+		os.Getenv("FIELD_ENCRYPTION_KEYS"),
+		os.Getenv("FIELD_ENCRYPTION_CURRENT_KEY"),
+		os.Getenv("FIELD_ENCRYPTION_INDEX_KEY"),
+	)
+	if err != nil {
+		logger.Error.Fatal("field encryption: " + err.Error())
+	}
+	return keyring
+}
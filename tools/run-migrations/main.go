@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/coneno/logger"
+	"github.com/influenzanet/user-management-service/pkg/clock"
+	"github.com/influenzanet/user-management-service/pkg/dbs/globaldb"
+	"github.com/influenzanet/user-management-service/pkg/dbs/userdb"
+	"github.com/influenzanet/user-management-service/pkg/fieldcrypt"
+	"github.com/influenzanet/user-management-service/pkg/migrations"
+	"github.com/influenzanet/user-management-service/pkg/models"
+)
+
+func main() {
+	instanceF := flag.String("instance", "", "Run migrations only for this instance ID. If empty, all instances from the global DB are migrated.")
+	dryRun := flag.Bool("dry-run", false, "Only report how many users would be changed, without writing to the DB.")
+	flag.Parse()
+
+	userDBService := userdb.NewUserDBService(getDBConfig("USER"), clock.Real, getFieldEncryption())
+
+	var globalDBService *globaldb.GlobalDBService
+	if *instanceF == "" {
+		globalDBService = globaldb.NewGlobalDBService(getDBConfig("GLOBAL"), nil)
+	}
+	runner := migrations.NewRunner(userDBService, globalDBService, migrations.UserMigrations)
+
+	var (
+		results []migrations.Result
+		err     error
+	)
+	if *instanceF != "" {
+		results, err = runner.RunForInstance(context.Background(), *instanceF, *dryRun)
+	} else {
+		results, err = runner.Run(context.Background(), *dryRun)
+	}
+
+	for _, r := range results {
+		fmt.Println(r)
+	}
+	if len(results) == 0 {
+		fmt.Println("user schema up to date")
+	}
+	if err != nil {
+		logger.Error.Fatal(err.Error())
+	}
+	if *dryRun {
+		fmt.Println("dry-run: no changes were written")
+	}
+}
+
+// getDBConfig reads the config of the USER or GLOBAL DB from the environment
+func getDBConfig(db string) models.DBConfig {
+	connStr := os.Getenv(db + "_DB_CONNECTION_STR")
+	username := os.Getenv(db + "_DB_USERNAME")
+	password := os.Getenv(db + "_DB_PASSWORD")
+	prefix := os.Getenv(db + "_DB_CONNECTION_PREFIX") // Used in test mode
+	URI := fmt.Sprintf(`mongodb%s://%s:%s@%s`, prefix, username, password, connStr)
+	if username == "" || password == "" {
+		URI = fmt.Sprintf(`mongodb%s://%s`, prefix, connStr)
+	}
+
+	var err error
+	Timeout, err := strconv.Atoi(os.Getenv("DB_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_TIMEOUT: " + err.Error())
+	}
+	IdleConnTimeout, err := strconv.Atoi(os.Getenv("DB_IDLE_CONN_TIMEOUT"))
+	if err != nil {
+		logger.Error.Fatal("DB_IDLE_CONN_TIMEOUT" + err.Error())
+	}
+	mps, err := strconv.Atoi(os.Getenv("DB_MAX_POOL_SIZE"))
+	MaxPoolSize := uint64(mps)
+	if err != nil {
+		logger.Error.Fatal("DB_MAX_POOL_SIZE: " + err.Error())
+	}
+
+	noCursorTimeout := os.Getenv("USE_NO_CURSOR_TIMEOUT") == "true"
+
+	DBNamePrefix := os.Getenv("DB_DB_NAME_PREFIX")
+
+	return models.DBConfig{
+		URI:             URI,
+		Timeout:         Timeout,
+		IdleConnTimeout: IdleConnTimeout,
+		NoCursorTimeout: noCursorTimeout,
+		MaxPoolSize:     MaxPoolSize,
+		DBNamePrefix:    DBNamePrefix,
+	}
+}
+
+// getFieldEncryption reads the keys for encrypting personal data from the environment, nil if not configured
+func getFieldEncryption() *fieldcrypt.Keyring {
+	keyring, err := fieldcrypt.ParseKeyring(
+		os.Getenv("FIELD_ENCRYPTION_KEYS"),
+		os.Getenv("FIELD_ENCRYPTION_CURRENT_KEY"),
+		os.Getenv("FIELD_ENCRYPTION_INDEX_KEY"),
+	)
+	if err != nil {
+		logger.Error.Fatal("field encryption: " + err.Error())
+	}
+	return keyring
+}
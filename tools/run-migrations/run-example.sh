@@ -0,0 +1,23 @@
+export USER_DB_CONNECTION_STR="<db-address>"
+export USER_DB_USERNAME="<db-user-name>"
+export USER_DB_PASSWORD="<db-password>"
+export USER_DB_CONNECTION_PREFIX="<+srv or empty>"
+
+# only needed if no instance is given
+export GLOBAL_DB_CONNECTION_STR="<db-address>"
+export GLOBAL_DB_USERNAME="<db-user-name>"
+export GLOBAL_DB_PASSWORD="<db-password>"
+export GLOBAL_DB_CONNECTION_PREFIX="<+srv or empty>"
+
+export DB_TIMEOUT=30
+export DB_IDLE_CONN_TIMEOUT=45
+export DB_MAX_POOL_SIZE=8
+export DB_DB_NAME_PREFIX="<db name prefix if any used>"
+
+# only needed if field encryption is enabled
+export FIELD_ENCRYPTION_KEYS="<key-id>:<base64 key>"
+export FIELD_ENCRYPTION_CURRENT_KEY="<key-id>"
+export FIELD_ENCRYPTION_INDEX_KEY="<base64 key>"
+
+
+go run main.go "$@"